@@ -0,0 +1,86 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+type Alert struct {
+	ID         string
+	IncidentID string
+	ServiceID  string
+	CreatedAt  time.Time
+}
+
+// AlertIncidentLookback is how far before the period ListAlerts looks for incidents, as the alerts of the
+// period can be grouped into incidents created earlier. PagerDuty only filters the incidents by creation
+// date, so the alerts added to older incidents are missed.
+const AlertIncidentLookback = 30 * 24 * time.Hour
+
+// ListAlerts returns the alerts created between since and until in the incidents of the given services,
+// including the incidents created up to AlertIncidentLookback before since.
+// Unlike incidents, alerts aren't deduplicated, so they tell how noisy the services were.
+func (p *PagerDutyClient) ListAlerts(serviceIDs []string, since, until time.Time) ([]*Alert, error) {
+	opts := pagerduty.ListIncidentsOptions{
+		ServiceIDs: serviceIDs,
+		Since:      since.Add(-AlertIncidentLookback).Format(time.RFC3339),
+		Until:      until.Format(time.RFC3339),
+	}
+	var alertList []*Alert
+
+	more := true
+	for more {
+		listIncidentsResponse, err := p.ApiClient.ListIncidents(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, incident := range listIncidentsResponse.Incidents {
+			alerts, err := p.listIncidentAlerts(incident.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, alert := range alerts {
+				if !alert.CreatedAt.Before(since) && alert.CreatedAt.Before(until) {
+					alertList = append(alertList, alert)
+				}
+			}
+		}
+		more = listIncidentsResponse.More
+		opts.Offset += listIncidentsResponse.Limit
+	}
+
+	return alertList, nil
+}
+
+func (p *PagerDutyClient) listIncidentAlerts(incidentID string) ([]*Alert, error) {
+	var opts pagerduty.ListIncidentAlertsOptions
+	var alertList []*Alert
+
+	more := true
+	for more {
+		listAlertsResponse, err := p.ApiClient.ListIncidentAlertsWithOpts(incidentID, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the alerts of incident %s: %w", incidentID, err)
+		}
+
+		for _, alert := range listAlertsResponse.Alerts {
+			createdAt, err := time.Parse(time.RFC3339, alert.CreatedAt)
+			if err != nil {
+				return nil, fmt.Errorf("invalid creation date of alert %s: %w", alert.ID, err)
+			}
+			alertList = append(alertList, &Alert{
+				ID:         alert.ID,
+				IncidentID: incidentID,
+				ServiceID:  alert.Service.ID,
+				CreatedAt:  createdAt,
+			})
+		}
+		more = listAlertsResponse.More
+		opts.Offset += listAlertsResponse.Limit
+	}
+
+	return alertList, nil
+}
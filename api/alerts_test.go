@@ -0,0 +1,132 @@
+package api
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListAlerts(t *testing.T) {
+	since := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		clientSetup func(*clientMock)
+		want        []*Alert
+		wantErr     bool
+	}{
+		{
+			name: "Failed to list incidents",
+			clientSetup: func(clientMock *clientMock) {
+				clientMock.On("ListIncidents", mock.Anything).Once().Return(
+					nil, errors.New("failed to list incidents"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed to list the alerts of an incident",
+			clientSetup: func(clientMock *clientMock) {
+				clientMock.On("ListIncidents", mock.Anything).Once().Return(
+					&pagerduty.ListIncidentsResponse{
+						Incidents: []pagerduty.Incident{{APIObject: pagerduty.APIObject{ID: "INC_1"}}},
+					}, nil)
+				clientMock.On("ListIncidentAlertsWithOpts", "INC_1", mock.Anything).Once().Return(
+					nil, errors.New("failed to list alerts"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Successfully list the alerts of the period",
+			clientSetup: func(clientMock *clientMock) {
+				clientMock.On("ListIncidents", pagerduty.ListIncidentsOptions{
+					ServiceIDs: []string{"SERVICE_1"},
+					Since:      "2023-12-02T00:00:00Z",
+					Until:      "2024-02-01T00:00:00Z",
+				}).Once().Return(
+					&pagerduty.ListIncidentsResponse{
+						Incidents: []pagerduty.Incident{
+							{APIObject: pagerduty.APIObject{ID: "INC_0"}},
+							{APIObject: pagerduty.APIObject{ID: "INC_1"}},
+						},
+					}, nil)
+				// incident created before the period, with alerts grouped into it before and during the period
+				clientMock.On("ListIncidentAlertsWithOpts", "INC_0", mock.Anything).Once().Return(
+					&pagerduty.ListAlertsResponse{
+						Alerts: []pagerduty.IncidentAlert{
+							{
+								APIObject: pagerduty.APIObject{ID: "ALERT_0"},
+								CreatedAt: "2023-12-31T23:00:00Z",
+								Service:   pagerduty.APIObject{ID: "SERVICE_1"},
+							},
+							{
+								APIObject: pagerduty.APIObject{ID: "ALERT_3"},
+								CreatedAt: "2024-01-01T01:00:00Z",
+								Service:   pagerduty.APIObject{ID: "SERVICE_1"},
+							},
+						},
+					}, nil)
+				clientMock.On("ListIncidentAlertsWithOpts", "INC_1", mock.Anything).Once().Return(
+					&pagerduty.ListAlertsResponse{
+						Alerts: []pagerduty.IncidentAlert{
+							{
+								APIObject: pagerduty.APIObject{ID: "ALERT_1"},
+								CreatedAt: "2024-01-10T10:00:00Z",
+								Service:   pagerduty.APIObject{ID: "SERVICE_1"},
+							},
+							{
+								APIObject: pagerduty.APIObject{ID: "ALERT_2"},
+								CreatedAt: "2024-02-01T00:00:00Z",
+								Service:   pagerduty.APIObject{ID: "SERVICE_1"},
+							},
+						},
+					}, nil)
+			},
+			want: []*Alert{
+				{
+					ID:         "ALERT_3",
+					IncidentID: "INC_0",
+					ServiceID:  "SERVICE_1",
+					CreatedAt:  time.Date(2024, time.January, 1, 1, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:         "ALERT_1",
+					IncidentID: "INC_1",
+					ServiceID:  "SERVICE_1",
+					CreatedAt:  time.Date(2024, time.January, 10, 10, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedClient := &clientMock{}
+			if tt.clientSetup != nil {
+				tt.clientSetup(mockedClient)
+			}
+
+			pdClient := PagerDutyClient{ApiClient: mockedClient}
+			alerts, err := pdClient.ListAlerts([]string{"SERVICE_1"}, since, until)
+			mockedClient.AssertExpectations(t)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, alerts, len(tt.want))
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].ID, alerts[i].ID)
+				assert.Equal(t, tt.want[i].IncidentID, alerts[i].IncidentID)
+				assert.Equal(t, tt.want[i].ServiceID, alerts[i].ServiceID)
+				assert.True(t, tt.want[i].CreatedAt.Equal(alerts[i].CreatedAt))
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointStats holds the calls made to an endpoint of the PagerDuty API.
+type EndpointStats struct {
+	Endpoint     string // method and path, with the IDs replaced by {id}
+	Calls        int
+	TotalLatency time.Duration
+	MaxLatency   time.Duration
+}
+
+// CallStats records the calls made to each endpoint of the PagerDuty API.
+type CallStats struct {
+	mu        sync.Mutex
+	endpoints map[string]*EndpointStats
+}
+
+func NewCallStats() *CallStats {
+	return &CallStats{endpoints: make(map[string]*EndpointStats)}
+}
+
+// WithCallStats records every call to the PagerDuty API in the given stats.
+func WithCallStats(stats *CallStats) ClientOption {
+	return func(c *clientConfig) {
+		c.callStats = stats
+	}
+}
+
+func (s *CallStats) record(endpoint string, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats, ok := s.endpoints[endpoint]
+	if !ok {
+		stats = &EndpointStats{Endpoint: endpoint}
+		s.endpoints[endpoint] = stats
+	}
+	stats.Calls++
+	stats.TotalLatency += latency
+	if latency > stats.MaxLatency {
+		stats.MaxLatency = latency
+	}
+}
+
+// Endpoints returns the stats of the endpoints called, sorted by endpoint.
+func (s *CallStats) Endpoints() []EndpointStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	endpoints := make([]EndpointStats, 0, len(s.endpoints))
+	for _, stats := range s.endpoints {
+		endpoints = append(endpoints, *stats)
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].Endpoint < endpoints[j].Endpoint
+	})
+	return endpoints
+}
+
+// endpointName returns the method and path of the request, with the IDs replaced by {id} so all the
+// calls to an endpoint are counted together. The PagerDuty collections are lower case, unlike the IDs.
+func endpointName(req *http.Request) string {
+	segments := strings.Split(req.URL.Path, "/")
+	for i, segment := range segments {
+		if segment != strings.ToLower(segment) || strings.ContainsAny(segment, "0123456789") {
+			segments[i] = "{id}"
+		}
+	}
+	return req.Method + " " + strings.Join(segments, "/")
+}
+
+// callStatsTransport records the latency of every call in the stats.
+type callStatsTransport struct {
+	next  http.RoundTripper
+	stats *CallStats
+}
+
+func (t *callStatsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	t.stats.record(endpointName(req), time.Since(start))
+	return resp, err
+}
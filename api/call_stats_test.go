@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_callStatsTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	stats := NewCallStats()
+	client := newHTTPClient(&clientConfig{callStats: stats})
+	for _, path := range []string{"/schedules/PABC123", "/schedules/PDEF456", "/users?offset=25"} {
+		resp, err := client.Get(server.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	endpoints := stats.Endpoints()
+	require.Len(t, endpoints, 2)
+	assert.Equal(t, "GET /schedules/{id}", endpoints[0].Endpoint)
+	assert.Equal(t, 2, endpoints[0].Calls)
+	assert.GreaterOrEqual(t, endpoints[0].TotalLatency, 10*time.Millisecond)
+	assert.GreaterOrEqual(t, endpoints[0].MaxLatency, 5*time.Millisecond)
+	assert.Equal(t, "GET /users", endpoints[1].Endpoint)
+	assert.Equal(t, 1, endpoints[1].Calls)
+}
+
+func Test_endpointName(t *testing.T) {
+	tests := []struct {
+		method string
+		url    string
+		want   string
+	}{
+		{method: http.MethodGet, url: "https://api.pagerduty.com/schedules", want: "GET /schedules"},
+		{method: http.MethodGet, url: "https://api.pagerduty.com/escalation_policies/P1A2B3C", want: "GET /escalation_policies/{id}"},
+		{method: http.MethodGet, url: "https://api.pagerduty.com/incidents/Q0RIJJZL24RC6W/alerts", want: "GET /incidents/{id}/alerts"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, tt.url, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, endpointName(req))
+		})
+	}
+}
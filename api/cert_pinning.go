@@ -0,0 +1,39 @@
+package api
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// NormalizeCertFingerprint returns the lowercase hex form of a SHA-256 certificate fingerprint,
+// accepting the colon separated form printed by openssl.
+func NormalizeCertFingerprint(fingerprint string) (string, error) {
+	normalized := strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))
+	decoded, err := hex.DecodeString(normalized)
+	if err != nil || len(decoded) != sha256.Size {
+		return "", fmt.Errorf("%q is not a SHA-256 fingerprint", fingerprint)
+	}
+	return normalized, nil
+}
+
+// verifyPinnedCertificate returns a TLS connection check that fails unless the server
+// certificate matches one of the fingerprints, so no request is sent to an unexpected server.
+func verifyPinnedCertificate(fingerprints []string) func(tls.ConnectionState) error {
+	return func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 {
+			return fmt.Errorf("no certificate presented by %s", state.ServerName)
+		}
+
+		sum := sha256.Sum256(state.PeerCertificates[0].Raw)
+		fingerprint := hex.EncodeToString(sum[:])
+		for _, pinned := range fingerprints {
+			if fingerprint == pinned {
+				return nil
+			}
+		}
+		return fmt.Errorf("certificate of %s with fingerprint %s is not pinned", state.ServerName, fingerprint)
+	}
+}
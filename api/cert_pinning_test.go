@@ -0,0 +1,54 @@
+package api
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newHTTPClient_pinnedCertificates(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	sum := sha256.Sum256(server.Certificate().Raw)
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	tests := []struct {
+		name         string
+		fingerprints []string
+		wantErr      bool
+	}{
+		{name: "pinned", fingerprints: []string{hex.EncodeToString(sum[:])}},
+		{name: "one of the pinned", fingerprints: []string{hex.EncodeToString(make([]byte, sha256.Size)), hex.EncodeToString(sum[:])}},
+		{name: "not pinned", fingerprints: []string{hex.EncodeToString(make([]byte, sha256.Size))}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newHTTPClient(&clientConfig{pinnedCertificates: tt.fingerprints})
+			client.Transport.(*http.Transport).TLSClientConfig.RootCAs = roots
+
+			resp, err := client.Get(server.URL)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestNormalizeCertFingerprint(t *testing.T) {
+	fingerprint, err := NormalizeCertFingerprint("AB:" + hex.EncodeToString(make([]byte, sha256.Size-1)))
+	require.NoError(t, err)
+	assert.Equal(t, "ab"+hex.EncodeToString(make([]byte, sha256.Size-1)), fingerprint)
+
+	_, err = NormalizeCertFingerprint("abcd")
+	require.Error(t, err)
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+// dnsCache caches host lookups for a fixed time. The standard resolver doesn't expose the
+// TTL of the records, so a short TTL keeps the cached addresses from going stale.
+type dnsCache struct {
+	resolver *net.Resolver
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]dnsCacheEntry
+}
+
+type dnsCacheEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+func newDNSCache(ttl time.Duration) *dnsCache {
+	return &dnsCache{
+		resolver: net.DefaultResolver,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  make(map[string]dnsCacheEntry),
+	}
+}
+
+func (c *dnsCache) lookupHost(ctx context.Context, host string) ([]string, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[host]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expires) {
+		return entry.addrs, nil
+	}
+
+	addrs, err := c.resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[host] = dnsCacheEntry{addrs: addrs, expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+	return addrs, nil
+}
+
+// dialContext resolves the host through the cache, then dials its addresses in turn. Like the standard
+// dialer, each address gets an equal share of the time left to connect, so an unreachable address
+// doesn't use up the whole dial timeout.
+func (c *dnsCache) dialContext(dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil || net.ParseIP(host) != nil {
+			return dialer.DialContext(ctx, network, address)
+		}
+
+		addrs, err := c.lookupHost(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		deadline := dialDeadline(ctx, dialer, time.Now())
+		var conn net.Conn
+		for i, addr := range addrs {
+			dialCtx, cancel := ctx, func() {}
+			if !deadline.IsZero() {
+				dialCtx, cancel = context.WithDeadline(ctx, partialDeadline(time.Now(), deadline, len(addrs)-i))
+			}
+			conn, err = dialer.DialContext(dialCtx, network, net.JoinHostPort(addr, port))
+			cancel()
+			if err == nil {
+				return conn, nil
+			}
+		}
+		return nil, err
+	}
+}
+
+// dialDeadline returns the earliest of the context deadline and those of the dialer, zero if there is none.
+func dialDeadline(ctx context.Context, dialer *net.Dialer, now time.Time) time.Time {
+	deadline := dialer.Deadline
+	if dialer.Timeout > 0 && (deadline.IsZero() || now.Add(dialer.Timeout).Before(deadline)) {
+		deadline = now.Add(dialer.Timeout)
+	}
+	if ctxDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+		deadline = ctxDeadline
+	}
+	return deadline
+}
+
+// minAddressDialTime is the least time an address is given to connect when there is enough left, as in
+// the standard dialer.
+const minAddressDialTime = 2 * time.Second
+
+// partialDeadline returns the deadline to dial one of the addresses left, sharing the time to the deadline
+// equally between them.
+func partialDeadline(now, deadline time.Time, addrsRemaining int) time.Time {
+	timeRemaining := deadline.Sub(now)
+	timeout := timeRemaining / time.Duration(addrsRemaining)
+	if timeout < minAddressDialTime {
+		if timeRemaining < minAddressDialTime {
+			timeout = timeRemaining
+		} else {
+			timeout = minAddressDialTime
+		}
+	}
+	return now.Add(timeout)
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_dnsCache_dialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newDNSCache(30 * time.Second)
+	cache.now = func() time.Time { return now }
+	cache.entries["api.pagerduty.test"] = dnsCacheEntry{addrs: []string{"127.0.0.1"}, expires: now.Add(time.Second)}
+
+	conn, err := cache.dialContext(&net.Dialer{})(context.Background(), "tcp", net.JoinHostPort("api.pagerduty.test", port))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	assert.Equal(t, server.Listener.Addr().String(), conn.RemoteAddr().String())
+}
+
+func Test_dnsCache_lookupHost(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newDNSCache(30 * time.Second)
+	cache.now = func() time.Time { return now }
+	cache.entries["api.pagerduty.test"] = dnsCacheEntry{addrs: []string{"10.0.0.1"}, expires: now.Add(time.Second)}
+
+	addrs, err := cache.lookupHost(context.Background(), "api.pagerduty.test")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1"}, addrs)
+
+	now = now.Add(time.Minute)
+	_, err = cache.lookupHost(context.Background(), "api.pagerduty.test")
+	require.Error(t, err)
+}
+
+func Test_dnsCache_dialContext_fallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newDNSCache(30 * time.Second)
+	cache.now = func() time.Time { return now }
+	// 192.0.2.0/24 is reserved for documentation, nothing answers there
+	cache.entries["api.pagerduty.test"] = dnsCacheEntry{addrs: []string{"192.0.2.1", "127.0.0.1"}, expires: now.Add(time.Second)}
+
+	conn, err := cache.dialContext(&net.Dialer{Timeout: 4 * time.Second})(context.Background(), "tcp", net.JoinHostPort("api.pagerduty.test", port))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	assert.Equal(t, server.Listener.Addr().String(), conn.RemoteAddr().String())
+}
+
+func Test_partialDeadline(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		deadline       time.Time
+		addrsRemaining int
+		want           time.Time
+	}{
+		{name: "Shared equally", deadline: now.Add(30 * time.Second), addrsRemaining: 3, want: now.Add(10 * time.Second)},
+		{name: "Last address", deadline: now.Add(30 * time.Second), addrsRemaining: 1, want: now.Add(30 * time.Second)},
+		{name: "Minimum time per address", deadline: now.Add(5 * time.Second), addrsRemaining: 5, want: now.Add(2 * time.Second)},
+		{name: "Less than the minimum left", deadline: now.Add(time.Second), addrsRemaining: 2, want: now.Add(time.Second)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, partialDeadline(now, tt.deadline, tt.addrsRemaining))
+		})
+	}
+}
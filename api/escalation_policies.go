@@ -0,0 +1,27 @@
+package api
+
+import "github.com/PagerDuty/go-pagerduty"
+
+type EscalationPolicy struct {
+	ID         string
+	Name       string
+	ServiceIDs []string
+}
+
+func (p *PagerDutyClient) GetEscalationPolicy(escalationPolicyID string) (*EscalationPolicy, error) {
+	escalationPolicy, err := p.ApiClient.GetEscalationPolicy(escalationPolicyID, &pagerduty.GetEscalationPolicyOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var serviceIDs []string
+	for _, service := range escalationPolicy.Services {
+		serviceIDs = append(serviceIDs, service.ID)
+	}
+
+	return &EscalationPolicy{
+		ID:         escalationPolicy.ID,
+		Name:       escalationPolicy.Name,
+		ServiceIDs: serviceIDs,
+	}, nil
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetEscalationPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		clientSetup func(*clientMock)
+		want        *EscalationPolicy
+		wantErr     bool
+	}{
+		{
+			name: "Failed to get escalation policy",
+			clientSetup: func(clientMock *clientMock) {
+				clientMock.On("GetEscalationPolicy", "QWERTY", mock.Anything).Once().Return(
+					nil, errors.New("failed to get escalation policy"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Successfully get escalation policy",
+			clientSetup: func(clientMock *clientMock) {
+				clientMock.On("GetEscalationPolicy", "QWERTY", mock.Anything).Once().Return(
+					&pagerduty.EscalationPolicy{
+						APIObject: pagerduty.APIObject{
+							ID: "QWERTY",
+						},
+						Name: "Policy 1",
+						Services: []pagerduty.APIObject{
+							{ID: "SERVICE_1"},
+							{ID: "SERVICE_2"},
+						},
+					}, nil)
+			},
+			want: &EscalationPolicy{
+				ID:         "QWERTY",
+				Name:       "Policy 1",
+				ServiceIDs: []string{"SERVICE_1", "SERVICE_2"},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedClient := &clientMock{}
+			if tt.clientSetup != nil {
+				tt.clientSetup(mockedClient)
+			}
+
+			pdClient := PagerDutyClient{ApiClient: mockedClient}
+			escalationPolicy, err := pdClient.GetEscalationPolicy("QWERTY")
+			mockedClient.AssertExpectations(t)
+
+			if tt.wantErr == true {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, escalationPolicy)
+		})
+	}
+}
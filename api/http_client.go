@@ -0,0 +1,346 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// ClientOption configures the HTTP client used for the PagerDuty API calls.
+type ClientOption func(*clientConfig)
+
+type clientConfig struct {
+	gzip  bool
+	http2 bool
+
+	maxIdleConns    int
+	maxConnsPerHost int
+	idleConnTimeout time.Duration
+
+	dnsCacheTTL time.Duration
+
+	userAgent      string
+	idempotencyKey bool
+
+	proxy              *url.URL
+	pinnedCertificates []string
+	insecureSkipVerify bool
+
+	requestLog io.Writer
+	callStats  *CallStats
+	debugLog   bool
+
+	apiEndpoint string
+}
+
+// WithGzip requests gzip compressed responses from the PagerDuty API.
+func WithGzip() ClientOption {
+	return func(c *clientConfig) {
+		c.gzip = true
+	}
+}
+
+// WithHTTP2 negotiates HTTP/2 with the PagerDuty API, which the go-pagerduty default client doesn't.
+func WithHTTP2() ClientOption {
+	return func(c *clientConfig) {
+		c.http2 = true
+	}
+}
+
+// WithConnectionPool sizes the pool of connections to the PagerDuty API. Zero values keep the
+// go-pagerduty defaults.
+func WithConnectionPool(maxIdleConns, maxConnsPerHost int, idleConnTimeout time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.maxIdleConns = maxIdleConns
+		c.maxConnsPerHost = maxConnsPerHost
+		c.idleConnTimeout = idleConnTimeout
+	}
+}
+
+// WithDNSCache caches the lookups of the PagerDuty API host for the given time.
+func WithDNSCache(ttl time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.dnsCacheTTL = ttl
+	}
+}
+
+// WithDebugLog logs the connection pool settings, the protocol negotiated by each new connection
+// with HTTP/2 and the compression ratio of the gzip responses.
+func WithDebugLog() ClientOption {
+	return func(c *clientConfig) {
+		c.debugLog = true
+	}
+}
+
+// WithUserAgent sends the given User-Agent header instead of the go-pagerduty one.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *clientConfig) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithIdempotencyKey sends an X-Idempotency-Key header, derived from a hash of the request, on
+// the calls that modify data, so retried requests aren't applied twice.
+func WithIdempotencyKey() ClientOption {
+	return func(c *clientConfig) {
+		c.idempotencyKey = true
+	}
+}
+
+// WithProxy sends the PagerDuty API calls through the given proxy, except for the hosts listed
+// in NO_PROXY. Without it the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables apply.
+func WithProxy(proxy *url.URL) ClientOption {
+	return func(c *clientConfig) {
+		c.proxy = proxy
+	}
+}
+
+// WithPinnedCertificates only accepts PagerDuty API certificates with one of the given
+// SHA-256 fingerprints, normalized with NormalizeCertFingerprint.
+func WithPinnedCertificates(fingerprints []string) ClientOption {
+	return func(c *clientConfig) {
+		c.pinnedCertificates = fingerprints
+	}
+}
+
+// WithInsecureSkipVerify disables the verification of the PagerDuty API certificate.
+func WithInsecureSkipVerify() ClientOption {
+	return func(c *clientConfig) {
+		c.insecureSkipVerify = true
+	}
+}
+
+// WithRequestLog writes every raw request and response to the writer, see requestLogTransport.
+func WithRequestLog(out io.Writer) ClientOption {
+	return func(c *clientConfig) {
+		c.requestLog = out
+	}
+}
+
+// WithAPIEndpoint sends the calls to the given base URL instead of https://api.pagerduty.com.
+func WithAPIEndpoint(endpoint string) ClientOption {
+	return func(c *clientConfig) {
+		c.apiEndpoint = strings.TrimSuffix(endpoint, "/")
+	}
+}
+
+// newHTTPClient builds an HTTP client with the same settings as the go-pagerduty default one,
+// plus the configured options.
+func newHTTPClient(config *clientConfig) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	baseTransport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       60 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		MaxIdleConnsPerHost:   runtime.GOMAXPROCS(0) + 1,
+	}
+
+	if config.proxy != nil {
+		baseTransport.Proxy = proxyFunc(config.proxy)
+	}
+	if len(config.pinnedCertificates) > 0 || config.insecureSkipVerify {
+		baseTransport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: config.insecureSkipVerify,
+		}
+		if len(config.pinnedCertificates) > 0 {
+			baseTransport.TLSClientConfig.VerifyConnection = verifyPinnedCertificate(config.pinnedCertificates)
+		}
+	}
+	if config.dnsCacheTTL > 0 {
+		baseTransport.DialContext = newDNSCache(config.dnsCacheTTL).dialContext(dialer)
+	}
+	if config.maxIdleConns > 0 {
+		baseTransport.MaxIdleConns = config.maxIdleConns
+	}
+	if config.maxConnsPerHost > 0 {
+		baseTransport.MaxConnsPerHost = config.maxConnsPerHost
+	}
+	if config.idleConnTimeout > 0 {
+		baseTransport.IdleConnTimeout = config.idleConnTimeout
+	}
+	if config.debugLog {
+		log.Printf("DEBUG: HTTP connection pool - max idle connections: %d, max connections per host: %d (0 is unlimited), idle connection timeout: %s",
+			baseTransport.MaxIdleConns, baseTransport.MaxConnsPerHost, baseTransport.IdleConnTimeout)
+	}
+
+	var transport http.RoundTripper = baseTransport
+	if config.requestLog != nil {
+		transport = &requestLogTransport{next: transport, out: config.requestLog}
+	}
+	if config.callStats != nil {
+		transport = &callStatsTransport{next: transport, stats: config.callStats}
+	}
+	if config.http2 {
+		baseTransport.ForceAttemptHTTP2 = true
+		if config.debugLog {
+			transport = &protocolLoggingTransport{next: transport}
+		}
+	}
+
+	if config.gzip {
+		transport = &gzipTransport{next: transport, debugLog: config.debugLog}
+	}
+
+	if config.idempotencyKey {
+		transport = &idempotencyKeyTransport{next: transport}
+	}
+
+	if config.userAgent != "" {
+		transport = &userAgentTransport{userAgent: config.userAgent, next: transport}
+	}
+
+	return &http.Client{Transport: transport}
+}
+
+// userAgentTransport replaces the User-Agent header of the requests.
+type userAgentTransport struct {
+	userAgent string
+	next      http.RoundTripper
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.next.RoundTrip(req)
+}
+
+// idempotencyKeyTransport sets the X-Idempotency-Key header of the POST, PUT, PATCH and DELETE
+// requests to a hash of their method, URL and body.
+type idempotencyKeyTransport struct {
+	next http.RoundTripper
+}
+
+func (t *idempotencyKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return t.next.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL.String() + "\n"))
+	hash.Write(body)
+
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.Header.Set("X-Idempotency-Key", hex.EncodeToString(hash.Sum(nil)))
+	return t.next.RoundTrip(req)
+}
+
+// protocolLoggingTransport logs the protocol negotiated by each new connection.
+type protocolLoggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *protocolLoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	newConnection := false
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			newConnection = !info.Reused
+		},
+	}
+
+	resp, err := t.next.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+	if err == nil && newConnection {
+		log.Printf("DEBUG: new connection to %s negotiated %s", req.URL.Host, resp.Proto)
+	}
+	return resp, err
+}
+
+// gzipTransport asks for gzip compressed responses and decompresses them, logging the
+// compression ratio with debugLog. Uncompressed responses are passed through untouched.
+type gzipTransport struct {
+	next     http.RoundTripper
+	debugLog bool
+}
+
+func (t *gzipTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.Header.Get("Content-Encoding") != "gzip" {
+		return resp, err
+	}
+
+	compressed := &countingReader{reader: resp.Body}
+	reader, err := gzip.NewReader(compressed)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	resp.Body = &gzipBody{
+		reader:     reader,
+		compressed: compressed,
+		body:       resp.Body,
+		path:       req.URL.Path,
+		debugLog:   t.debugLog,
+	}
+	return resp, nil
+}
+
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
+type gzipBody struct {
+	reader       *gzip.Reader
+	compressed   *countingReader
+	body         io.Closer
+	path         string
+	uncompressed int64
+	debugLog     bool
+}
+
+func (b *gzipBody) Read(p []byte) (int, error) {
+	n, err := b.reader.Read(p)
+	b.uncompressed += int64(n)
+	return n, err
+}
+
+func (b *gzipBody) Close() error {
+	if b.debugLog && b.compressed.count > 0 {
+		log.Printf("DEBUG: gzip response of %s: %d bytes decompressed to %d (%.1fx)",
+			b.path, b.compressed.count, b.uncompressed, float64(b.uncompressed)/float64(b.compressed.count))
+	}
+	return b.body.Close()
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_gzipTransport(t *testing.T) {
+	tests := []struct {
+		name     string
+		compress bool
+	}{
+		{
+			name:     "Compressed response is decompressed",
+			compress: true,
+		},
+		{
+			name:     "Uncompressed response is passed through",
+			compress: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+				if !tt.compress {
+					_, _ = w.Write([]byte(`{"schedules":[]}`))
+					return
+				}
+				w.Header().Set("Content-Encoding", "gzip")
+				gz := gzip.NewWriter(w)
+				_, _ = gz.Write([]byte(`{"schedules":[]}`))
+				_ = gz.Close()
+			}))
+			defer server.Close()
+
+			client := newHTTPClient(&clientConfig{gzip: true})
+			resp, err := client.Get(server.URL + "/schedules")
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, `{"schedules":[]}`, string(body))
+			assert.Empty(t, resp.Header.Get("Content-Encoding"))
+		})
+	}
+}
+
+func Test_newHTTPClient_http2(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	client := newHTTPClient(&clientConfig{http2: true, debugLog: true})
+	transport := client.Transport.(*protocolLoggingTransport).next.(*http.Transport)
+	transport.TLSClientConfig = server.Client().Transport.(*http.Transport).TLSClientConfig
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "HTTP/2.0", resp.Proto)
+}
+
+func Test_newHTTPClient_debugLog(t *testing.T) {
+	_, ok := newHTTPClient(&clientConfig{http2: true}).Transport.(*http.Transport)
+	assert.True(t, ok, "no protocol logging without the debug log")
+
+	_, ok = newHTTPClient(&clientConfig{http2: true, debugLog: true}).Transport.(*protocolLoggingTransport)
+	assert.True(t, ok)
+}
+
+func Test_newHTTPClient_connectionPool(t *testing.T) {
+	config := &clientConfig{}
+	WithConnectionPool(50, 20, 90*time.Second)(config)
+
+	transport := newHTTPClient(config).Transport.(*http.Transport)
+
+	assert.Equal(t, 50, transport.MaxIdleConns)
+	assert.Equal(t, 20, transport.MaxConnsPerHost)
+	assert.Equal(t, 90*time.Second, transport.IdleConnTimeout)
+}
+
+func Test_newHTTPClient_userAgent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.UserAgent()))
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("User-Agent", "go-pagerduty/1.5.1")
+
+	resp, err := newHTTPClient(&clientConfig{userAgent: "pd-report/test"}).Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "pd-report/test", string(body))
+}
+
+func Test_idempotencyKeyTransport(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		keys = append(keys, r.Header.Get("X-Idempotency-Key"))
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	client := newHTTPClient(&clientConfig{idempotencyKey: true})
+	send := func(method, body string) {
+		req, err := http.NewRequest(method, server.URL+"/schedules/PXXXXXX/overrides", strings.NewReader(body))
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		received, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, body, string(received))
+	}
+
+	send(http.MethodPost, `{"override":{"start":"2021-01-01"}}`)
+	send(http.MethodPost, `{"override":{"start":"2021-01-01"}}`)
+	send(http.MethodPost, `{"override":{"start":"2021-01-02"}}`)
+	send(http.MethodGet, "")
+
+	require.Len(t, keys, 4)
+	assert.NotEmpty(t, keys[0])
+	assert.Equal(t, keys[0], keys[1])
+	assert.NotEqual(t, keys[0], keys[2])
+	assert.Empty(t, keys[3])
+}
+
+func Test_newHTTPClient_insecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	_, err := newHTTPClient(&clientConfig{}).Get(server.URL)
+	require.Error(t, err)
+
+	resp, err := newHTTPClient(&clientConfig{insecureSkipVerify: true}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+}
+
+func TestNewPagerDutyAPIClient_apiEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/escalation_policies/QWERTY", r.URL.Path)
+		_, _ = w.Write([]byte(`{"escalation_policy":{"id":"QWERTY","name":"Policy 1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewPagerDutyAPIClient("token", WithAPIEndpoint(server.URL+"/"))
+	escalationPolicy, err := client.GetEscalationPolicy("QWERTY")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Policy 1", escalationPolicy.Name)
+}
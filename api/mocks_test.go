@@ -12,6 +12,29 @@ type clientMock struct {
 	mock.Mock
 }
 
+// GetEscalationPolicy provides a mock function with given fields: id, o
+func (_m *clientMock) GetEscalationPolicy(id string, o *pagerduty.GetEscalationPolicyOptions) (*pagerduty.EscalationPolicy, error) {
+	ret := _m.Called(id, o)
+
+	var r0 *pagerduty.EscalationPolicy
+	if rf, ok := ret.Get(0).(func(string, *pagerduty.GetEscalationPolicyOptions) *pagerduty.EscalationPolicy); ok {
+		r0 = rf(id, o)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pagerduty.EscalationPolicy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *pagerduty.GetEscalationPolicyOptions) error); ok {
+		r1 = rf(id, o)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSchedule provides a mock function with given fields: id, o
 func (_m *clientMock) GetSchedule(id string, o pagerduty.GetScheduleOptions) (*pagerduty.Schedule, error) {
 	ret := _m.Called(id, o)
@@ -58,6 +81,52 @@ func (_m *clientMock) GetUser(id string, o pagerduty.GetUserOptions) (*pagerduty
 	return r0, r1
 }
 
+// ListIncidentAlertsWithOpts provides a mock function with given fields: id, o
+func (_m *clientMock) ListIncidentAlertsWithOpts(id string, o pagerduty.ListIncidentAlertsOptions) (*pagerduty.ListAlertsResponse, error) {
+	ret := _m.Called(id, o)
+
+	var r0 *pagerduty.ListAlertsResponse
+	if rf, ok := ret.Get(0).(func(string, pagerduty.ListIncidentAlertsOptions) *pagerduty.ListAlertsResponse); ok {
+		r0 = rf(id, o)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pagerduty.ListAlertsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, pagerduty.ListIncidentAlertsOptions) error); ok {
+		r1 = rf(id, o)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListIncidents provides a mock function with given fields: o
+func (_m *clientMock) ListIncidents(o pagerduty.ListIncidentsOptions) (*pagerduty.ListIncidentsResponse, error) {
+	ret := _m.Called(o)
+
+	var r0 *pagerduty.ListIncidentsResponse
+	if rf, ok := ret.Get(0).(func(pagerduty.ListIncidentsOptions) *pagerduty.ListIncidentsResponse); ok {
+		r0 = rf(o)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pagerduty.ListIncidentsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(pagerduty.ListIncidentsOptions) error); ok {
+		r1 = rf(o)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListSchedules provides a mock function with given fields: o
 func (_m *clientMock) ListSchedules(o pagerduty.ListSchedulesOptions) (*pagerduty.ListSchedulesResponse, error) {
 	ret := _m.Called(o)
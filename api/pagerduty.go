@@ -13,6 +13,9 @@ type PdClient interface {
 	ListUsers(o pagerduty.ListUsersOptions) (*pagerduty.ListUsersResponse, error)
 	GetUser(id string, o pagerduty.GetUserOptions) (*pagerduty.User, error)
 	GetSchedule(id string, o pagerduty.GetScheduleOptions) (*pagerduty.Schedule, error)
+	GetEscalationPolicy(id string, o *pagerduty.GetEscalationPolicyOptions) (*pagerduty.EscalationPolicy, error)
+	ListIncidents(o pagerduty.ListIncidentsOptions) (*pagerduty.ListIncidentsResponse, error)
+	ListIncidentAlertsWithOpts(id string, o pagerduty.ListIncidentAlertsOptions) (*pagerduty.ListAlertsResponse, error)
 }
 
 type PagerDutyClient struct {
@@ -32,8 +35,20 @@ type UserRotaInfo struct {
 
 type ScheduleUserRotationData map[string]*UserRotaInfo
 
-func NewPagerDutyAPIClient(authToken string) *PagerDutyClient {
+func NewPagerDutyAPIClient(authToken string, opts ...ClientOption) *PagerDutyClient {
+	client := pagerduty.NewClient(authToken)
+	if len(opts) > 0 {
+		config := &clientConfig{}
+		for _, opt := range opts {
+			opt(config)
+		}
+		if config.apiEndpoint != "" {
+			client = pagerduty.NewClient(authToken, pagerduty.WithAPIEndpoint(config.apiEndpoint))
+		}
+		client.HTTPClient = newHTTPClient(config)
+	}
+
 	return &PagerDutyClient{
-		ApiClient: pagerduty.NewClient(authToken),
+		ApiClient: client,
 	}
 }
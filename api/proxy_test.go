@@ -0,0 +1,52 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newHTTPClient_proxy(t *testing.T) {
+	t.Setenv("NO_PROXY", "")
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.String()))
+	}))
+	defer proxy.Close()
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+
+	resp, err := newHTTPClient(&clientConfig{proxy: proxyURL}).Get("http://api.pagerduty.test/schedules")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "http://api.pagerduty.test/schedules", string(body))
+}
+
+func Test_bypassProxy(t *testing.T) {
+	tests := []struct {
+		name    string
+		host    string
+		noProxy string
+		want    bool
+	}{
+		{name: "empty list", host: "api.pagerduty.com", noProxy: "", want: false},
+		{name: "wildcard", host: "api.pagerduty.com", noProxy: "*", want: true},
+		{name: "exact host", host: "api.pagerduty.com", noProxy: "localhost, api.pagerduty.com", want: true},
+		{name: "domain", host: "api.pagerduty.com", noProxy: "pagerduty.com", want: true},
+		{name: "leading dot", host: "api.pagerduty.com", noProxy: ".pagerduty.com", want: true},
+		{name: "with port", host: "api.pagerduty.com", noProxy: "api.pagerduty.com:443", want: true},
+		{name: "other domain", host: "api.pagerduty.com", noProxy: "duty.com,example.com", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, bypassProxy(tt.host, tt.noProxy))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+)
+
+const requestLogMaxBody = 64 * 1024
+
+var requestLogRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// requestLogTransport writes every request and response in HTTP wire format, with the
+// credentials redacted and the response bodies truncated to requestLogMaxBody. It sits below
+// gzipTransport to log the headers as sent, so gzip response bodies are decompressed for the log.
+type requestLogTransport struct {
+	next http.RoundTripper
+
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func (t *requestLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	dumpedRequest, err := dumpRedacted(req.Header, func() ([]byte, error) {
+		return httputil.DumpRequestOut(req, true)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.write(dumpedRequest, []byte(fmt.Sprintf("# request failed: %v\n", err)))
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	dumpedResponse, err := dumpRedacted(resp.Header, func() ([]byte, error) {
+		return httputil.DumpResponse(resp, false)
+	})
+	if err != nil {
+		return nil, err
+	}
+	logged := body
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		logged, err = gunzip(body)
+		if err != nil {
+			logged = []byte(fmt.Sprintf("# failed to decompress the gzip body: %v\n", err))
+		}
+	}
+	if len(logged) > requestLogMaxBody {
+		dumpedResponse = append(dumpedResponse, logged[:requestLogMaxBody]...)
+		dumpedResponse = append(dumpedResponse, fmt.Sprintf("\n# truncated %d bytes\n", len(logged)-requestLogMaxBody)...)
+	} else {
+		dumpedResponse = append(dumpedResponse, logged...)
+	}
+	t.write(dumpedRequest, dumpedResponse)
+
+	return resp, nil
+}
+
+func gunzip(body []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+func (t *requestLogTransport) write(request, response []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, _ = t.out.Write(append(append(request, "\n\n"...), append(response, "\n\n"...)...))
+}
+
+// dumpRedacted runs the dump with the sensitive headers redacted, restoring them afterwards.
+func dumpRedacted(header http.Header, dump func() ([]byte, error)) ([]byte, error) {
+	saved := make(map[string][]string)
+	for _, name := range requestLogRedactedHeaders {
+		if values, ok := header[name]; ok {
+			saved[name] = values
+			header[name] = []string{"REDACTED"}
+		}
+	}
+	defer func() {
+		for name, values := range saved {
+			header[name] = values
+		}
+	}()
+	return dump()
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_requestLogTransport(t *testing.T) {
+	tests := []struct {
+		name         string
+		responseBody string
+		wantLogged   string
+	}{
+		{name: "short body", responseBody: `{"schedules":[]}`, wantLogged: `{"schedules":[]}`},
+		{name: "long body", responseBody: strings.Repeat("a", requestLogMaxBody+10), wantLogged: strings.Repeat("a", requestLogMaxBody) + "\n# truncated 10 bytes\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "Token token=secret", r.Header.Get("Authorization"))
+				_, _ = w.Write([]byte(tt.responseBody))
+			}))
+			defer server.Close()
+
+			var out bytes.Buffer
+			client := newHTTPClient(&clientConfig{requestLog: &out})
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/schedules", nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Token token=secret")
+
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.responseBody, string(body))
+			logged := out.String()
+			assert.True(t, strings.HasPrefix(logged, "GET /schedules HTTP/1.1\r\n"))
+			assert.Contains(t, logged, "Authorization: REDACTED\r\n")
+			assert.NotContains(t, logged, "secret")
+			assert.Contains(t, logged, "HTTP/1.1 200 OK\r\n")
+			assert.Contains(t, logged, "\r\n\r\n"+tt.wantLogged)
+		})
+	}
+}
+
+func Test_requestLogTransport_gzip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+		w.Header().Set("Content-Encoding", "gzip")
+		writer := gzip.NewWriter(w)
+		_, _ = writer.Write([]byte(`{"schedules":[]}`))
+		_ = writer.Close()
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	client := newHTTPClient(&clientConfig{requestLog: &out, gzip: true})
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/schedules", nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"schedules":[]}`, string(body))
+	logged := out.String()
+	assert.Contains(t, logged, "Accept-Encoding: gzip\r\n")
+	assert.Contains(t, logged, "Content-Encoding: gzip\r\n")
+	assert.Contains(t, logged, "\r\n\r\n"+`{"schedules":[]}`)
+}
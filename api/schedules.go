@@ -1,20 +1,30 @@
 package api
 
 import (
+	"errors"
 	"time"
 
 	"github.com/PagerDuty/go-pagerduty"
 )
 
 type Schedule struct {
-	ID            string
-	Name          string
-	TimeZone      string
-	FinalSchedule ScheduleLayer
+	ID                  string
+	Name                string
+	TimeZone            string
+	EscalationPolicyIDs []string
+	UserIDs             []string
+	ScheduleLayers      []ScheduleLayer
+	OverrideSubschedule ScheduleLayer
+	FinalSchedule       ScheduleLayer
 }
 
 type ScheduleLayer struct {
 	RenderedScheduleEntries []RenderedScheduleEntry
+
+	// rotation settings, only set for the layers in ScheduleLayers
+	Name                 string
+	RotationVirtualStart string // handoff time the rotation is based on
+	UserIDs              []string
 }
 
 type RenderedScheduleEntry struct {
@@ -26,14 +36,27 @@ type RenderedScheduleEntry struct {
 type ScheduleInfo struct {
 	ID            string
 	Name          string
+	TimeZone      string // time zone configured in PagerDuty
 	Location      *time.Location
 	Start         time.Time
 	End           time.Time
 	FinalSchedule ScheduleLayer
+
+	EscalationPolicyIDs []string
+	ScheduleLayers      []ScheduleLayer // ordered from the highest to the lowest priority
+	OverrideSubschedule ScheduleLayer
 }
 
 func (p *PagerDutyClient) ListSchedules() ([]*Schedule, error) {
-	var opts pagerduty.ListSchedulesOptions
+	return p.listSchedules(pagerduty.ListSchedulesOptions{})
+}
+
+// SearchSchedules returns the schedules whose name matches the query, as filtered by PagerDuty.
+func (p *PagerDutyClient) SearchSchedules(query string) ([]*Schedule, error) {
+	return p.listSchedules(pagerduty.ListSchedulesOptions{Query: query})
+}
+
+func (p *PagerDutyClient) listSchedules(opts pagerduty.ListSchedulesOptions) ([]*Schedule, error) {
 	var scheduleList []*Schedule
 
 	more := true
@@ -64,13 +87,47 @@ func (p *PagerDutyClient) GetSchedule(scheduleID, startDate, endDate string) (*S
 	return convertSchedule(scheduleResponse), nil
 }
 
+// IsNotFound reports whether err is a PagerDuty API error for a resource that does not exist.
+func IsNotFound(err error) bool {
+	var apiErr pagerduty.APIError
+	return errors.As(err, &apiErr) && apiErr.NotFound()
+}
+
 func convertSchedule(schedule *pagerduty.Schedule) *Schedule {
 	return &Schedule{
-		ID:            schedule.ID,
-		Name:          schedule.Name,
-		TimeZone:      schedule.TimeZone,
-		FinalSchedule: convertScheduleLayer(schedule.FinalSchedule),
+		ID:                  schedule.ID,
+		Name:                schedule.Name,
+		TimeZone:            schedule.TimeZone,
+		EscalationPolicyIDs: convertAPIObjectIDs(schedule.EscalationPolicies),
+		UserIDs:             convertAPIObjectIDs(schedule.Users),
+		ScheduleLayers:      convertScheduleLayers(schedule.ScheduleLayers),
+		OverrideSubschedule: convertScheduleLayer(schedule.OverrideSubschedule),
+		FinalSchedule:       convertScheduleLayer(schedule.FinalSchedule),
+	}
+}
+
+func convertAPIObjectIDs(objects []pagerduty.APIObject) []string {
+	var ids []string
+	for _, object := range objects {
+		ids = append(ids, object.ID)
+	}
+
+	return ids
+}
+
+func convertScheduleLayers(layers []pagerduty.ScheduleLayer) []ScheduleLayer {
+	var layerList []ScheduleLayer
+	for _, layer := range layers {
+		layerInfo := convertScheduleLayer(layer)
+		layerInfo.Name = layer.Name
+		layerInfo.RotationVirtualStart = layer.RotationVirtualStart
+		for _, user := range layer.Users {
+			layerInfo.UserIDs = append(layerInfo.UserIDs, user.User.ID)
+		}
+		layerList = append(layerList, layerInfo)
 	}
+
+	return layerList
 }
 
 func convertScheduleLayer(layer pagerduty.ScheduleLayer) ScheduleLayer {
@@ -186,3 +186,41 @@ func Test_GetSchedule(t *testing.T) {
 		})
 	}
 }
+
+func Test_convertScheduleLayers(t *testing.T) {
+	layers := convertScheduleLayers([]pagerduty.ScheduleLayer{
+		{
+			Name:                 "Layer 1",
+			RotationVirtualStart: "2022-08-24T09:00:00+01:00",
+			Users: []pagerduty.UserReference{
+				{User: pagerduty.APIObject{ID: "USER_1"}},
+				{User: pagerduty.APIObject{ID: "USER_2"}},
+			},
+		},
+	})
+
+	assert.Equal(t, []ScheduleLayer{
+		{
+			Name:                 "Layer 1",
+			RotationVirtualStart: "2022-08-24T09:00:00+01:00",
+			UserIDs:              []string{"USER_1", "USER_2"},
+		},
+	}, layers)
+}
+
+func Test_SearchSchedules(t *testing.T) {
+	mockedClient := &clientMock{}
+	mockedClient.On("ListSchedules", pagerduty.ListSchedulesOptions{Query: "SRE"}).Once().Return(
+		&pagerduty.ListSchedulesResponse{
+			Schedules: []pagerduty.Schedule{{APIObject: pagerduty.APIObject{ID: "QWERTY"}, Name: "SRE Primary"}},
+		}, nil)
+
+	pdClient := PagerDutyClient{ApiClient: mockedClient}
+	scheduleList, err := pdClient.SearchSchedules("SRE")
+	mockedClient.AssertExpectations(t)
+
+	require.NoError(t, err)
+	require.Len(t, scheduleList, 1)
+	assert.Equal(t, "QWERTY", scheduleList[0].ID)
+	assert.Equal(t, "SRE Primary", scheduleList[0].Name)
+}
@@ -3,23 +3,34 @@ package api
 import "github.com/PagerDuty/go-pagerduty"
 
 type Team struct {
-	ID   string
-	Name string
+	ID       string
+	Name     string
+	ParentID string // only set by ListTeams
 }
 
 func (p *PagerDutyClient) ListTeams() ([]*Team, error) {
 	var opts pagerduty.ListTeamOptions
-	listTeamsResponse, err := p.ApiClient.ListTeams(opts)
-	if err != nil {
-		return nil, err
-	}
-
 	var teamList []*Team
-	for _, team := range listTeamsResponse.Teams {
-		teamList = append(teamList, &Team{
-			ID:   team.ID,
-			Name: team.Name,
-		})
+
+	more := true
+	for more {
+		listTeamsResponse, err := p.ApiClient.ListTeams(opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, team := range listTeamsResponse.Teams {
+			newTeam := &Team{
+				ID:   team.ID,
+				Name: team.Name,
+			}
+			if team.Parent != nil {
+				newTeam.ParentID = team.Parent.ID
+			}
+			teamList = append(teamList, newTeam)
+		}
+		more = listTeamsResponse.More
+		opts.Offset += listTeamsResponse.Limit
 	}
+
 	return teamList, nil
 }
@@ -38,6 +38,13 @@ func Test_ListTeams(t *testing.T) {
 								Name:        "Team 1",
 								Description: "This is the team 1",
 							},
+							{
+								APIObject: pagerduty.APIObject{
+									ID: "ASDFGH",
+								},
+								Name:   "Team 2",
+								Parent: &pagerduty.APIObject{ID: "QWERTY"},
+							},
 						},
 					}, nil)
 			},
@@ -46,9 +53,39 @@ func Test_ListTeams(t *testing.T) {
 					ID:   "QWERTY",
 					Name: "Team 1",
 				},
+				{
+					ID:       "ASDFGH",
+					Name:     "Team 2",
+					ParentID: "QWERTY",
+				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "Successfully get several pages of teams",
+			clientSetup: func(clientMock *clientMock) {
+				clientMock.On("ListTeams", pagerduty.ListTeamOptions{}).Once().Return(
+					&pagerduty.ListTeamResponse{
+						APIListObject: pagerduty.APIListObject{Limit: 1, More: true},
+						Teams:         []pagerduty.Team{{APIObject: pagerduty.APIObject{ID: "QWERTY"}, Name: "Team 1"}},
+					}, nil)
+				clientMock.On("ListTeams", pagerduty.ListTeamOptions{Offset: 1}).Once().Return(
+					&pagerduty.ListTeamResponse{
+						APIListObject: pagerduty.APIListObject{Limit: 1, Offset: 1},
+						Teams:         []pagerduty.Team{{APIObject: pagerduty.APIObject{ID: "ASDFGH"}, Name: "Team 2"}},
+					}, nil)
+			},
+			want: []*Team{
+				{
+					ID:   "QWERTY",
+					Name: "Team 1",
+				},
+				{
+					ID:   "ASDFGH",
+					Name: "Team 2",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -67,11 +104,13 @@ func Test_ListTeams(t *testing.T) {
 			}
 
 			require.NoError(t, err)
+			require.Len(t, teamList, len(tt.want))
 
 			for i, wantTeam := range tt.want {
 				assert.IsType(t, &Team{}, teamList[i])
 				assert.Equal(t, wantTeam.ID, teamList[i].ID)
 				assert.Equal(t, wantTeam.Name, teamList[i].Name)
+				assert.Equal(t, wantTeam.ParentID, teamList[i].ParentID)
 			}
 		})
 	}
@@ -48,9 +48,14 @@ func (p *PagerDutyClient) GetUserById(id string) (*User, error) {
 func convertUser(user *pagerduty.User) *User {
 	var userTeams []Team
 	for _, team := range user.Teams {
+		// The users' teams are references, with the team name in the summary.
+		name := team.Name
+		if name == "" {
+			name = team.Summary
+		}
 		userTeams = append(userTeams, Team{
 			ID:   team.ID,
-			Name: team.Name,
+			Name: name,
 		})
 	}
 
@@ -26,7 +26,8 @@ func Test_GetUserById(t *testing.T) {
 				Timezone: "Europe/London",
 				Teams: []Team{
 					{
-						ID: "QWERTY",
+						ID:   "QWERTY",
+						Name: "Platform",
 					},
 				},
 			},
@@ -41,7 +42,8 @@ func Test_GetUserById(t *testing.T) {
 					Teams: []pagerduty.Team{
 						{
 							APIObject: pagerduty.APIObject{
-								ID: "QWERTY",
+								ID:      "QWERTY",
+								Summary: "Platform",
 							},
 						},
 					},
@@ -88,6 +90,7 @@ func Test_GetUserById(t *testing.T) {
 			assert.Equal(t, tt.want.Email, user.Email)
 			assert.Equal(t, tt.want.Timezone, user.Timezone)
 			assert.Equal(t, tt.want.Teams[0].ID, user.Teams[0].ID)
+			assert.Equal(t, tt.want.Teams[0].Name, user.Teams[0].Name)
 		})
 	}
 }
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const alertsReceivedColumn = "alerts_received"
+
+var includeAlertCount bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includeAlertCount, "include-alert-count", false, fmt.Sprintf("add the number of alerts raised on the services escalating to each user's schedules while they were on call (alerts grouped into incidents created over %d days before the report period are not counted)",
+		int(api.AlertIncidentLookback.Hours()/24)))
+}
+
+// setAlertCounts sets the number of alerts each user of the schedule received while on call, as a measure
+// of the noise of the services escalating to the schedule.
+func (pd *pagerDutyClient) setAlertCounts(scheduleData *report.ScheduleData, scheduleInfo *api.ScheduleInfo,
+	usersRotationData api.ScheduleUserRotationData, serviceIDs map[string]bool) error {
+
+	var alerts []*api.Alert
+	if len(serviceIDs) > 0 {
+		ids := make([]string, 0, len(serviceIDs))
+		for serviceID := range serviceIDs {
+			ids = append(ids, serviceID)
+		}
+		sort.Strings(ids)
+
+		var err error
+		alerts, err = pd.client.ListAlerts(ids, scheduleInfo.Start, scheduleInfo.End)
+		if err != nil {
+			return fmt.Errorf("failed to list the alerts of schedule %s: %w", scheduleInfo.ID, err)
+		}
+	}
+
+	alertsByUser := make(map[string]int)
+	for _, userRotaInfo := range usersRotationData {
+		alertsByUser[userRotaInfo.Name] += countAlertsInPeriods(alerts, userRotaInfo.Periods)
+	}
+	for _, user := range scheduleData.RotaUsers {
+		setExtraValue(user, alertsReceivedColumn, strconv.Itoa(alertsByUser[user.Name]))
+	}
+	return nil
+}
+
+func countAlertsInPeriods(alerts []*api.Alert, periods []*api.UserRotaPeriod) int {
+	count := 0
+	for _, alert := range alerts {
+		for _, period := range periods {
+			if !alert.CreatedAt.Before(period.Start) && alert.CreatedAt.Before(period.End) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// setSummaryAlertCounts sets the alerts received by the users in the summary, adding up the ones of each schedule.
+func setSummaryAlertCounts(data *report.PrintableData) error {
+	alertsByUser := make(map[string]int)
+	for _, scheduleData := range data.SchedulesData {
+		for _, user := range scheduleData.RotaUsers {
+			count, err := strconv.Atoi(user.ExtraValues[alertsReceivedColumn])
+			if err != nil {
+				return fmt.Errorf("invalid alert count for %s in schedule %s: %w", user.Name, scheduleData.ID, err)
+			}
+			alertsByUser[user.Name] += count
+		}
+	}
+	for _, user := range data.UsersSchedulesSummary {
+		setExtraValue(user, alertsReceivedColumn, strconv.Itoa(alertsByUser[user.Name]))
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_pagerDutyClient_setAlertCounts(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.January, 3, 0, 0, 0, 0, time.UTC)
+	scheduleInfo := &api.ScheduleInfo{ID: "SCHED_1", Start: start, End: end}
+	usersRotationData := api.ScheduleUserRotationData{
+		"USER_1": {ID: "USER_1", Name: "John Doe", Periods: []*api.UserRotaPeriod{
+			{Start: start, End: start.Add(24 * time.Hour)},
+		}},
+		"USER_2": {ID: "USER_2", Name: "Mary Jane", Periods: []*api.UserRotaPeriod{
+			{Start: start.Add(24 * time.Hour), End: end},
+		}},
+	}
+	alertAt := func(d time.Duration) *api.Alert { return &api.Alert{CreatedAt: start.Add(d)} }
+
+	t.Run("counts the alerts of each user's periods", func(t *testing.T) {
+		mockedClient := &clientMock{}
+		mockedClient.On("ListAlerts", []string{"SERVICE_1", "SERVICE_2"}, start, end).Once().Return([]*api.Alert{
+			alertAt(time.Hour), alertAt(2 * time.Hour), alertAt(24 * time.Hour),
+		}, nil)
+
+		scheduleData := &report.ScheduleData{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{
+			{Name: "John Doe"}, {Name: "Mary Jane"},
+		}}
+		pd := pagerDutyClient{client: mockedClient}
+		err := pd.setAlertCounts(scheduleData, scheduleInfo, usersRotationData, map[string]bool{"SERVICE_2": true, "SERVICE_1": true})
+		mockedClient.AssertExpectations(t)
+
+		require.NoError(t, err)
+		assert.Equal(t, "2", scheduleData.RotaUsers[0].ExtraValues[alertsReceivedColumn])
+		assert.Equal(t, "1", scheduleData.RotaUsers[1].ExtraValues[alertsReceivedColumn])
+
+		data := &report.PrintableData{
+			SchedulesData:         []*report.ScheduleData{scheduleData, scheduleData},
+			UsersSchedulesSummary: []*report.ScheduleUser{{Name: "John Doe"}, {Name: "Mary Jane"}},
+		}
+		require.NoError(t, setSummaryAlertCounts(data))
+		assert.Equal(t, "4", data.UsersSchedulesSummary[0].ExtraValues[alertsReceivedColumn])
+		assert.Equal(t, "2", data.UsersSchedulesSummary[1].ExtraValues[alertsReceivedColumn])
+	})
+
+	t.Run("no alerts without services", func(t *testing.T) {
+		mockedClient := &clientMock{}
+		scheduleData := &report.ScheduleData{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{{Name: "John Doe"}}}
+		pd := pagerDutyClient{client: mockedClient}
+		require.NoError(t, pd.setAlertCounts(scheduleData, scheduleInfo, usersRotationData, nil))
+		mockedClient.AssertExpectations(t)
+		assert.Equal(t, "0", scheduleData.RotaUsers[0].ExtraValues[alertsReceivedColumn])
+	})
+
+	t.Run("failed to list the alerts", func(t *testing.T) {
+		mockedClient := &clientMock{}
+		mockedClient.On("ListAlerts", []string{"SERVICE_1"}, start, end).Once().Return(nil, errors.New("failed to list"))
+		pd := pagerDutyClient{client: mockedClient}
+		err := pd.setAlertCounts(&report.ScheduleData{}, scheduleInfo, usersRotationData, map[string]bool{"SERVICE_1": true})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+var (
+	maxAmountWarn  float32
+	maxAmountError float32
+)
+
+func init() {
+	scheduleReportCmd.Flags().Float32Var(&maxAmountWarn, "max-amount-warn", 0, "warn when the grand total of the report exceeds this amount (0 to disable)")
+	scheduleReportCmd.Flags().Float32Var(&maxAmountError, "max-amount-error", 0, "abort the report when the grand total exceeds this amount (0 to disable)")
+}
+
+func scheduleTotalAmount(scheduleData *report.ScheduleData) float32 {
+	var total float32
+	for _, user := range scheduleData.RotaUsers {
+		total += user.TotalAmount
+	}
+	return roundCurrency(total)
+}
+
+func grandTotalAmount(data *report.PrintableData) float32 {
+	var total float32
+	for _, user := range data.UsersSchedulesSummary {
+		total += user.TotalAmount
+	}
+	return roundCurrency(total)
+}
+
+// checkAmountThreshold warns when the amount exceeds warnAt and fails when it exceeds errorAt.
+// A zero threshold disables the corresponding check.
+func checkAmountThreshold(description string, amount, warnAt, errorAt float32) error {
+	if errorAt > 0 && amount > errorAt {
+		return fmt.Errorf("%s (%s) exceeds the maximum amount of %s - check the configured rates", description,
+			formatCurrencyAmount(amount), formatCurrencyAmount(errorAt))
+	}
+	if warnAt > 0 && amount > warnAt {
+		log.Printf("WARNING: %s (%s) exceeds the amount of %s - check the configured rates", description,
+			formatCurrencyAmount(amount), formatCurrencyAmount(warnAt))
+	}
+	return nil
+}
+
+// checkAmountThresholds guards against misconfigured rates producing absurdly large
+// amounts, checking each schedule total against its configured thresholds and the
+// grand total against the command line ones.
+func checkAmountThresholds(data *report.PrintableData) error {
+	for _, scheduleData := range data.SchedulesData {
+		settings := Config.FindScheduleSettingsByID(scheduleData.ID)
+		if settings == nil {
+			continue
+		}
+
+		err := checkAmountThreshold(fmt.Sprintf("total of schedule '%s'", scheduleData.ID),
+			scheduleTotalAmount(scheduleData), settings.MaxAmountWarn, settings.MaxAmountError)
+		if err != nil {
+			return err
+		}
+	}
+
+	return checkAmountThreshold("grand total", grandTotalAmount(data), maxAmountWarn, maxAmountError)
+}
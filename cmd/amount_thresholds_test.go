@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkAmountThresholds(t *testing.T) {
+	data := &report.PrintableData{
+		SchedulesData: []*report.ScheduleData{
+			{
+				ID: "SCHED_1",
+				RotaUsers: []*report.ScheduleUser{
+					{Name: "John Doe", TotalAmount: 600},
+					{Name: "Mary Jane", TotalAmount: 500},
+				},
+			},
+			{
+				ID: "SCHED_2",
+				RotaUsers: []*report.ScheduleUser{
+					{Name: "John Doe", TotalAmount: 100},
+				},
+			},
+		},
+		UsersSchedulesSummary: []*report.ScheduleUser{
+			{Name: "John Doe", TotalAmount: 700},
+			{Name: "Mary Jane", TotalAmount: 500},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		scheduleSettings []configuration.ScheduleSettings
+		maxAmountWarn    float32
+		maxAmountError   float32
+		wantErr          bool
+	}{
+		{
+			name: "No thresholds configured",
+		},
+		{
+			name:           "Grand total under the error threshold",
+			maxAmountWarn:  1000,
+			maxAmountError: 1200,
+		},
+		{
+			name:           "Grand total over the error threshold",
+			maxAmountError: 1000,
+			wantErr:        true,
+		},
+		{
+			name: "Schedule total over its error threshold",
+			scheduleSettings: []configuration.ScheduleSettings{
+				{Id: "SCHED_1", MaxAmountError: 1000},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Schedule total under its error threshold",
+			scheduleSettings: []configuration.ScheduleSettings{
+				{Id: "SCHED_1", MaxAmountWarn: 1000, MaxAmountError: 2000},
+				{Id: "SCHED_2", MaxAmountError: 100},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConfig(t, configuration.New())
+			Config.ScheduleSettings = tt.scheduleSettings
+			maxAmountWarn, maxAmountError = tt.maxAmountWarn, tt.maxAmountError
+			defer func() { maxAmountWarn, maxAmountError = 0, 0 }()
+
+			err := checkAmountThresholds(data)
+
+			if tt.wantErr == true {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
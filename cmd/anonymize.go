@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+var anonymizeScheduleID bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&anonymizeScheduleID, "anonymize-schedule-id", false, "replace the schedule IDs in the report with SCHED-1, SCHED-2... printing the mapping to stderr")
+}
+
+// anonymizeScheduleIDs replaces the PagerDuty schedule IDs with sequential ones, numbered in the alphabetical
+// order of the original IDs so the same schedules get the same number every time. The mapping is written to w,
+// never to the report, so whoever generated the report can de-anonymize it.
+func anonymizeScheduleIDs(data *report.PrintableData, w io.Writer) {
+	schedules := make([]*report.ScheduleData, len(data.SchedulesData))
+	copy(schedules, data.SchedulesData)
+	sort.SliceStable(schedules, func(i, j int) bool {
+		return schedules[i].ID < schedules[j].ID
+	})
+
+	for i, scheduleData := range schedules {
+		anonymizedID := fmt.Sprintf("SCHED-%d", i+1)
+		fmt.Fprintln(w, fmt.Sprintf("%s: %s (%s)", anonymizedID, scheduleData.ID, scheduleData.Name))
+		scheduleData.ID = anonymizedID
+		// the URL would give the original ID away
+		scheduleData.URL = ""
+	}
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_anonymizeScheduleIDs(t *testing.T) {
+	data := &report.PrintableData{
+		SchedulesData: []*report.ScheduleData{
+			{ID: "PZZZZZZ", Name: "Platform", URL: scheduleURL("PZZZZZZ")},
+			{ID: "PAAAAAA", Name: "Database"},
+			{ID: "PMMMMMM", Name: "Networking"},
+		},
+	}
+
+	var mapping bytes.Buffer
+	anonymizeScheduleIDs(data, &mapping)
+
+	assert.Equal(t, "SCHED-3", data.SchedulesData[0].ID)
+	assert.Empty(t, data.SchedulesData[0].URL)
+	assert.Equal(t, "SCHED-1", data.SchedulesData[1].ID)
+	assert.Equal(t, "SCHED-2", data.SchedulesData[2].ID)
+	assert.Equal(t, "SCHED-1: PAAAAAA (Database)\n"+
+		"SCHED-2: PMMMMMM (Networking)\n"+
+		"SCHED-3: PZZZZZZ (Platform)\n", mapping.String())
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+)
+
+var (
+	printAPICallSummary bool
+	apiCallStats        *api.CallStats
+)
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&printAPICallSummary, "print-api-call-summary", false, "print the number of calls and the latency of each PagerDuty API endpoint called by the report to stderr")
+}
+
+// writeAPICallSummary writes a table with the calls made to each endpoint of the PagerDuty API.
+func writeAPICallSummary(w io.Writer, stats *api.CallStats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Endpoint\tCalls\tTotal latency\tMax latency")
+	for _, endpoint := range stats.Endpoints() {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", endpoint.Endpoint, endpoint.Calls,
+			endpoint.TotalLatency.Round(time.Millisecond), endpoint.MaxLatency.Round(time.Millisecond))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_writeAPICallSummary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"teams":[]}`))
+	}))
+	defer server.Close()
+
+	stats := api.NewCallStats()
+	client := api.NewPagerDutyAPIClient("token", api.WithAPIEndpoint(server.URL), api.WithCallStats(stats))
+	_, err := client.ListTeams()
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, writeAPICallSummary(&out, stats))
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	assert.Regexp(t, `^Endpoint\s+Calls\s+Total latency\s+Max latency$`, string(lines[0]))
+	assert.Regexp(t, `^GET /teams\s+1\s+\S+\s+\S+$`, string(lines[1]))
+}
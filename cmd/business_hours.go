@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+)
+
+const (
+	defaultBusinessHoursStartAt = 9
+	defaultBusinessHoursEndAt   = 17
+)
+
+var nonBusinessHoursOnly bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&nonBusinessHoursOnly, "include-non-business-hours-only", false, "only pay the hours outside the business hours of the weekdays, in the schedule time zone (see businessHours in the config)")
+}
+
+// businessHours returns the configured start and end hours of the business day, 09:00 to 17:00 by default.
+func businessHours() (int, int) {
+	hours := Config.BusinessHours
+	if hours.StartsAt == 0 && hours.EndsAt == 0 {
+		return defaultBusinessHoursStartAt, defaultBusinessHoursEndAt
+	}
+	return hours.StartsAt, hours.EndsAt
+}
+
+// isBusinessHour reports whether date falls in the business hours of a weekday that is neither a weekend day
+// nor a bank holiday, in the given time zone.
+func isBusinessHour(calendar *configuration.BHCalendar, date time.Time, location *time.Location) bool {
+	if location != nil {
+		date = date.In(location)
+	}
+	if calendar.IsWeekend(date) || calendar.IsDateBankHoliday(date) {
+		return false
+	}
+	startsAt, endsAt := businessHours()
+	return date.Hour() >= startsAt && date.Hour() < endsAt
+}
+
+func businessHoursNote() string {
+	startsAt, endsAt := businessHours()
+	return fmt.Sprintf("Only hours outside business hours paid (%02d:00-%02d:00 on weekdays)", startsAt, endsAt)
+}
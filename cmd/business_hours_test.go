@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_isBusinessHour(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+	calendar := &configuration.BHCalendar{
+		DaysMaps: map[string]configuration.BankHoliday{"25/12/2023": {Name: "Christmas Day"}},
+	}
+
+	tests := []struct {
+		name          string
+		businessHours configuration.BusinessHours
+		date          time.Time
+		want          bool
+	}{
+		{name: "weekday morning", date: time.Date(2023, 7, 3, 9, 0, 0, 0, london), want: true},
+		{name: "weekday before business hours", date: time.Date(2023, 7, 3, 8, 30, 0, 0, london), want: false},
+		{name: "weekday at the end of business hours", date: time.Date(2023, 7, 3, 17, 0, 0, 0, london), want: false},
+		{name: "converted to the schedule time zone", date: time.Date(2023, 7, 3, 16, 30, 0, 0, time.UTC), want: false},
+		{name: "weekend", date: time.Date(2023, 7, 1, 12, 0, 0, 0, london), want: false},
+		{name: "bank holiday", date: time.Date(2023, 12, 25, 12, 0, 0, 0, london), want: false},
+		{
+			name:          "configured business hours",
+			businessHours: configuration.BusinessHours{StartsAt: 8, EndsAt: 18},
+			date:          time.Date(2023, 7, 3, 17, 30, 0, 0, london),
+			want:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConfig(t, configuration.New())
+			Config.BusinessHours = tt.businessHours
+			require.Equal(t, tt.want, isBusinessHour(calendar, tt.date, london))
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+)
+
+var checkScheduleExists bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&checkScheduleExists, "check-schedule-exists", false, "check that all the schedules exist in PagerDuty before fetching any data")
+}
+
+// checkSchedulesExist fails early when any of the schedules is unknown to PagerDuty, as a
+// mistyped schedule ID would otherwise only show up as a confusing empty report. All the
+// missing schedules are reported together.
+func (pd *pagerDutyClient) checkSchedulesExist(schedules []Schedule) error {
+	missing := make([]string, 0)
+	for _, schedule := range schedules {
+		_, err := pd.client.GetSchedule(schedule.id, "", "")
+		if api.IsNotFound(err) {
+			missing = append(missing, schedule.id)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check schedule %s exists: %w", schedule.id, err)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("schedule(s) not found: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
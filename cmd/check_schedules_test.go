@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_pagerDutyClient_checkSchedulesExist(t *testing.T) {
+	schedules := []Schedule{{id: "SCHED_1"}, {id: "SCHED_2"}, {id: "SCHED_3"}}
+	notFound := pagerduty.APIError{StatusCode: http.StatusNotFound}
+
+	tests := []struct {
+		name        string
+		mockSetup   func(*clientMock)
+		wantErr     bool
+		wantMissing []string
+	}{
+		{
+			name: "All schedules exist",
+			mockSetup: func(mock *clientMock) {
+				mock.On("GetSchedule", "SCHED_1", "", "").Once().Return(&api.Schedule{ID: "SCHED_1"}, nil)
+				mock.On("GetSchedule", "SCHED_2", "", "").Once().Return(&api.Schedule{ID: "SCHED_2"}, nil)
+				mock.On("GetSchedule", "SCHED_3", "", "").Once().Return(&api.Schedule{ID: "SCHED_3"}, nil)
+			},
+		},
+		{
+			name: "All the missing schedules are reported",
+			mockSetup: func(mock *clientMock) {
+				mock.On("GetSchedule", "SCHED_1", "", "").Once().Return(nil, notFound)
+				mock.On("GetSchedule", "SCHED_2", "", "").Once().Return(&api.Schedule{ID: "SCHED_2"}, nil)
+				mock.On("GetSchedule", "SCHED_3", "", "").Once().Return(nil, notFound)
+			},
+			wantErr:     true,
+			wantMissing: []string{"SCHED_1", "SCHED_3"},
+		},
+		{
+			name: "Fails to get a schedule",
+			mockSetup: func(mock *clientMock) {
+				mock.On("GetSchedule", "SCHED_1", "", "").Once().Return(nil, errors.New("failed"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedClient := &clientMock{}
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockedClient)
+			}
+
+			pd := pagerDutyClient{client: mockedClient}
+			err := pd.checkSchedulesExist(schedules)
+			mockedClient.AssertExpectations(t)
+
+			if tt.wantErr == true {
+				require.Error(t, err)
+				for _, id := range tt.wantMissing {
+					assert.Contains(t, err.Error(), id)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const maxConsecutiveDaysColumn = "max_consecutive_days"
+
+var includeConsecutiveDays bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includeConsecutiveDays, "include-consecutive-days", false, "add a max_consecutive_days column with the longest run of calendar days, in the schedule time zone, each user was on call")
+}
+
+// maxConsecutiveDays returns the longest run of calendar days in the location with on-call time in any of the periods.
+func maxConsecutiveDays(periods []*api.UserRotaPeriod, location *time.Location) int {
+	if location == nil {
+		location = time.UTC
+	}
+	days := make(map[time.Time]bool)
+	for _, period := range periods {
+		if !period.End.After(period.Start) {
+			continue
+		}
+		last := calendarDay(period.End.Add(-time.Nanosecond), location)
+		for day := calendarDay(period.Start, location); !day.After(last); day = day.AddDate(0, 0, 1) {
+			days[day] = true
+		}
+	}
+
+	sorted := make([]time.Time, 0, len(days))
+	for day := range days {
+		sorted = append(sorted, day)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	var longest, run int
+	for i, day := range sorted {
+		if i > 0 && sorted[i-1].AddDate(0, 0, 1).Equal(day) {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+	return longest
+}
+
+// calendarDay returns the date of the time in the location, as midnight UTC so days can be compared.
+func calendarDay(t time.Time, location *time.Location) time.Time {
+	year, month, day := t.In(location).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// setSummaryConsecutiveDays sets the longest run of days of the users in the summary, the longest one of any
+// schedule.
+func setSummaryConsecutiveDays(data *report.PrintableData) error {
+	daysByUser := make(map[string]int)
+	for _, scheduleData := range data.SchedulesData {
+		for _, user := range scheduleData.RotaUsers {
+			days, err := strconv.Atoi(user.ExtraValues[maxConsecutiveDaysColumn])
+			if err != nil {
+				return fmt.Errorf("invalid consecutive days for %s in schedule %s: %w", user.Name, scheduleData.ID, err)
+			}
+			if days > daysByUser[user.Name] {
+				daysByUser[user.Name] = days
+			}
+		}
+	}
+	for _, user := range data.UsersSchedulesSummary {
+		setExtraValue(user, maxConsecutiveDaysColumn, strconv.Itoa(daysByUser[user.Name]))
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_maxConsecutiveDays(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	require.NoError(t, err)
+	utc := func(d, h int) time.Time { return time.Date(2023, 7, d, h, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name     string
+		periods  []*api.UserRotaPeriod
+		location *time.Location
+		want     int
+	}{
+		{name: "no periods", want: 0},
+		{
+			name:    "ending at midnight",
+			periods: []*api.UserRotaPeriod{{Start: utc(3, 8), End: utc(5, 0)}},
+			want:    2,
+		},
+		{
+			name: "gap of a day",
+			periods: []*api.UserRotaPeriod{
+				{Start: utc(3, 8), End: utc(3, 20)},
+				{Start: utc(4, 8), End: utc(4, 20)},
+				{Start: utc(6, 8), End: utc(6, 20)},
+			},
+			want: 2,
+		},
+		{
+			name:     "days of the schedule time zone",
+			periods:  []*api.UserRotaPeriod{{Start: utc(3, 8), End: utc(3, 20)}},
+			location: sydney,
+			want:     2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, maxConsecutiveDays(tt.periods, tt.location))
+		})
+	}
+}
+
+func Test_setSummaryConsecutiveDays(t *testing.T) {
+	user := func(days string) *report.ScheduleUser {
+		return &report.ScheduleUser{Name: "John Doe", ExtraValues: map[string]string{maxConsecutiveDaysColumn: days}}
+	}
+	summary := &report.ScheduleUser{Name: "John Doe"}
+	data := &report.PrintableData{
+		SchedulesData: []*report.ScheduleData{
+			{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{user("3")}},
+			{ID: "SCHED_2", RotaUsers: []*report.ScheduleUser{user("14")}},
+		},
+		UsersSchedulesSummary: []*report.ScheduleUser{summary},
+	}
+
+	require.NoError(t, setSummaryConsecutiveDays(data))
+	assert.Equal(t, "14", summary.ExtraValues[maxConsecutiveDaysColumn])
+}
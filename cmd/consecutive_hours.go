@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+var (
+	maxConsecutiveHoursWarn  float32
+	maxConsecutiveHoursError float32
+)
+
+func init() {
+	scheduleReportCmd.Flags().Float32Var(&maxConsecutiveHoursWarn, "max-consecutive-hours-warn", 0, "warn when a user is on call for more than this number of consecutive hours (0 to disable)")
+	scheduleReportCmd.Flags().Float32Var(&maxConsecutiveHoursError, "max-consecutive-hours-error", 0, "abort the report when a user is on call for more than this number of consecutive hours (0 to disable)")
+}
+
+// mergeStints merges the back to back and overlapping periods into stints of continuous on-call time,
+// sorted by start.
+func mergeStints(periods []*api.UserRotaPeriod) []api.UserRotaPeriod {
+	sorted := make([]*api.UserRotaPeriod, len(periods))
+	copy(sorted, periods)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	stints := make([]api.UserRotaPeriod, 0, len(sorted))
+	for _, period := range sorted {
+		last := len(stints) - 1
+		if last < 0 || period.Start.After(stints[last].End) {
+			stints = append(stints, *period)
+		} else if period.End.After(stints[last].End) {
+			stints[last].End = period.End
+		}
+	}
+	return stints
+}
+
+// longestStint returns the start and length in hours of the longest run of back to back periods.
+func longestStint(periods []*api.UserRotaPeriod) (time.Time, float32) {
+	var longestStart time.Time
+	var longest float32
+	for _, stint := range mergeStints(periods) {
+		if hours := intervalHours(stint.Start, stint.End); hours > longest {
+			longestStart, longest = stint.Start, hours
+		}
+	}
+	return longestStart, longest
+}
+
+// checkConsecutiveHours flags the users of the schedule on call for longer than the thresholds in one go,
+// as a welfare check. Going over the warning threshold adds a note to the schedule.
+func checkConsecutiveHours(scheduleData *report.ScheduleData, usersRotationData api.ScheduleUserRotationData) error {
+	userIDs := make([]string, 0, len(usersRotationData))
+	for userID := range usersRotationData {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+
+	for _, userID := range userIDs {
+		userRotaInfo := usersRotationData[userID]
+		start, hours := longestStint(userRotaInfo.Periods)
+		if maxConsecutiveHoursError > 0 && hours > maxConsecutiveHoursError {
+			return fmt.Errorf("%s was on call in schedule '%s' for %.2f consecutive hours from %s, more than the maximum of %.2f",
+				userRotaInfo.Name, scheduleData.ID, hours, start.Format(time.RFC822), maxConsecutiveHoursError)
+		}
+		if maxConsecutiveHoursWarn > 0 && hours > maxConsecutiveHoursWarn {
+			log.Printf("WARNING: %s was on call in schedule '%s' for %.2f consecutive hours from %s, more than %.2f",
+				userRotaInfo.Name, scheduleData.ID, hours, start.Format(time.RFC822), maxConsecutiveHoursWarn)
+			scheduleData.Notes = append(scheduleData.Notes, fmt.Sprintf("WARNING: %s on call for %.2f consecutive hours from %s",
+				userRotaInfo.Name, hours, start.Format(time.RFC822)))
+		}
+	}
+	return nil
+}
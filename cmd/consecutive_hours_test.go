@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_longestStint(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2023, 7, d, h, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name      string
+		periods   []*api.UserRotaPeriod
+		wantStart time.Time
+		wantHours float32
+	}{
+		{name: "no periods", wantHours: 0},
+		{
+			name: "back to back periods are merged",
+			periods: []*api.UserRotaPeriod{
+				{Start: day(4, 8), End: day(5, 8)},
+				{Start: day(3, 8), End: day(4, 8)},
+				{Start: day(10, 8), End: day(11, 20)},
+			},
+			wantStart: day(3, 8),
+			wantHours: 48,
+		},
+		{
+			name: "gaps split the stints",
+			periods: []*api.UserRotaPeriod{
+				{Start: day(3, 8), End: day(4, 8)},
+				{Start: day(4, 9), End: day(5, 8)},
+			},
+			wantStart: day(3, 8),
+			wantHours: 24,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, hours := longestStint(tt.periods)
+			require.Equal(t, tt.wantStart, start)
+			require.Equal(t, tt.wantHours, hours)
+		})
+	}
+}
+
+func Test_mergeStints(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2023, 7, d, h, 0, 0, 0, time.UTC) }
+	periods := []*api.UserRotaPeriod{
+		{Start: day(10, 8), End: day(11, 8)},
+		{Start: day(3, 8), End: day(4, 8)},
+		{Start: day(4, 8), End: day(5, 8)},
+		{Start: day(4, 20), End: day(4, 22)},
+	}
+
+	require.Equal(t, []api.UserRotaPeriod{
+		{Start: day(3, 8), End: day(5, 8)},
+		{Start: day(10, 8), End: day(11, 8)},
+	}, mergeStints(periods))
+	require.Equal(t, time.Date(2023, 7, 3, 8, 0, 0, 0, time.UTC), periods[1].Start, "the periods must not change")
+}
+
+func Test_checkConsecutiveHours(t *testing.T) {
+	defer func() { maxConsecutiveHoursWarn, maxConsecutiveHoursError = 0, 0 }()
+	usersRotationData := api.ScheduleUserRotationData{
+		"USER_1": {ID: "USER_1", Name: "John Doe", Periods: []*api.UserRotaPeriod{
+			{Start: time.Date(2023, 7, 3, 8, 0, 0, 0, time.UTC), End: time.Date(2023, 7, 6, 8, 0, 0, 0, time.UTC)},
+		}},
+	}
+
+	tests := []struct {
+		name      string
+		warn      float32
+		error     float32
+		wantErr   bool
+		wantNotes int
+	}{
+		{name: "under the thresholds", warn: 72, error: 96},
+		{name: "over the warning threshold", warn: 48, error: 96, wantNotes: 1},
+		{name: "over the error threshold", warn: 48, error: 60, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxConsecutiveHoursWarn, maxConsecutiveHoursError = tt.warn, tt.error
+			scheduleData := &report.ScheduleData{ID: "SCHED_1"}
+			err := checkConsecutiveHours(scheduleData, usersRotationData)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, scheduleData.Notes, tt.wantNotes)
+		})
+	}
+}
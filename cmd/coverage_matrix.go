@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+)
+
+// uncoveredCell marks the hours nobody is on call for.
+const uncoveredCell = "!!"
+
+var printCoverageMatrix bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&printCoverageMatrix, "print-schedule-coverage-matrix", false, "print a grid of who is on call at each hour of each day of the period, then exit without generating the report")
+}
+
+// printCoverageMatrices prints the coverage matrix of every report schedule.
+func (pd *pagerDutyClient) printCoverageMatrices(w io.Writer) error {
+	schedules, err := pd.processArguments()
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range schedules {
+		scheduleInfo, err := pd.getScheduleInformation(schedule.id, schedule.startDate, schedule.endDate)
+		if err != nil {
+			return err
+		}
+		err = pd.applyManualEntries(scheduleInfo)
+		if err != nil {
+			return err
+		}
+		err = writeCoverageMatrix(w, scheduleInfo)
+		if err != nil {
+			return fmt.Errorf("failed to print the coverage of schedule %s: %w", schedule.id, err)
+		}
+	}
+	return nil
+}
+
+// writeCoverageMatrix writes a grid with the hours of the day as rows and the days of the
+// period as columns, each cell holding the initials of the user on call at the start of the
+// hour, in the schedule time zone.
+func writeCoverageMatrix(w io.Writer, scheduleInfo *api.ScheduleInfo) error {
+	entries, err := parseRenderedScheduleEntries(scheduleInfo.FinalSchedule.RenderedScheduleEntries, scheduleInfo.Location)
+	if err != nil {
+		return err
+	}
+
+	start := scheduleInfo.Start.In(scheduleInfo.Location)
+	firstDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, scheduleInfo.Location)
+	var days []time.Time
+	for day := firstDay; day.Before(scheduleInfo.End); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+
+	legend := make(map[string]string)
+	fmt.Fprintf(w, "Schedule: '%s' (%s) - %s\n", scheduleInfo.Name, scheduleInfo.ID, scheduleInfo.Location)
+	fmt.Fprintf(w, "%-5s", "Hour")
+	for _, day := range days {
+		fmt.Fprintf(w, " %-3s", day.Format("02"))
+	}
+	fmt.Fprintln(w)
+
+	for hour := 0; hour < 24; hour++ {
+		fmt.Fprintf(w, "%02d:00", hour)
+		for _, day := range days {
+			cell := ""
+			at := time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, scheduleInfo.Location)
+			if !at.Before(scheduleInfo.Start) && at.Before(scheduleInfo.End) {
+				cell = uncoveredCell
+				if user := onCallUserAt(entries, at); user != nil {
+					cell = initials(user.Summary)
+					legend[cell] = user.Summary
+				}
+			}
+			fmt.Fprintf(w, " %-3s", cell)
+		}
+		fmt.Fprintln(w)
+	}
+
+	names := make([]string, 0, len(legend))
+	for cell := range legend {
+		names = append(names, fmt.Sprintf("%s: %s", cell, legend[cell]))
+	}
+	sort.Strings(names)
+	names = append(names, fmt.Sprintf("%s: nobody on call", uncoveredCell))
+	fmt.Fprintf(w, "%s\n\n", strings.Join(names, ", "))
+	return nil
+}
+
+func onCallUserAt(entries []onCallEntry, at time.Time) *api.User {
+	for i := range entries {
+		if !at.Before(entries[i].start) && at.Before(entries[i].end) {
+			return &entries[i].user
+		}
+	}
+	return nil
+}
+
+// initials returns the first letter of up to three words of the name.
+func initials(name string) string {
+	var result []rune
+	for _, word := range strings.Fields(name) {
+		if len(result) == 3 {
+			break
+		}
+		result = append(result, unicode.ToUpper([]rune(word)[0]))
+	}
+	return string(result)
+}
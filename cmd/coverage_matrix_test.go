@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_writeCoverageMatrix(t *testing.T) {
+	scheduleInfo := &api.ScheduleInfo{
+		ID:       "SCHED_1",
+		Name:     "Platform",
+		Location: time.UTC,
+		Start:    time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC),
+		End:      time.Date(2022, 8, 3, 0, 0, 0, 0, time.UTC),
+		FinalSchedule: api.ScheduleLayer{
+			RenderedScheduleEntries: []api.RenderedScheduleEntry{
+				{Start: "2022-08-01T00:00:00Z", End: "2022-08-01T12:00:00Z", User: api.User{ID: "USER_1", Summary: "John Doe"}},
+				{Start: "2022-08-01T12:00:00Z", End: "2022-08-02T22:00:00Z", User: api.User{ID: "USER_2", Summary: "mary jane watson smith"}},
+			},
+		},
+	}
+
+	var out bytes.Buffer
+	err := writeCoverageMatrix(&out, scheduleInfo)
+	require.NoError(t, err)
+
+	lines := strings.Split(out.String(), "\n")
+	assert.Equal(t, "Schedule: 'Platform' (SCHED_1) - UTC", lines[0])
+	assert.Equal(t, "Hour  01  02 ", lines[1])
+	assert.Equal(t, "00:00 JD  MJW", lines[2])
+	assert.Equal(t, "12:00 MJW MJW", lines[14])
+	assert.Equal(t, "22:00 MJW !! ", lines[24])
+	assert.Equal(t, "JD: John Doe, MJW: mary jane watson smith, !!: nobody on call", lines[26])
+}
+
+func Test_initials(t *testing.T) {
+	assert.Equal(t, "JD", initials("John Doe"))
+	assert.Equal(t, "RS", initials("roger Solé"))
+	assert.Equal(t, "", initials(""))
+}
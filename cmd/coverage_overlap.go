@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+)
+
+var includeCoverageOverlap bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includeCoverageOverlap, "include-coverage-overlap", false, "annotate the schedules with the person-hours when two or more users are on call at the same time in different layers")
+}
+
+type layerShift struct {
+	layer  int
+	start  time.Time
+	end    time.Time
+	userID string
+}
+
+// layerShifts returns the rendered entries of all the schedule layers.
+func layerShifts(scheduleInfo *api.ScheduleInfo) ([]layerShift, error) {
+	shifts := make([]layerShift, 0)
+	for i, layer := range scheduleInfo.ScheduleLayers {
+		for _, entry := range layer.RenderedScheduleEntries {
+			start, err := time.Parse(time.RFC3339, entry.Start)
+			if err != nil {
+				return nil, err
+			}
+			end, err := time.Parse(time.RFC3339, entry.End)
+			if err != nil {
+				return nil, err
+			}
+			shifts = append(shifts, layerShift{layer: i, start: start, end: end, userID: entry.User.ID})
+		}
+	}
+	return shifts, nil
+}
+
+// overlapPersonHours returns the person-hours of the schedule layers when two or more users are on call at
+// the same time, e.g. 2 hours with 3 users on call are 6 person-hours. A user on call in several layers
+// at the same time is counted once.
+func overlapPersonHours(scheduleInfo *api.ScheduleInfo) (float32, error) {
+	shifts, err := layerShifts(scheduleInfo)
+	if err != nil {
+		return 0, err
+	}
+	boundaries := make([]time.Time, 0, 2*len(shifts))
+	for _, shift := range shifts {
+		boundaries = append(boundaries, shift.start, shift.end)
+	}
+	sort.Slice(boundaries, func(i, j int) bool {
+		return boundaries[i].Before(boundaries[j])
+	})
+
+	var personHours float32
+	for i := 1; i < len(boundaries); i++ {
+		from, to := boundaries[i-1], boundaries[i]
+		if !from.Before(to) {
+			continue
+		}
+		users := make(map[string]bool)
+		for _, shift := range shifts {
+			if !from.Before(shift.start) && !to.After(shift.end) {
+				users[shift.userID] = true
+			}
+		}
+		if len(users) >= 2 {
+			personHours += float32(len(users)) * intervalHours(from, to)
+		}
+	}
+	return personHours, nil
+}
+
+// coverageOverlapNote returns a note with the overlap person-hours of the schedule.
+func coverageOverlapNote(scheduleInfo *api.ScheduleInfo) (string, error) {
+	personHours, err := overlapPersonHours(scheduleInfo)
+	if err != nil {
+		return "", fmt.Errorf("failed to calculate the coverage overlap of schedule %s: %w", scheduleInfo.ID, err)
+	}
+	return fmt.Sprintf("Coverage overlap: %.2f person-hours", personHours), nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_coverageOverlapNote(t *testing.T) {
+	john := api.User{ID: "USER_1", Summary: "John Doe"}
+	mary := api.User{ID: "USER_2", Summary: "Mary Jane"}
+	roger := api.User{ID: "USER_3", Summary: "Roger Solé"}
+
+	tests := []struct {
+		name   string
+		layers []api.ScheduleLayer
+		want   string
+	}{
+		{
+			name: "single layer",
+			layers: []api.ScheduleLayer{{RenderedScheduleEntries: []api.RenderedScheduleEntry{
+				{Start: "2022-08-01T00:00:00Z", End: "2022-08-02T00:00:00Z", User: john},
+				{Start: "2022-08-02T00:00:00Z", End: "2022-08-03T00:00:00Z", User: mary},
+			}}},
+			want: "Coverage overlap: 0.00 person-hours",
+		},
+		{
+			name: "overlapping layers",
+			layers: []api.ScheduleLayer{
+				{RenderedScheduleEntries: []api.RenderedScheduleEntry{
+					{Start: "2022-08-01T00:00:00Z", End: "2022-08-02T00:00:00Z", User: john},
+				}},
+				{RenderedScheduleEntries: []api.RenderedScheduleEntry{
+					{Start: "2022-08-01T18:00:00Z", End: "2022-08-02T06:00:00Z", User: mary},
+				}},
+				{RenderedScheduleEntries: []api.RenderedScheduleEntry{
+					{Start: "2022-08-01T22:00:00Z", End: "2022-08-01T23:30:00Z", User: roger},
+				}},
+			},
+			// 6h with John and Mary, 1.5h of which Roger too
+			want: "Coverage overlap: 13.50 person-hours",
+		},
+		{
+			name: "same user in two layers",
+			layers: []api.ScheduleLayer{
+				{RenderedScheduleEntries: []api.RenderedScheduleEntry{
+					{Start: "2022-08-01T00:00:00Z", End: "2022-08-02T00:00:00Z", User: john},
+				}},
+				{RenderedScheduleEntries: []api.RenderedScheduleEntry{
+					{Start: "2022-08-01T00:00:00Z", End: "2022-08-02T00:00:00Z", User: john},
+				}},
+			},
+			want: "Coverage overlap: 0.00 person-hours",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			note, err := coverageOverlapNote(&api.ScheduleInfo{ID: "SCHED_1", ScheduleLayers: tt.layers})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, note)
+		})
+	}
+}
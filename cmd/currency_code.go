@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const currencyCodeColumn = "currency_code"
+
+var includeCurrencyCodeColumn bool
+
+// currencyCodes are the ISO 4217 codes of the common currency symbols.
+var currencyCodes = map[string]string{
+	"£":   "GBP",
+	"€":   "EUR",
+	"$":   "USD",
+	"US$": "USD",
+	"¥":   "JPY",
+	"₹":   "INR",
+	"CHF": "CHF",
+	"zł":  "PLN",
+	"kr":  "SEK",
+}
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includeCurrencyCodeColumn, "include-currency-code-column", false, "show the amounts as bare numbers with the ISO 4217 code of the currency in a currency_code column, for financial systems")
+}
+
+// currencyCode returns the ISO 4217 code of the configured currency, either configured as currencyCode or
+// derived from the currency symbol.
+func currencyCode() (string, error) {
+	if Config.RotationPrices.CurrencyCode != "" {
+		return strings.ToUpper(Config.RotationPrices.CurrencyCode), nil
+	}
+	currency := strings.TrimSpace(Config.RotationPrices.Currency)
+	if code, ok := currencyCodes[currency]; ok {
+		return code, nil
+	}
+	if isCurrencyCode(currency) {
+		return currency, nil
+	}
+	return "", fmt.Errorf("no currency code known for %q, configure it in rotationPrices.currencyCode", currency)
+}
+
+func isCurrencyCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// reportCurrency returns the currency symbol or format shown with the amounts, no symbol when the currency has its
+// own column.
+func reportCurrency() string {
+	if currencyFormat != "" {
+		return currencyFormat
+	}
+	if includeCurrencyCodeColumn {
+		return ""
+	}
+	return Config.RotationPrices.Currency
+}
+
+// setCurrencyCodes sets the currency code of the users of each schedule and of the summary.
+func setCurrencyCodes(data *report.PrintableData, code string) {
+	users := data.UsersSchedulesSummary
+	for _, scheduleData := range data.SchedulesData {
+		users = append(users[:len(users):len(users)], scheduleData.RotaUsers...)
+	}
+	for _, user := range users {
+		setExtraValue(user, currencyCodeColumn, code)
+	}
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_currencyCode(t *testing.T) {
+	tests := []struct {
+		name         string
+		currency     string
+		currencyCode string
+		want         string
+		wantErr      bool
+	}{
+		{name: "pound symbol", currency: "£", want: "GBP"},
+		{name: "euro symbol with spaces", currency: " € ", want: "EUR"},
+		{name: "currency configured as a code", currency: "AUD", want: "AUD"},
+		{name: "configured code", currency: "$", currencyCode: "cad", want: "CAD"},
+		{name: "unknown symbol", currency: "₿", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConfig(t, configuration.New())
+			Config.RotationPrices.Currency = tt.currency
+			Config.RotationPrices.CurrencyCode = tt.currencyCode
+
+			got, err := currencyCode()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+var currencyFormat string
+
+func init() {
+	scheduleReportCmd.Flags().StringVar(&currencyFormat, "currency-format", "", "format of the amounts instead of the currency symbol before them, with {amount} replaced by the number, e.g. \"£{amount} GBP\"")
+}
+
+func validateCurrencyFormat(format string) error {
+	if format == "" {
+		return nil
+	}
+	if count := strings.Count(format, report.AmountPlaceholder); count != 1 {
+		return fmt.Errorf("currency format %q has %d %s placeholders, use exactly one", format, count, report.AmountPlaceholder)
+	}
+	return nil
+}
+
+// invoiceCurrency returns the currency symbol or format shown with the amounts of the invoice and of the
+// messages, see formatCurrencyAmount. It is empty without a format when there is no configuration loaded.
+func invoiceCurrency() string {
+	if currencyFormat != "" {
+		return currencyFormat
+	}
+	if Config == nil {
+		return ""
+	}
+	return Config.RotationPrices.Currency
+}
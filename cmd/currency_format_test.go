@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateCurrencyFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		wantErr string
+	}{
+		{name: "Not set", format: ""},
+		{name: "One placeholder", format: "£{amount} GBP"},
+		{name: "No placeholder", format: "£ GBP", wantErr: `currency format "£ GBP" has 0 {amount} placeholders, use exactly one`},
+		{name: "Two placeholders", format: "{amount} ({amount})", wantErr: `currency format "{amount} ({amount})" has 2 {amount} placeholders, use exactly one`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCurrencyFormat(tt.format)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_currencyFormat_consoleReport(t *testing.T) {
+	defer func() { currencyFormat = "" }()
+	setConfig(t, configuration.New())
+	currencyFormat = "£{amount} GBP"
+
+	data := &report.PrintableData{
+		UsersSchedulesSummary: []*report.ScheduleUser{{Name: "Alice", TotalAmount: 125.5}},
+	}
+	var out bytes.Buffer
+	_, err := newReportWriter("console", &out).GenerateReport(data)
+
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "£125.50 GBP")
+	assert.NotContains(t, out.String(), "££")
+}
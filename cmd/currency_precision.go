@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const defaultCurrencyDecimals = 2
+
+var currencyPrecisions map[string]int
+
+// currencyDecimals are the ISO 4217 decimal places of the currencies not using 2.
+var currencyDecimals = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+func init() {
+	scheduleReportCmd.Flags().StringToIntVar(&currencyPrecisions, "currency-precision-map", map[string]int{}, "decimal places of the amounts in a currency instead of its ISO 4217 ones, as <currencyCode>=<decimals>, e.g. JPY=0")
+}
+
+func validateCurrencyPrecisions(precisions map[string]int) error {
+	for code, decimals := range precisions {
+		if !isCurrencyCode(strings.ToUpper(code)) {
+			return fmt.Errorf("currency precision: %s is not an ISO 4217 currency code", code)
+		}
+		if decimals < 0 || decimals > 4 {
+			return fmt.Errorf("currency precision: %d decimal places for %s, use 0 to 4", decimals, code)
+		}
+	}
+	return nil
+}
+
+// amountDecimals returns the decimal places the amounts are rounded to and shown with, those of the configured
+// currency, 2 if it is not known or there is no configuration loaded.
+func amountDecimals() int {
+	if Config == nil {
+		return defaultCurrencyDecimals
+	}
+	code, err := currencyCode()
+	if err != nil {
+		return defaultCurrencyDecimals
+	}
+	for overridden, decimals := range currencyPrecisions {
+		if strings.ToUpper(overridden) == code {
+			return decimals
+		}
+	}
+	if decimals, ok := currencyDecimals[code]; ok {
+		return decimals
+	}
+	return defaultCurrencyDecimals
+}
+
+// formatAmount returns the amount with the decimal places of the currency.
+func formatAmount(amount float32) string {
+	return fmt.Sprintf("%.*f", amountDecimals(), amount)
+}
+
+// formatCurrencyAmount returns the amount with the decimal places of the currency and its symbol, or in the
+// currency format, for the messages and notes showing amounts.
+func formatCurrencyAmount(amount float32) string {
+	return report.FormatAmount(invoiceCurrency(), amount, amountDecimals())
+}
+
+func roundToDecimals(round func(float64) float64, amount float32, decimals int) float32 {
+	scale := math.Pow10(decimals)
+	return float32(round(float64(amount)*scale) / scale)
+}
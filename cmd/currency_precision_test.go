@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_amountDecimals(t *testing.T) {
+	defer func() { currencyPrecisions = map[string]int{} }()
+
+	tests := []struct {
+		name       string
+		currency   string
+		precisions map[string]int
+		want       int
+	}{
+		{name: "Default", currency: "£", want: 2},
+		{name: "ISO 4217 decimal places", currency: "¥", want: 0},
+		{name: "Currency code", currency: "KWD", want: 3},
+		{name: "Overridden", currency: "£", precisions: map[string]int{"gbp": 3}, want: 3},
+		{name: "Unknown currency", currency: "₿", want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConfig(t, configuration.New())
+			Config.RotationPrices.Currency = tt.currency
+			currencyPrecisions = tt.precisions
+
+			assert.Equal(t, tt.want, amountDecimals())
+		})
+	}
+}
+
+func Test_amountDecimals_noConfig(t *testing.T) {
+	setConfig(t, nil)
+
+	assert.Equal(t, 2, amountDecimals())
+	assert.Equal(t, float32(4.17), roundCurrency(4.16666))
+}
+
+func Test_formatCurrencyAmount(t *testing.T) {
+	defer func() { currencyFormat = "" }()
+	setConfig(t, configuration.New())
+	Config.RotationPrices.Currency = "¥"
+	assert.Equal(t, "¥4500", formatCurrencyAmount(4500))
+
+	currencyFormat = "{amount} JPY"
+	assert.Equal(t, "4500 JPY", formatCurrencyAmount(4500))
+
+	setConfig(t, nil)
+	currencyFormat = ""
+	assert.Equal(t, "4500.00", formatCurrencyAmount(4500))
+}
+
+func Test_roundCurrency_currencyDecimals(t *testing.T) {
+	setConfig(t, configuration.New())
+	Config.RotationPrices.Currency = "KWD"
+	assert.Equal(t, float32(4.167), roundCurrency(4.16666))
+
+	Config.RotationPrices.Currency = "¥"
+	assert.Equal(t, float32(417), roundCurrency(416.6666))
+
+	roundAmount, err := currencyRounder("half_even")
+	assert.NoError(t, err)
+	assert.Equal(t, float32(416), roundAmount(416.5))
+}
+
+func Test_validateCurrencyPrecisions(t *testing.T) {
+	assert.NoError(t, validateCurrencyPrecisions(map[string]int{"JPY": 0, "kwd": 3}))
+	assert.ErrorContains(t, validateCurrencyPrecisions(map[string]int{"YEN": 5}), "5 decimal places for YEN")
+	assert.ErrorContains(t, validateCurrencyPrecisions(map[string]int{"£": 2}), "£ is not an ISO 4217 currency code")
+}
+
+func Test_newReportWriter_currencyDecimals(t *testing.T) {
+	setConfig(t, configuration.New())
+	Config.RotationPrices.Currency = "¥"
+	data := &report.PrintableData{
+		UsersSchedulesSummary: []*report.ScheduleUser{{Name: "John Doe", TotalAmountWorkHours: 4500, TotalAmount: 4500}},
+	}
+
+	var out bytes.Buffer
+	_, err := newReportWriter("console", &out).GenerateReport(data)
+
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "¥4500 ")
+	assert.NotContains(t, out.String(), "¥4500.00")
+}
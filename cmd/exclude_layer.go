@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+)
+
+var excludedLayers []int
+
+func init() {
+	scheduleReportCmd.Flags().IntSliceVar(&excludedLayers, "exclude-layer", []int{}, "1-based index of a schedule layer, as listed by PagerDuty from the highest priority one, whose entries are left out of the report, e.g. a training layer")
+}
+
+// scheduleExcludedLayers returns the layers left out of the schedule, those of --exclude-layer and those
+// configured for the schedule.
+func scheduleExcludedLayers(scheduleID string) []int {
+	layers := append([]int(nil), excludedLayers...)
+	if settings := Config.FindScheduleSettingsByID(scheduleID); settings != nil {
+		for _, layer := range settings.ExcludedLayers {
+			if !containsInt(layers, layer) {
+				layers = append(layers, layer)
+			}
+		}
+	}
+	sort.Ints(layers)
+	return layers
+}
+
+func containsInt(s []int, e int) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
+
+// excludeLayers drops the given layers from the schedule and rebuilds its final schedule from the remaining
+// layers, each one covering the lower priority ones, with the overrides on top.
+func excludeLayers(scheduleInfo *api.ScheduleInfo, layers []int) error {
+	if len(layers) == 0 {
+		return nil
+	}
+	for _, layer := range layers {
+		if layer < 1 || layer > len(scheduleInfo.ScheduleLayers) {
+			log.Printf("WARNING: schedule '%s' has no layer %d to exclude", scheduleInfo.ID, layer)
+		}
+	}
+
+	var entries []onCallEntry
+	remainingLayers := make([]api.ScheduleLayer, 0, len(scheduleInfo.ScheduleLayers))
+	for i := len(scheduleInfo.ScheduleLayers) - 1; i >= 0; i-- {
+		layer := scheduleInfo.ScheduleLayers[i]
+		if containsInt(layers, i+1) {
+			log.Printf("[%s] excluding layer %d (%s)", scheduleInfo.ID, i+1, layer.Name)
+			continue
+		}
+		layerEntries, err := parseRenderedScheduleEntries(layer.RenderedScheduleEntries, scheduleInfo.Location)
+		if err != nil {
+			return fmt.Errorf("failed to parse the entries of layer %d of schedule %s: %w", i+1, scheduleInfo.ID, err)
+		}
+		entries = overrideOnCallEntries(entries, layerEntries)
+		remainingLayers = append([]api.ScheduleLayer{layer}, remainingLayers...)
+	}
+
+	overrides, err := parseRenderedScheduleEntries(scheduleInfo.OverrideSubschedule.RenderedScheduleEntries, scheduleInfo.Location)
+	if err != nil {
+		return fmt.Errorf("failed to parse the overrides of schedule %s: %w", scheduleInfo.ID, err)
+	}
+	entries = clipOnCallEntries(overrideOnCallEntries(entries, overrides), scheduleInfo.Start, scheduleInfo.End)
+
+	scheduleInfo.ScheduleLayers = remainingLayers
+	scheduleInfo.FinalSchedule.RenderedScheduleEntries = formatRenderedScheduleEntries(entries)
+	return nil
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_scheduleExcludedLayers(t *testing.T) {
+	defer func() { excludedLayers = []int{} }()
+	setConfig(t, configuration.New())
+	Config.ScheduleSettings = []configuration.ScheduleSettings{{Id: "SCHED_1", ExcludedLayers: []int{3, 2}}}
+	excludedLayers = []int{2}
+
+	assert.Equal(t, []int{2, 3}, scheduleExcludedLayers("SCHED_1"))
+	assert.Equal(t, []int{2}, scheduleExcludedLayers("SCHED_2"))
+}
+
+func Test_excludeLayers(t *testing.T) {
+	john := api.User{ID: "USER_1", Summary: "John Doe"}
+	mary := api.User{ID: "USER_2", Summary: "Mary Jane"}
+	trainee := api.User{ID: "USER_3", Summary: "Joe Bloggs"}
+	entry := func(start, end string, user api.User) api.RenderedScheduleEntry {
+		return api.RenderedScheduleEntry{Start: start, End: end, User: user}
+	}
+	newScheduleInfo := func() *api.ScheduleInfo {
+		return &api.ScheduleInfo{
+			ID:       "SCHED_1",
+			Location: time.UTC,
+			Start:    time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC),
+			End:      time.Date(2022, 8, 3, 0, 0, 0, 0, time.UTC),
+			ScheduleLayers: []api.ScheduleLayer{
+				{Name: "Training", RenderedScheduleEntries: []api.RenderedScheduleEntry{
+					entry("2022-08-01T09:00:00Z", "2022-08-01T17:00:00Z", trainee),
+				}},
+				{Name: "Primary", RenderedScheduleEntries: []api.RenderedScheduleEntry{
+					entry("2022-08-01T00:00:00Z", "2022-08-02T00:00:00Z", john),
+					entry("2022-08-02T00:00:00Z", "2022-08-03T00:00:00Z", mary),
+				}},
+			},
+			OverrideSubschedule: api.ScheduleLayer{RenderedScheduleEntries: []api.RenderedScheduleEntry{
+				entry("2022-08-02T12:00:00Z", "2022-08-02T18:00:00Z", john),
+			}},
+			FinalSchedule: api.ScheduleLayer{RenderedScheduleEntries: []api.RenderedScheduleEntry{
+				entry("2022-08-01T00:00:00Z", "2022-08-01T09:00:00Z", john),
+				entry("2022-08-01T09:00:00Z", "2022-08-01T17:00:00Z", trainee),
+			}},
+		}
+	}
+
+	t.Run("training layer excluded", func(t *testing.T) {
+		scheduleInfo := newScheduleInfo()
+
+		require.NoError(t, excludeLayers(scheduleInfo, []int{1}))
+
+		assert.Equal(t, []api.RenderedScheduleEntry{
+			entry("2022-08-01T00:00:00Z", "2022-08-02T00:00:00Z", john),
+			entry("2022-08-02T00:00:00Z", "2022-08-02T12:00:00Z", mary),
+			entry("2022-08-02T12:00:00Z", "2022-08-02T18:00:00Z", john),
+			entry("2022-08-02T18:00:00Z", "2022-08-03T00:00:00Z", mary),
+		}, scheduleInfo.FinalSchedule.RenderedScheduleEntries)
+		require.Len(t, scheduleInfo.ScheduleLayers, 1)
+		assert.Equal(t, "Primary", scheduleInfo.ScheduleLayers[0].Name)
+	})
+
+	t.Run("nothing excluded", func(t *testing.T) {
+		scheduleInfo := newScheduleInfo()
+
+		require.NoError(t, excludeLayers(scheduleInfo, nil))
+
+		assert.Equal(t, newScheduleInfo(), scheduleInfo)
+	})
+
+	t.Run("invalid entry", func(t *testing.T) {
+		scheduleInfo := newScheduleInfo()
+		scheduleInfo.ScheduleLayers[1].RenderedScheduleEntries[0].Start = "yesterday"
+
+		assert.Error(t, excludeLayers(scheduleInfo, []int{1}))
+	})
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const (
+	projectedColumn = "projected"
+	projectedValue  = "PROJECTED"
+)
+
+var (
+	allowFutureDates bool
+
+	// projectedAfter is when the report was generated if its period ends later, the on-call time
+	// after it coming from the schedules as currently planned.
+	projectedAfter time.Time
+)
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&allowFutureDates, "allow-future-dates", false, "generate a partial report when the period ends in the future, marking the users with on-call time after now as PROJECTED")
+}
+
+// checkFutureDates fails if the period of any schedule ends after now, unless future dates are allowed,
+// and returns the time the on-call time is projected after, zero if none is. The periods end at the
+// hour the daily rotation starts, the hours before it belonging to the previous day, so a period ending
+// today is not in the future, e.g. a monthly report run at midnight on the 1st.
+func checkFutureDates(schedules []Schedule, now time.Time) (time.Time, error) {
+	rotationStart := time.Duration(Config.RotationInfo.DailyRotationStartsAt) * time.Hour
+	for _, schedule := range schedules {
+		if !schedule.endDate.Add(-rotationStart).After(now) {
+			continue
+		}
+		if !allowFutureDates {
+			return time.Time{}, fmt.Errorf("the report period of schedule %s ends in the future (%s), use --allow-future-dates to generate a partial report",
+				schedule.id, schedule.endDate.Format(time.RFC822))
+		}
+		log.Printf("WARNING: the report period ends in the future, this is a PARTIAL report and the on-call time after %s is PROJECTED from the current schedules",
+			now.Format(time.RFC822))
+		return now, nil
+	}
+	return time.Time{}, nil
+}
+
+// isProjected reports whether any of the periods has on-call time after the given time.
+func isProjected(periods []*api.UserRotaPeriod, after time.Time) bool {
+	for _, period := range periods {
+		if period.End.After(after) {
+			return true
+		}
+	}
+	return false
+}
+
+func projectedNote(after time.Time) string {
+	return fmt.Sprintf("%s: the on-call time after %s comes from the schedule as currently planned", projectedValue, after.Format(time.RFC822))
+}
+
+// setSummaryProjected marks the users in the summary projected in any schedule.
+func setSummaryProjected(data *report.PrintableData) {
+	projected := make(map[string]bool)
+	for _, scheduleData := range data.SchedulesData {
+		for _, user := range scheduleData.RotaUsers {
+			if user.ExtraValues[projectedColumn] == projectedValue {
+				projected[user.Name] = true
+			}
+		}
+	}
+	for _, user := range data.UsersSchedulesSummary {
+		value := ""
+		if projected[user.Name] {
+			value = projectedValue
+		}
+		setExtraValue(user, projectedColumn, value)
+	}
+}
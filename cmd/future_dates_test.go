@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkFutureDates(t *testing.T) {
+	defer func() { allowFutureDates = false }()
+	setConfig(t, configuration.New())
+	Config.RotationInfo.DailyRotationStartsAt = 8
+	now := time.Date(2023, 7, 17, 10, 0, 0, 0, time.UTC)
+	past := []Schedule{{id: "SCHED_1", endDate: time.Date(2023, 7, 1, 8, 0, 0, 0, time.UTC)}}
+	future := append(past, Schedule{id: "SCHED_2", endDate: time.Date(2023, 8, 1, 8, 0, 0, 0, time.UTC)})
+	midnight := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		schedules        []Schedule
+		allowFutureDates bool
+		now              time.Time
+		want             time.Time
+		wantErr          string
+	}{
+		{name: "Period in the past", schedules: past},
+		{
+			name:      "Period ending in the future",
+			schedules: future,
+			wantErr:   "the report period of schedule SCHED_2 ends in the future",
+		},
+		{
+			name:             "Future dates allowed",
+			schedules:        future,
+			allowFutureDates: true,
+			want:             now,
+		},
+		{
+			name:      "Monthly run at midnight on the 1st",
+			schedules: future,
+			now:       midnight,
+		},
+		{
+			name:      "Year to date run before the rotation hour",
+			schedules: []Schedule{{id: "SCHED_1", endDate: time.Date(2023, 7, 17, 8, 0, 0, 0, time.UTC)}},
+			now:       time.Date(2023, 7, 17, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "Period ending tomorrow",
+			schedules: []Schedule{{id: "SCHED_1", endDate: time.Date(2023, 7, 18, 8, 0, 0, 0, time.UTC)}},
+			wantErr:   "the report period of schedule SCHED_1 ends in the future",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowFutureDates = tt.allowFutureDates
+			if tt.now.IsZero() {
+				tt.now = now
+			}
+
+			got, err := checkFutureDates(tt.schedules, tt.now)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_isProjected(t *testing.T) {
+	now := time.Date(2023, 7, 17, 10, 0, 0, 0, time.UTC)
+	periods := []*api.UserRotaPeriod{{Start: now.Add(-48 * time.Hour), End: now.Add(-24 * time.Hour)}}
+
+	assert.False(t, isProjected(periods, now))
+	assert.True(t, isProjected(append(periods, &api.UserRotaPeriod{Start: now.Add(-time.Hour), End: now.Add(time.Hour)}), now))
+}
+
+func Test_setSummaryProjected(t *testing.T) {
+	data := &report.PrintableData{
+		SchedulesData: []*report.ScheduleData{
+			{RotaUsers: []*report.ScheduleUser{
+				{Name: "John Doe", ExtraValues: map[string]string{projectedColumn: ""}},
+				{Name: "Jane Doe", ExtraValues: map[string]string{projectedColumn: ""}},
+			}},
+			{RotaUsers: []*report.ScheduleUser{
+				{Name: "John Doe", ExtraValues: map[string]string{projectedColumn: projectedValue}},
+			}},
+		},
+		UsersSchedulesSummary: []*report.ScheduleUser{{Name: "John Doe"}, {Name: "Jane Doe"}},
+	}
+
+	setSummaryProjected(data)
+	assert.Equal(t, projectedValue, data.UsersSchedulesSummary[0].ExtraValues[projectedColumn])
+	assert.Equal(t, "", data.UsersSchedulesSummary[1].ExtraValues[projectedColumn])
+}
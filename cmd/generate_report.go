@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"math"
+	"os"
+	"strconv"
 	"time"
 
 	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
@@ -20,30 +23,98 @@ var (
 		Short: "generates the report(s) for the given schedule(s) id(s)",
 		Long:  "Generates the report of the given list of schedules or all (except the ignored ones configured in yml)",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// the configuration may not be loaded, so these run before anything depending on it
+			if validateConfigOnly {
+				return runValidateConfig(os.Stdout)
+			}
+			if watchConfig {
+				return runWatchConfig(os.Stdout)
+			}
+			if configErr != nil {
+				// notified with the notifiers the flags and environment variables configure
+				if notifiers, err := reportNotifiers(); err == nil {
+					(&pagerDutyClient{}).notifyReportResult(notifiers, configErr)
+				}
+				return configErr
+			}
+			client, closeClient, err := newPagerDutyAPIClient()
+			if err != nil {
+				return err
+			}
+			defer closeClient()
 			pd := &pagerDutyClient{
-				client:              api.NewPagerDutyAPIClient(Config.PdAuthToken),
+				client:              client,
 				defaultUserTimezone: Config.DefaultUserTimezone,
 			}
-			return pd.generateReport()
+			if checkForUpdates {
+				return checkLatestRelease(os.Stdout, latestReleaseURL)
+			}
+			if printCoverageMatrix {
+				return pd.printCoverageMatrices(os.Stdout)
+			}
+			if printHourlyRates {
+				return pd.printHourlyRates(os.Stdout)
+			}
+			if rateContractFile != "" {
+				return pd.testRatesAgainstContract(os.Stdout)
+			}
+			if scheduleHealthCheck {
+				return pd.printScheduleHealth(os.Stdout)
+			}
+			notifiers, err := reportNotifiers()
+			if err != nil {
+				return err
+			}
+			var profiler *memoryProfiler
+			if profileMemory {
+				profiler = startMemoryProfiler()
+			}
+			err = withStatusPageMaintenance(pd.generateReport)
+			if profiler != nil {
+				profiler.Stop(os.Stderr)
+			}
+			if apiCallStats != nil {
+				_ = writeAPICallSummary(os.Stderr, apiCallStats)
+			}
+			// a rejected payment is a decision, not a failure to alert about
+			if !errors.Is(err, errPaymentRejected) {
+				pd.notifyReportResult(notifiers, err)
+			}
+			return err
 		},
 	}
 
-	rawSchedules []string
-	outputFormat string
-	directory    string
+	rawSchedules              []string
+	outputFormats             []string
+	directory                 string
+	scheduleTimezoneOverrides map[string]string
+	accountNameOverride       string
+	outputLineEnding          string
+	outputSeparator           string
+	csvSeparator              rune
+	skipEmptySchedules        bool
+	warnEmptySchedules        bool
+	periodLabel               string
 )
 
 func init() {
 	scheduleReportCmd.Flags().StringSliceVarP(&rawSchedules, "schedules", "s", []string{"all"}, "schedule ids to report (comma-separated with no spaces), or 'all'")
-	scheduleReportCmd.Flags().StringVarP(&outputFormat, "output-format", "o", "console", "pdf, console, csv")
+	scheduleReportCmd.Flags().StringSliceVarP(&outputFormats, "output-format", "o", []string{"console"}, "pdf, console, csv (comma-separated for several formats)")
 	scheduleReportCmd.Flags().StringVarP(&directory, "output", "d", "", "output path (default is $HOME)")
+	scheduleReportCmd.Flags().StringVar(&outputLineEnding, "output-line-ending", "lf", "line ending of the csv output: crlf, lf")
+	scheduleReportCmd.Flags().StringVar(&outputSeparator, "output-separator", ",", "field separator of the csv output, e.g. \"\\t\" for tab-separated or \"|\" for pipe-separated files")
+	scheduleReportCmd.Flags().BoolVar(&skipEmptySchedules, "skip-empty-schedules", false, "omit schedules with no on-call entries in the report period")
+	scheduleReportCmd.Flags().BoolVar(&warnEmptySchedules, "warn-empty-schedules", false, "log a warning for each schedule skipped by --skip-empty-schedules")
+	scheduleReportCmd.Flags().StringVar(&periodLabel, "period-label", "", "human-readable name of the report period shown in the report header, e.g. \"Q1 2024\"")
+	scheduleReportCmd.Flags().StringVar(&accountNameOverride, "account-name-override", "", "account name to show in the report instead of the configured one")
+	scheduleReportCmd.Flags().StringToStringVar(&scheduleTimezoneOverrides, "schedule-timezone-override", map[string]string{}, "time zone to use for a schedule instead of the one configured in PagerDuty, as <scheduleID>=<timezone>")
 	rootCmd.AddCommand(scheduleReportCmd)
 }
 
-// roundCurrency rounds a float32 value to 2 decimal places for clean currency amounts.
+// roundCurrency rounds a float32 value to the decimal places of the currency for clean currency amounts.
 // This prevents messy recurring decimals (e.g., £4.166666) in payment reports.
 func roundCurrency(amount float32) float32 {
-	return float32(math.Round(float64(amount)*100) / 100)
+	return roundToDecimals(math.Round, amount, amountDecimals())
 }
 
 type Schedule struct {
@@ -61,10 +132,34 @@ func contains(s []string, e string) bool {
 	return false
 }
 
-func (pd *pagerDutyClient) processArguments() []Schedule {
-	if !contains([]string{"console", "pdf", "csv"}, outputFormat) {
-		log.Printf("output format %s not supported. Defaulting to 'console'", outputFormat)
-		outputFormat = "console"
+func (pd *pagerDutyClient) processArguments() ([]Schedule, error) {
+	outputFormats = supportedFormats(outputFormats)
+	if !contains([]string{sourceOfTruthAPI, sourceOfTruthConfig}, overrideSourceOfTruth) {
+		return nil, fmt.Errorf("source of truth %s not supported, use api or config", overrideSourceOfTruth)
+	}
+	if _, ok := userSortKeys[userOrder]; userOrder != "" && !ok {
+		return nil, fmt.Errorf("user order %s not supported", userOrder)
+	}
+	if !contains([]string{scheduleOrderAPI, scheduleOrderAlphabetical, scheduleOrderConfig}, scheduleOrder) {
+		return nil, fmt.Errorf("schedule order %s not supported, use api, alphabetical or config", scheduleOrder)
+	}
+	if !contains([]string{"crlf", "lf"}, outputLineEnding) {
+		return nil, fmt.Errorf("output line ending %s not supported, use crlf or lf", outputLineEnding)
+	}
+	separator, err := parseOutputSeparator(outputSeparator)
+	if err != nil {
+		return nil, err
+	}
+	csvSeparator = separator
+	if err := validateCurrencyPrecisions(currencyPrecisions); err != nil {
+		return nil, err
+	}
+	if err := validateCurrencyFormat(currencyFormat); err != nil {
+		return nil, err
+	}
+	scheduleWeights, err = parseScheduleWeights(rawScheduleWeights)
+	if err != nil {
+		return nil, err
 	}
 	if directory == "" {
 		directory, _ = homedir.Dir()
@@ -77,7 +172,7 @@ func (pd *pagerDutyClient) processArguments() []Schedule {
 		var err error
 		defaultStartDate, err = time.Parse(time.RFC822, Config.ReportTimeRange.Start)
 		if err != nil {
-			log.Fatalf("Error parsing report start time: %s", err)
+			return nil, fmt.Errorf("error parsing report start time: %w", err)
 		}
 	} else {
 		defaultStartDate = time.Date(lastMonth.Year(), lastMonth.Month(), 1, 0, 0, 0, 0, time.UTC)
@@ -88,13 +183,31 @@ func (pd *pagerDutyClient) processArguments() []Schedule {
 		var err error
 		defaultEndDate, err = time.Parse(time.RFC822, Config.ReportTimeRange.End)
 		if err != nil {
-			log.Fatalf("Error parsing report end time: %s", err)
+			return nil, fmt.Errorf("error parsing report end time: %w", err)
 		}
 	} else {
 		defaultEndDate = defaultStartDate.AddDate(0, 1, 0)
 		defaultEndDate = defaultEndDate.Add(time.Hour * time.Duration(Config.RotationInfo.DailyRotationStartsAt))
 	}
 
+	if period != "" {
+		var err error
+		defaultStartDate, defaultEndDate, err = periodRange(period, fiscalYearStart, monthStartDay, now)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("Period %s resolved to %s - %s", period, defaultStartDate.Format("2006-01-02"),
+			defaultEndDate.AddDate(0, 0, -1).Format("2006-01-02"))
+		defaultEndDate = defaultEndDate.Add(time.Hour * time.Duration(Config.RotationInfo.DailyRotationStartsAt))
+	}
+
+	if scheduleNamePattern != "" {
+		rawSchedules, err = pd.scheduleIDsFromName(scheduleNamePattern, allScheduleNameMatches)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	startOverrides := make(map[string]time.Time)
 	endOverrides := make(map[string]time.Time)
 
@@ -102,11 +215,11 @@ func (pd *pagerDutyClient) processArguments() []Schedule {
 		var err error
 		startOverrides[override.Id], err = time.Parse(time.RFC822, override.Start)
 		if err != nil {
-			log.Fatalf("Error parsing start time override for schedule %s: %v", override.Id, err)
+			return nil, fmt.Errorf("error parsing start time override for schedule %s: %w", override.Id, err)
 		}
 		endOverrides[override.Id], err = time.Parse(time.RFC822, override.End)
 		if err != nil {
-			log.Fatalf("Error parsing end time override for schedule %s: %v", override.Id, err)
+			return nil, fmt.Errorf("error parsing end time override for schedule %s: %w", override.Id, err)
 		}
 	}
 
@@ -114,7 +227,7 @@ func (pd *pagerDutyClient) processArguments() []Schedule {
 	if len(rawSchedules) == 1 && rawSchedules[0] == "all" {
 		schedulesList, err := pd.client.ListSchedules()
 		if err != nil {
-			log.Fatalln(fmt.Sprintf("Error getting the schedules list: %s", err.Error()))
+			return nil, fmt.Errorf("error getting the schedules list: %w", err)
 		}
 
 		for _, schedule := range schedulesList {
@@ -175,20 +288,23 @@ func (pd *pagerDutyClient) processArguments() []Schedule {
 
 				log.Printf("[%s] defaultStartDate: %s, defaultEndDate: %s", schedule, thisStartDate, thisEndDate)
 			} else {
-				log.Fatal("Configuration explicitly ignores schedule passed as parameter - check your config.")
+				return nil, fmt.Errorf("configuration explicitly ignores schedule '%s' passed as parameter - check your config", schedule)
 			}
 		}
 	}
 
-	return schedules
-}
+	if tierFilter != "" {
+		schedules = filterSchedulesByTier(schedules, tierFilter)
+	}
 
-func (pd *pagerDutyClient) generateReport() error {
-	input := pd.processArguments()
+	return schedules, nil
+}
 
+// reportTimeRange returns the earliest start date and the latest end date of the given schedules.
+func reportTimeRange(schedules []Schedule) (time.Time, time.Time) {
 	firstStartDate := time.Now()
 	lastEndDate := time.Time{}
-	for _, schedule := range input {
+	for _, schedule := range schedules {
 		if schedule.startDate.Before(firstStartDate) {
 			firstStartDate = schedule.startDate
 		}
@@ -197,13 +313,79 @@ func (pd *pagerDutyClient) generateReport() error {
 			lastEndDate = schedule.endDate
 		}
 	}
-	configuration.LoadCalendars(firstStartDate.Year())
+	return firstStartDate, lastEndDate
+}
+
+// isScheduleEmpty reports whether the schedule has no on-call entries in the report period,
+// as happens with seasonal schedules.
+func isScheduleEmpty(scheduleInfo *api.ScheduleInfo) bool {
+	return len(scheduleInfo.FinalSchedule.RenderedScheduleEntries) == 0
+}
+
+func logSkippedSchedule(scheduleInfo *api.ScheduleInfo, warn bool) {
+	level := "DEBUG"
+	if warn {
+		level = "WARNING"
+	}
+	log.Printf("%s: skipping schedule '%s' (%s) as it has no entries from %s to %s",
+		level, scheduleInfo.Name, scheduleInfo.ID, scheduleInfo.Start, scheduleInfo.End)
+}
+
+func (pd *pagerDutyClient) generateReport() error {
+	input, err := pd.processArguments()
+	if err != nil {
+		return err
+	}
+	pd.reportSchedules = input
+
+	projectedAfter, err = checkFutureDates(input, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var invoice report.Invoice
+	if generateInvoice {
+		invoice, err = newInvoice()
+		if err != nil {
+			return err
+		}
+	}
+
+	if checkScheduleExists {
+		err = pd.checkSchedulesExist(input)
+		if err != nil {
+			return err
+		}
+	}
+
+	if validateEmailFormat || errorOnInvalidEmail {
+		err = pd.validateUserEmails(errorOnInvalidEmail)
+		if err != nil {
+			return err
+		}
+	}
+
+	firstStartDate, lastEndDate := reportTimeRange(input)
+	err = configuration.LoadCalendars(firstStartDate.Year(), lastEndDate.Year())
+	if err != nil {
+		return err
+	}
+	sourceBankHolidays, err = loadHolidaySources(holidaySources, firstStartDate.Year(), lastEndDate.Year())
+	if err != nil {
+		return err
+	}
 	printableData := &report.PrintableData{
+		AccountName:   Config.AccountName,
+		PeriodLabel:   periodLabel,
 		Start:         firstStartDate,
 		End:           lastEndDate,
 		SchedulesData: make([]*report.ScheduleData, 0),
 	}
 
+	if accountNameOverride != "" {
+		printableData.AccountName = accountNameOverride
+	}
+
 	pricesInfo, err := Config.GetPricesInfo()
 	if err != nil {
 		return err
@@ -213,13 +395,51 @@ func (pd *pagerDutyClient) generateReport() error {
 		Config.RotationPrices.Currency, pricesInfo.WeekDayHourlyPrice, pricesInfo.HoursWeekDay, pricesInfo.WeekendDayHourlyPrice,
 		pricesInfo.HoursWeekendDay, pricesInfo.BhDayHourlyPrice, pricesInfo.HoursBhDay))
 
-	for _, schedule := range input {
+	progress := newReportProgress(input)
+	if resume {
+		progress, err = loadReportProgress(resumeFile, input)
+		if err != nil {
+			return err
+		}
+	}
+	scheduleServices, serviceAmounts := progress.ScheduleServices, progress.ServiceAmounts
+
+	// the progress is only saved for runs that can be resumed, without --resume an interrupt stops the run as usual
+	var interrupted <-chan struct{}
+	if resume {
+		var stopNotifyInterrupt func()
+		interrupted, stopNotifyInterrupt = notifyInterrupt()
+		defer stopNotifyInterrupt()
+	}
+	for ; progress.NextSchedule < len(input); progress.NextSchedule++ {
+		select {
+		case <-interrupted:
+			return progress.interrupt(resumeFile)
+		default:
+		}
+
+		schedule := input[progress.NextSchedule]
 		log.Printf("Loading information for the schedule '%s'", schedule.id)
 		scheduleInfo, err := pd.getScheduleInformation(schedule.id, schedule.startDate, schedule.endDate)
 		if err != nil {
 			return err
 		}
 
+		err = excludeLayers(scheduleInfo, scheduleExcludedLayers(scheduleInfo.ID))
+		if err != nil {
+			return err
+		}
+
+		err = pd.applyManualEntries(scheduleInfo)
+		if err != nil {
+			return err
+		}
+
+		if skipEmptySchedules && isScheduleEmpty(scheduleInfo) {
+			logSkippedSchedule(scheduleInfo, warnEmptySchedules)
+			continue
+		}
+
 		usersRotationData, err := getUsersRotationData(scheduleInfo)
 		if err != nil {
 			return err
@@ -230,32 +450,316 @@ func (pd *pagerDutyClient) generateReport() error {
 			return err
 		}
 
-		printableData.SchedulesData = append(printableData.SchedulesData, scheduleData)
+		rate := scheduleRatePerService(scheduleInfo.ID)
+		if includeServiceImpact || rate > 0 || includeAlertCount {
+			scheduleServices[scheduleInfo.ID], err = pd.scheduleServiceIDs(scheduleInfo)
+			if err != nil {
+				return err
+			}
+		}
+		if rate > 0 {
+			primaryHours, err := primaryLayerHours(scheduleInfo)
+			if err != nil {
+				return err
+			}
+			err = applyServiceRate(scheduleData, primaryHours, len(scheduleServices[scheduleInfo.ID]), rate, serviceAmounts)
+			if err != nil {
+				return err
+			}
+		}
+
+		if includeAlertCount {
+			err = pd.setAlertCounts(scheduleData, scheduleInfo, usersRotationData, scheduleServices[scheduleInfo.ID])
+			if err != nil {
+				return err
+			}
+		}
+
+		if maxConsecutiveHoursWarn > 0 || maxConsecutiveHoursError > 0 {
+			err = checkConsecutiveHours(scheduleData, usersRotationData)
+			if err != nil {
+				return err
+			}
+		}
+
+		if includeCoverageOverlap {
+			note, err := coverageOverlapNote(scheduleInfo)
+			if err != nil {
+				return err
+			}
+			scheduleData.Notes = append(scheduleData.Notes, note)
+		}
+
+		if includeLayerOverlapMinutes {
+			note, err := handoverOverlapNote(scheduleInfo)
+			if err != nil {
+				return err
+			}
+			scheduleData.Notes = append(scheduleData.Notes, note)
+		}
+
+		if inferRotationLength {
+			note, err := rotationLengthNote(scheduleInfo)
+			if err != nil {
+				return err
+			}
+			scheduleData.Notes = append(scheduleData.Notes, note)
+		}
+
+		if includeLayerRotationSpeed {
+			note, err := layerRotationSpeedNote(scheduleInfo)
+			if err != nil {
+				return err
+			}
+			scheduleData.Notes = append(scheduleData.Notes, note)
+		}
+
+		if includeOverrideCount {
+			err = setOverrideCounts(scheduleData, scheduleInfo)
+			if err != nil {
+				return err
+			}
+		}
+
+		if includeScheduleURL {
+			scheduleData.URL = scheduleURL(scheduleInfo.ID)
+		}
+
+		if includeHourlyBreakdown {
+			scheduleData.HourlyBreakdown, err = hourlyBreakdown(scheduleInfo)
+			if err != nil {
+				return err
+			}
+		}
+
+		if includeSwapLog {
+			swapNotes, err := swapLogNotes(scheduleInfo)
+			if err != nil {
+				return err
+			}
+			scheduleData.Notes = append(scheduleData.Notes, swapNotes...)
+		}
+
+		err = sortScheduleUsers(scheduleData.RotaUsers, scheduleUserSortKey(schedule.id))
+		if err != nil {
+			return fmt.Errorf("failed to sort users of schedule %s: %w", schedule.id, err)
+		}
+
+		progress.SchedulesData = append(progress.SchedulesData, scheduleData)
+	}
+	printableData.SchedulesData = progress.SchedulesData
+	if hrSystemURL != "" {
+		activeEmployees, err := listActiveEmployees(hrSystemURL, hrToken)
+		if err != nil {
+			return err
+		}
+		setHRStatuses(printableData.SchedulesData, activeEmployees, holdInactiveUserPay, serviceAmounts)
+	}
+	err = sortSchedules(printableData.SchedulesData, scheduleOrder)
+	if err != nil {
+		return err
+	}
+	if groupSchedulesByTimeZone {
+		groupByTimeZone(printableData.SchedulesData)
 	}
 
-	summaryPrintableData := calculateSummaryData(printableData.SchedulesData, pricesInfo)
+	roundSummaryAmount, err := currencyRounder(reportRoundingMode())
+	if err != nil {
+		return err
+	}
+	summaryPrintableData := calculateSummaryData(printableData.SchedulesData, pricesInfo, roundSummaryAmount)
 	printableData.UsersSchedulesSummary = summaryPrintableData
 
-	var reportWriter report.Writer
-	if outputFormat == "pdf" {
-		reportWriter = report.NewPDFReport(Config.RotationPrices.Currency, directory)
-	} else if outputFormat == "csv" {
-		reportWriter = report.NewCsvReport(Config.RotationPrices.Currency, directory)
-	} else {
-		reportWriter = report.NewConsoleReport(Config.RotationPrices.Currency)
+	if includeServiceImpact {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, affectedServicesColumn)
+		setServiceImpact(printableData, scheduleServices)
+	}
+	if len(serviceAmounts) > 0 {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, serviceAmountColumn)
+		setServiceAmounts(printableData, serviceAmounts, roundSummaryAmount)
+	}
+
+	if len(scheduleWeights) > 0 {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, weightedHoursColumn)
+		setWeightedHours(printableData)
+	}
+
+	if includeUserURL {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, userURLColumn)
+		setSummaryUserURLs(printableData)
+	}
+
+	if includeShiftCount {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, shiftCountColumn)
+		err = setSummaryShiftCounts(printableData)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !projectedAfter.IsZero() {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, projectedColumn)
+		setSummaryProjected(printableData)
+	}
+
+	if includeTimezoneOffset {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, utcOffsetColumn)
+		setSummaryUTCOffsets(printableData)
+	}
+
+	if includeOnCallFrequency {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, onCallFrequencyColumn, rotationRateColumn)
+		err = setSummaryOnCallFrequencies(printableData)
+		if err != nil {
+			return err
+		}
+	}
+
+	if hrSystemURL != "" {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, hrStatusColumn)
+		setSummaryHRStatuses(printableData)
+	}
+
+	if includeConsecutiveDays {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, maxConsecutiveDaysColumn)
+		err = setSummaryConsecutiveDays(printableData)
+		if err != nil {
+			return err
+		}
+	}
+
+	if includeOverrideCount {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, overrideCountColumn, overrideHoursColumn)
+		err = setSummaryOverrideCounts(printableData)
+		if err != nil {
+			return err
+		}
+	}
+
+	if includeAlertCount {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, alertsReceivedColumn)
+		err = setSummaryAlertCounts(printableData)
+		if err != nil {
+			return err
+		}
+	}
+
+	if normalizeAmountsToHours {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, normalizedHoursColumn)
+		err = setSummaryNormalizedHours(printableData)
+		if err != nil {
+			return err
+		}
+	}
+
+	if normalizeToWorkweek {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, workweekFractionColumn)
+		setWorkweekFractions(printableData)
 	}
-	message, err := reportWriter.GenerateReport(printableData)
+
+	if includeScheduleTier {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, setScheduleTiers(printableData)...)
+	}
+
+	if includePayFrequencyColumn {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, payFrequencyColumn)
+		setPayFrequencies(printableData)
+	}
+
+	if includeCurrencyCodeColumn {
+		code, err := currencyCode()
+		if err != nil {
+			return err
+		}
+		printableData.ExtraColumns = append(printableData.ExtraColumns, currencyCodeColumn)
+		setCurrencyCodes(printableData, code)
+	}
+
+	if payTagsEnabled() {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, payTagColumn)
+		setPayTags(printableData)
+	}
+
+	if includeManager {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, managerEmailColumn, managerNameColumn)
+		err = pd.setManagers(printableData)
+		if err != nil {
+			return err
+		}
+	}
+
+	if includeTeamHierarchy {
+		printableData.ExtraColumns = append(printableData.ExtraColumns, teamPathColumn)
+		err = pd.setTeamPaths(printableData)
+		if err != nil {
+			return err
+		}
+	}
+
+	if groupUsersByTeam {
+		err = pd.setTeamGroups(printableData)
+		if err != nil {
+			return err
+		}
+	}
+
+	if verifyReportTotals {
+		logTotalDiscrepancies(printableData, serviceAmounts)
+	}
+
+	if warnOnNegativeAmount {
+		err = checkNegativeAmounts(printableData)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = checkAmountThresholds(printableData)
+	if err != nil {
+		return err
+	}
+
+	if paymentApprovalRequired {
+		approved, err := approvePayment(os.Stdin, os.Stdout, printableData)
+		if err != nil {
+			return err
+		}
+		if !approved {
+			return &exitCodeError{code: paymentRejectedExitCode, err: errPaymentRejected}
+		}
+	}
+
+	if anonymizeScheduleID {
+		anonymizeScheduleIDs(printableData, os.Stderr)
+	}
+
+	_, err = writeReports(printableData, outputFormats)
 	if err != nil {
 		return err
 	}
 
-	if len(message) > 0 {
-		log.Println(message)
+	if perScheduleReport {
+		err = writePerScheduleReports(printableData, outputFormats)
+		if err != nil {
+			return err
+		}
+	}
+
+	if generateInvoice {
+		err = writeInvoice(invoice, printableData)
+		if err != nil {
+			return err
+		}
+	}
+
+	if resume {
+		_ = os.Remove(resumeFile)
 	}
 	return nil
 }
 
-func calculateSummaryData(data []*report.ScheduleData, pricesInfo *configuration.PricesInfo) []*report.ScheduleUser {
+func calculateSummaryData(data []*report.ScheduleData, pricesInfo *configuration.PricesInfo,
+	roundAmount func(float32) float32) []*report.ScheduleUser {
 	usersSummary := make(map[string]*report.ScheduleUser)
 
 	for _, schedData := range data {
@@ -287,10 +791,10 @@ func calculateSummaryData(data []*report.ScheduleData, pricesInfo *configuration
 
 		// Round summary totals to handle any accumulated floating-point precision errors
 		// when summing already-rounded amounts from multiple schedules
-		userSummary.TotalAmountWorkHours = roundCurrency(userSummary.TotalAmountWorkHours)
-		userSummary.TotalAmountWeekendHours = roundCurrency(userSummary.TotalAmountWeekendHours)
-		userSummary.TotalAmountBankHolidaysHours = roundCurrency(userSummary.TotalAmountBankHolidaysHours)
-		userSummary.TotalAmount = roundCurrency(userSummary.TotalAmount)
+		userSummary.TotalAmountWorkHours = roundAmount(userSummary.TotalAmountWorkHours)
+		userSummary.TotalAmountWeekendHours = roundAmount(userSummary.TotalAmountWeekendHours)
+		userSummary.TotalAmountBankHolidaysHours = roundAmount(userSummary.TotalAmountBankHolidaysHours)
+		userSummary.TotalAmount = roundAmount(userSummary.TotalAmount)
 
 		result = append(result, userSummary)
 	}
@@ -307,14 +811,26 @@ func (pd *pagerDutyClient) getScheduleInformation(scheduleID string, startDate,
 	}
 
 	location, _ := time.LoadLocation(schedule.TimeZone)
+	if timezone, ok := scheduleTimezoneOverrides[scheduleID]; ok {
+		log.Printf("WARNING: overriding time zone of schedule '%s' from '%s' to '%s'", scheduleID, schedule.TimeZone, timezone)
+		location, err = time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load time zone override for schedule %s: %w", scheduleID, err)
+		}
+	}
 
 	scheduleInfo := &api.ScheduleInfo{
 		ID:            scheduleID,
 		Name:          schedule.Name,
+		TimeZone:      schedule.TimeZone,
 		Location:      location,
 		Start:         startDate,
 		End:           endDate,
 		FinalSchedule: schedule.FinalSchedule,
+
+		EscalationPolicyIDs: schedule.EscalationPolicyIDs,
+		ScheduleLayers:      schedule.ScheduleLayers,
+		OverrideSubschedule: schedule.OverrideSubschedule,
 	}
 	return scheduleInfo, nil
 }
@@ -356,13 +872,44 @@ func (pd *pagerDutyClient) generateScheduleData(scheduleInfo *api.ScheduleInfo,
 
 	scheduleData := &report.ScheduleData{
 		ID:        scheduleInfo.ID,
-		Name:      scheduleInfo.Name,
+		Name:      scheduleLabel(scheduleInfo.ID, scheduleInfo.Name),
 		StartDate: schedule.startDate,
 		EndDate:   schedule.endDate,
+		TimeZone:  scheduleInfo.TimeZone,
 		RotaUsers: make([]*report.ScheduleUser, 0),
+		Notes:     make([]string, 0),
+	}
+	if scheduleInfo.Location != nil {
+		scheduleData.TimeZone = scheduleInfo.Location.String()
+	}
+
+	roundAmount, err := currencyRounder(scheduleRoundingMode(scheduleInfo.ID))
+	if err != nil {
+		return nil, fmt.Errorf("invalid rounding mode for schedule %s: %w", scheduleInfo.ID, err)
+	}
+
+	weekendDays, err := scheduleWeekendDays(scheduleInfo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid weekend definition for schedule %s: %w", scheduleInfo.ID, err)
 	}
 
-	for userID, userRotaInfo := range usersRotationData {
+	if timezone, ok := scheduleTimezoneOverrides[scheduleInfo.ID]; ok {
+		scheduleData.Notes = append(scheduleData.Notes,
+			fmt.Sprintf("Time zone overridden: %s (PagerDuty: %s)", timezone, scheduleInfo.TimeZone))
+	}
+
+	if nonBusinessHoursOnly {
+		scheduleData.Notes = append(scheduleData.Notes, businessHoursNote())
+	}
+
+	projected := !projectedAfter.IsZero() && scheduleInfo.End.After(projectedAfter)
+	if projected {
+		scheduleData.Notes = append(scheduleData.Notes, projectedNote(projectedAfter))
+	}
+
+	var roundedTotal, fullPrecisionTotal float32
+	for _, userID := range scheduleUserIDs(scheduleInfo) {
+		userRotaInfo := usersRotationData[userID]
 		rotationUserConfig, err := Config.FindRotationUserInfoByID(userID)
 		if err != nil {
 			log.Println("Error:", err)
@@ -370,10 +917,15 @@ func (pd *pagerDutyClient) generateScheduleData(scheduleInfo *api.ScheduleInfo,
 		}
 
 		calendarName := fmt.Sprintf("%s-%d", rotationUserConfig.HolidaysCalendar, scheduleInfo.Start.Year())
-		userCalendar, present := configuration.BankHolidaysCalendars[calendarName]
+		userCalendar, present := configuration.BankHolidaysCalendars.Find(rotationUserConfig.HolidaysCalendar,
+			scheduleInfo.Start.Year(), lastOnCallYear(scheduleInfo.End))
 		if !present {
 			return nil, fmt.Errorf("aborted due to calendar '%s' not found for user '%s'", calendarName, userID)
 		}
+		userCalendar.WeekendDays = weekendDays
+		if len(sourceBankHolidays) > 0 {
+			userCalendar = userCalendar.WithBankHolidays(sourceBankHolidays)
+		}
 
 		userEmailAddress, err := pd.getUserEmail(userRotaInfo.ID)
 		if err != nil {
@@ -394,9 +946,18 @@ func (pd *pagerDutyClient) generateScheduleData(scheduleInfo *api.ScheduleInfo,
 				return nil, fmt.Errorf("aborted due to failed to convert to user local timezone: %w", err)
 			}
 
+			checkEvery := time.Minute * time.Duration(Config.RotationInfo.CheckRotationChangeEvery)
 			for currentLocalDate.Before(period.End) {
-				updateDataForDate(&userCalendar, scheduleUserData, currentMonth, currentLocalDate)
-				currentLocalDate = currentLocalDate.Add(time.Minute * time.Duration(Config.RotationInfo.CheckRotationChangeEvery))
+				nextLocalDate := currentLocalDate.Add(checkEvery)
+				if nextLocalDate.After(period.End) {
+					nextLocalDate = period.End.In(currentLocalDate.Location())
+				}
+				if nonBusinessHoursOnly && isBusinessHour(&userCalendar, currentLocalDate, scheduleInfo.Location) {
+					currentLocalDate = nextLocalDate
+					continue
+				}
+				updateDataForDate(&userCalendar, scheduleUserData, currentMonth, currentLocalDate, intervalHours(currentLocalDate, nextLocalDate))
+				currentLocalDate = nextLocalDate
 			}
 		}
 
@@ -404,20 +965,63 @@ func (pd *pagerDutyClient) generateScheduleData(scheduleInfo *api.ScheduleInfo,
 		scheduleUserData.NumWeekendDays = scheduleUserData.NumWeekendHours / float32(pricesInfo.HoursWeekendDay)
 		scheduleUserData.NumBankHolidaysDays = scheduleUserData.NumBankHolidaysHours / float32(pricesInfo.HoursBhDay)
 
-		// Calculate amounts with full precision, then round to 2 decimal places for clean currency values.
-		// This prevents messy recurring decimals (e.g., £4.166666 per 30-min interval) in reports.
-		scheduleUserData.TotalAmountWorkHours = roundCurrency(scheduleUserData.NumWorkHours * pricesInfo.WeekDayHourlyPrice)
-		scheduleUserData.TotalAmountWeekendHours = roundCurrency(scheduleUserData.NumWeekendHours * pricesInfo.WeekendDayHourlyPrice)
-		scheduleUserData.TotalAmountBankHolidaysHours = roundCurrency(scheduleUserData.NumBankHolidaysHours * pricesInfo.BhDayHourlyPrice)
-		scheduleUserData.TotalAmount = roundCurrency(scheduleUserData.TotalAmountWorkHours +
-			scheduleUserData.TotalAmountWeekendHours +
-			scheduleUserData.TotalAmountBankHolidaysHours)
+		userPricesInfo, err := Config.GetUserPricesInfo(scheduleInfo.ID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("aborted due to failed to get prices for user '%s': %w", userID, err)
+		}
+
+		if hourlyRateRounding >= 0 {
+			fullPrecisionUserData := *scheduleUserData
+			setUserAmounts(&fullPrecisionUserData, userPricesInfo, roundAmount)
+			fullPrecisionTotal += fullPrecisionUserData.TotalAmount
+			userPricesInfo = roundHourlyRates(userPricesInfo, hourlyRateRounding)
+		}
+		setUserAmounts(scheduleUserData, userPricesInfo, roundAmount)
+		roundedTotal += scheduleUserData.TotalAmount
+		if includeUserURL {
+			setExtraValue(scheduleUserData, userURLColumn, userURL(userID))
+		}
+		if includeShiftCount {
+			setExtraValue(scheduleUserData, shiftCountColumn, strconv.Itoa(len(mergeStints(userRotaInfo.Periods))))
+		}
+		if includeConsecutiveDays {
+			setExtraValue(scheduleUserData, maxConsecutiveDaysColumn,
+				strconv.Itoa(maxConsecutiveDays(userRotaInfo.Periods, scheduleInfo.Location)))
+		}
+		if !projectedAfter.IsZero() {
+			value := ""
+			if projected && isProjected(userRotaInfo.Periods, projectedAfter) {
+				value = projectedValue
+			}
+			setExtraValue(scheduleUserData, projectedColumn, value)
+		}
+		if includeTimezoneOffset {
+			setExtraValue(scheduleUserData, utcOffsetColumn, utcOffsets(userRotaInfo.Periods, scheduleInfo.Location))
+		}
+		if includeOnCallFrequency {
+			setOnCallFrequency(scheduleUserData, onCallWeeks(userRotaInfo.Periods, scheduleInfo.Location),
+				len(weeksBetween(scheduleInfo.Start, scheduleInfo.End, scheduleInfo.Location)))
+		}
+		if normalizeAmountsToHours {
+			setNormalizedHours(scheduleUserData, userPricesInfo.WeekDayHourlyPrice)
+		}
 		scheduleData.RotaUsers = append(scheduleData.RotaUsers, scheduleUserData)
 	}
 
+	if hourlyRateRounding >= 0 {
+		scheduleData.Notes = append(scheduleData.Notes,
+			hourlyRateRoundingNote(hourlyRateRounding, roundCurrency(roundedTotal), roundCurrency(fullPrecisionTotal)))
+	}
+
 	return scheduleData, nil
 }
 
+// lastOnCallYear returns the year of the last day paid in a period ending at end. The hours before the daily
+// rotation starts belong to the day before, so a period ending on the 1st of January at 08:00 ends the year before.
+func lastOnCallYear(end time.Time) int {
+	return end.Add(-time.Duration(Config.RotationInfo.DailyRotationStartsAt)*time.Hour - time.Nanosecond).Year()
+}
+
 func (pd *pagerDutyClient) convertToUserLocalTimezone(scheduleDate time.Time, userID string) (time.Time, error) {
 	timezone, err := pd.getUserTimezone(userID)
 	if err != nil {
@@ -489,49 +1093,55 @@ func (pd *pagerDutyClient) getUserEmail(userID string) (string, error) {
 	return email, nil
 }
 
-func updateDataForDate(calendar *configuration.BHCalendar, data *report.ScheduleUser, currentMonth time.Month, date time.Time) {
+// intervalHours returns the length of the interval in hours, with second precision so short
+// stints at the end of a period are counted exactly.
+func intervalHours(start, end time.Time) float32 {
+	return float32(end.Sub(start).Seconds() / 3600.0)
+}
+
+func updateDataForDate(calendar *configuration.BHCalendar, data *report.ScheduleUser, currentMonth time.Month, date time.Time, hours float32) {
 	if date.Hour() < Config.RotationInfo.DailyRotationStartsAt {
 		newDate := date.Add(time.Hour * time.Duration(-(date.Hour() + 1))) // move to yesterday night to determine which kind of day it was
 		// if yesterday night was last month, ignore the date
 		if newDate.Month() == currentMonth {
-			updateDataForDate(calendar, data, currentMonth, newDate)
+			updateDataForDate(calendar, data, currentMonth, newDate, hours)
 		}
 	} else {
 		if calendar.IsDateBankHoliday(date) {
 			excludedHours := Config.FindRotationExcludedHoursByDay("bankholiday")
 			if excludedHours == nil {
 				//fmt.Printf("%s - Month: %d, time: %v -- bank holiday\n", data.Name, currentMonth, date)
-				data.NumBankHolidaysHours += 0.5
+				data.NumBankHolidaysHours += hours
 				return
 			}
 
 			if date.Hour() < excludedHours.ExcludedStartsAt || date.Hour() >= excludedHours.ExcludedEndsAt {
 				//fmt.Printf("%s - Month: %d, time: %v -- bank holiday non excluded hours\n", data.Name, currentMonth, date)
-				data.NumBankHolidaysHours += 0.5
+				data.NumBankHolidaysHours += hours
 			}
 		} else if calendar.IsWeekend(date) {
 			excludedHours := Config.FindRotationExcludedHoursByDay("weekend")
 			if excludedHours == nil {
 				//fmt.Printf("%s - Month: %d, time: %v -- weekend\n", data.Name, currentMonth, date)
-				data.NumWeekendHours += 0.5
+				data.NumWeekendHours += hours
 				return
 			}
 
 			if date.Hour() < excludedHours.ExcludedStartsAt || date.Hour() >= excludedHours.ExcludedEndsAt {
 				//fmt.Printf("%s - Month: %d, time: %v -- weekend non excluded hours\n", data.Name, currentMonth, date)
-				data.NumWeekendHours += 0.5
+				data.NumWeekendHours += hours
 			}
 		} else {
 			excludedHours := Config.FindRotationExcludedHoursByDay("weekday")
 			if excludedHours == nil {
 				//fmt.Printf("%s - Month: %d, time: %v -- weekday\n", data.Name, currentMonth, date)
-				data.NumWorkHours += 0.5
+				data.NumWorkHours += hours
 				return
 			}
 
 			if date.Hour() < excludedHours.ExcludedStartsAt || date.Hour() >= excludedHours.ExcludedEndsAt {
 				//fmt.Printf("%s - Month: %d, time: %v -- weekday non excluded hours\n", data.Name, currentMonth, date)
-				data.NumWorkHours += 0.5
+				data.NumWorkHours += hours
 			}
 		}
 	}
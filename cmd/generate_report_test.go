@@ -6,11 +6,20 @@ import (
 	"time"
 
 	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// setConfig replaces the global configuration for the test, restoring the previous one when the test ends.
+func setConfig(t *testing.T, config *configuration.Configuration) {
+	t.Helper()
+	previous := Config
+	t.Cleanup(func() { Config = previous })
+	Config = config
+}
+
 func Test_pagerDutyClient_loadUsersInMemoryCache(t *testing.T) {
 	users := []*api.User{
 		{
@@ -302,3 +311,134 @@ func Test_pagerDutyClient_convertToUserLocalTimezone(t *testing.T) {
 		})
 	}
 }
+
+func Test_pagerDutyClient_getScheduleInformation(t *testing.T) {
+	tests := []struct {
+		name              string
+		timezoneOverrides map[string]string
+		mockSetup         func(mock *clientMock)
+		wantLocation      string
+		wantErr           bool
+	}{
+		{
+			name: "Uses the schedule time zone configured in PagerDuty",
+			mockSetup: func(mock *clientMock) {
+				mock.On("GetSchedule", "SCHED_1", "2022-08-01T00:00:00", "2022-09-01T00:00:00").Once().
+					Return(&api.Schedule{ID: "SCHED_1", TimeZone: "Europe/London"}, nil)
+			},
+			wantLocation: "Europe/London",
+		},
+		{
+			name:              "Uses the time zone override of the schedule",
+			timezoneOverrides: map[string]string{"SCHED_1": "America/Chicago"},
+			mockSetup: func(mock *clientMock) {
+				mock.On("GetSchedule", "SCHED_1", "2022-08-01T00:00:00", "2022-09-01T00:00:00").Once().
+					Return(&api.Schedule{ID: "SCHED_1", TimeZone: "Europe/London"}, nil)
+			},
+			wantLocation: "America/Chicago",
+		},
+		{
+			name:              "Ignores time zone overrides of other schedules",
+			timezoneOverrides: map[string]string{"SCHED_2": "America/Chicago"},
+			mockSetup: func(mock *clientMock) {
+				mock.On("GetSchedule", "SCHED_1", "2022-08-01T00:00:00", "2022-09-01T00:00:00").Once().
+					Return(&api.Schedule{ID: "SCHED_1", TimeZone: "Europe/London"}, nil)
+			},
+			wantLocation: "Europe/London",
+		},
+		{
+			name:              "Fails with an invalid time zone override",
+			timezoneOverrides: map[string]string{"SCHED_1": "Mars/Kaiser_Sea"},
+			mockSetup: func(mock *clientMock) {
+				mock.On("GetSchedule", "SCHED_1", "2022-08-01T00:00:00", "2022-09-01T00:00:00").Once().
+					Return(&api.Schedule{ID: "SCHED_1", TimeZone: "Europe/London"}, nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedClient := &clientMock{}
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockedClient)
+			}
+
+			scheduleTimezoneOverrides = tt.timezoneOverrides
+			defer func() { scheduleTimezoneOverrides = nil }()
+
+			pd := pagerDutyClient{client: mockedClient}
+			got, err := pd.getScheduleInformation("SCHED_1",
+				time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC))
+			mockedClient.AssertExpectations(t)
+
+			if tt.wantErr == true {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantLocation, got.Location.String())
+			assert.Equal(t, "Europe/London", got.TimeZone)
+		})
+	}
+}
+
+func Test_isScheduleEmpty(t *testing.T) {
+	tests := []struct {
+		name         string
+		scheduleInfo *api.ScheduleInfo
+		want         bool
+	}{
+		{
+			name:         "Schedule without entries",
+			scheduleInfo: &api.ScheduleInfo{ID: "SCHED_1"},
+			want:         true,
+		},
+		{
+			name: "Schedule with entries",
+			scheduleInfo: &api.ScheduleInfo{
+				ID: "SCHED_1",
+				FinalSchedule: api.ScheduleLayer{
+					RenderedScheduleEntries: []api.RenderedScheduleEntry{
+						{Start: "2021-01-01T00:00:00Z", End: "2021-01-02T00:00:00Z", User: api.User{ID: "1"}},
+					},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isScheduleEmpty(tt.scheduleInfo))
+		})
+	}
+}
+
+func Test_intervalHours(t *testing.T) {
+	start := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		end  time.Time
+		want float32
+	}{
+		{name: "Half hour", end: start.Add(30 * time.Minute), want: 0.5},
+		{name: "Short stint", end: start.Add(45 * time.Second), want: 0.0125},
+		{name: "Minutes and seconds", end: start.Add(7*time.Minute + 30*time.Second), want: 0.125},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, intervalHours(start, tt.end))
+		})
+	}
+}
+
+func Test_lastOnCallYear(t *testing.T) {
+	setConfig(t, configuration.New())
+	Config.RotationInfo.DailyRotationStartsAt = 8
+
+	assert.Equal(t, 2024, lastOnCallYear(time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 2025, lastOnCallYear(time.Date(2025, 1, 1, 8, 30, 0, 0, time.UTC)))
+	assert.Equal(t, 2025, lastOnCallYear(time.Date(2025, 4, 1, 8, 0, 0, 0, time.UTC)))
+}
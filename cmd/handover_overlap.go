@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+)
+
+var includeLayerOverlapMinutes bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includeLayerOverlapMinutes, "include-layer-overlap-minutes", false, "annotate the schedules with the minutes both users are on call when a shift in one layer hands over to a shift in another")
+}
+
+// handoverOverlapMinutes returns the minutes two users of different layers are on call at the same time
+// around a handover, i.e. when a shift starts before the shift of another layer ends and finishes after it.
+// Shifts covering another one entirely are deliberate layering, reported by --include-coverage-overlap.
+func handoverOverlapMinutes(scheduleInfo *api.ScheduleInfo) (int, error) {
+	shifts, err := layerShifts(scheduleInfo)
+	if err != nil {
+		return 0, err
+	}
+
+	var minutes float64
+	for _, outgoing := range shifts {
+		for _, incoming := range shifts {
+			if outgoing.layer == incoming.layer || outgoing.userID == incoming.userID {
+				continue
+			}
+			if outgoing.start.Before(incoming.start) && incoming.start.Before(outgoing.end) && outgoing.end.Before(incoming.end) {
+				minutes += outgoing.end.Sub(incoming.start).Minutes()
+			}
+		}
+	}
+	return int(minutes), nil
+}
+
+// handoverOverlapNote returns a note with the handover overlap minutes of the schedule.
+func handoverOverlapNote(scheduleInfo *api.ScheduleInfo) (string, error) {
+	minutes, err := handoverOverlapMinutes(scheduleInfo)
+	if err != nil {
+		return "", fmt.Errorf("failed to calculate the handover overlap of schedule %s: %w", scheduleInfo.ID, err)
+	}
+	return fmt.Sprintf("Handover overlap: %d minutes", minutes), nil
+}
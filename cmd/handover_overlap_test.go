@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_handoverOverlapNote(t *testing.T) {
+	john := api.User{ID: "USER_1", Summary: "John Doe"}
+	mary := api.User{ID: "USER_2", Summary: "Mary Jane"}
+
+	tests := []struct {
+		name   string
+		layers []api.ScheduleLayer
+		want   string
+	}{
+		{
+			name: "clean handover",
+			layers: []api.ScheduleLayer{
+				{RenderedScheduleEntries: []api.RenderedScheduleEntry{
+					{Start: "2022-08-01T09:00:00Z", End: "2022-08-01T17:00:00Z", User: john},
+				}},
+				{RenderedScheduleEntries: []api.RenderedScheduleEntry{
+					{Start: "2022-08-01T17:00:00Z", End: "2022-08-02T09:00:00Z", User: mary},
+				}},
+			},
+			want: "Handover overlap: 0 minutes",
+		},
+		{
+			name: "overlapping handovers",
+			layers: []api.ScheduleLayer{
+				{RenderedScheduleEntries: []api.RenderedScheduleEntry{
+					{Start: "2022-08-01T09:00:00Z", End: "2022-08-01T17:10:00Z", User: john},
+					{Start: "2022-08-02T08:55:00Z", End: "2022-08-02T17:00:00Z", User: john},
+				}},
+				{RenderedScheduleEntries: []api.RenderedScheduleEntry{
+					{Start: "2022-08-01T17:00:00Z", End: "2022-08-02T09:00:00Z", User: mary},
+				}},
+			},
+			want: "Handover overlap: 15 minutes",
+		},
+		{
+			name: "shift covered by another layer",
+			layers: []api.ScheduleLayer{
+				{RenderedScheduleEntries: []api.RenderedScheduleEntry{
+					{Start: "2022-08-01T00:00:00Z", End: "2022-08-02T00:00:00Z", User: john},
+				}},
+				{RenderedScheduleEntries: []api.RenderedScheduleEntry{
+					{Start: "2022-08-01T09:00:00Z", End: "2022-08-01T17:00:00Z", User: mary},
+				}},
+			},
+			want: "Handover overlap: 0 minutes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			note, err := handoverOverlapNote(&api.ScheduleInfo{ID: "SCHED_1", ScheduleLayers: tt.layers})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, note)
+		})
+	}
+}
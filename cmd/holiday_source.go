@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+)
+
+const builtinHolidaySourcePrefix = "builtin:"
+
+// builtinCalendarAliases are the built-in calendars of the ISO 3166 country codes not named after them.
+var builtinCalendarAliases = map[string]string{
+	"gb": "uk",
+	"es": "sp",
+}
+
+var (
+	holidaySources []string
+	// sourceBankHolidays are the bank holidays of --holiday-source, added to the calendar of every user.
+	sourceBankHolidays []configuration.BankHoliday
+)
+
+func init() {
+	scheduleReportCmd.Flags().StringSliceVar(&holidaySources, "holiday-source", []string{}, "additional bank holidays for every user, from an iCalendar URL, a local .ics file or a built-in calendar, e.g. builtin:GB (repeat or comma-separate to combine sources)")
+}
+
+// loadHolidaySources returns the bank holidays of all the sources. The built-in calendars must be loaded
+// for the years of the report.
+func loadHolidaySources(sources []string, fromYear, toYear int) ([]configuration.BankHoliday, error) {
+	bankHolidays := make([]configuration.BankHoliday, 0)
+	for _, source := range sources {
+		holidays, err := loadHolidaySource(source, fromYear, toYear)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the holidays of %s: %w", source, err)
+		}
+		bankHolidays = append(bankHolidays, holidays...)
+	}
+	return bankHolidays, nil
+}
+
+func loadHolidaySource(source string, fromYear, toYear int) ([]configuration.BankHoliday, error) {
+	switch {
+	case strings.HasPrefix(source, builtinHolidaySourcePrefix):
+		name := strings.ToLower(strings.TrimPrefix(source, builtinHolidaySourcePrefix))
+		if alias, ok := builtinCalendarAliases[name]; ok {
+			name = alias
+		}
+		calendar, present := configuration.BankHolidaysCalendars.Find(name, fromYear, toYear)
+		if !present {
+			return nil, fmt.Errorf("no built-in calendar %s for %d", name, fromYear)
+		}
+		holidays := make([]configuration.BankHoliday, 0, len(calendar.DaysMaps))
+		for _, holiday := range calendar.DaysMaps {
+			holidays = append(holidays, holiday)
+		}
+		return holidays, nil
+	case strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://"):
+		return fetchICal(source)
+	case strings.HasSuffix(strings.ToLower(source), ".ics"):
+		file, err := os.Open(source)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return configuration.ParseICal(file)
+	default:
+		return nil, fmt.Errorf("unknown holiday source, use an iCalendar URL, a .ics file or %s<calendar>", builtinHolidaySourcePrefix)
+	}
+}
+
+func fetchICal(url string) ([]configuration.BankHoliday, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/calendar")
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+	return configuration.ParseICal(resp.Body)
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testICal = "BEGIN:VCALENDAR\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20240101\r\n" +
+	"SUMMARY:New Year's Day\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20241225\r\n" +
+	"DTEND;VALUE=DATE:20241227\r\n" +
+	"SUMMARY:Christmas\\, and Boxing Day\r\n" +
+	"  (folded)\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func holidayKeys(holidays []configuration.BankHoliday) map[string]string {
+	keys := make(map[string]string, len(holidays))
+	for _, holiday := range holidays {
+		keys[holiday.Date.ToHashKey()] = holiday.Name
+	}
+	return keys
+}
+
+func Test_loadHolidaySources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testICal))
+	}))
+	defer server.Close()
+
+	icsFile := filepath.Join(t.TempDir(), "holidays.ics")
+	require.NoError(t, os.WriteFile(icsFile, []byte(testICal), 0o600))
+	want := map[string]string{
+		"01/01/2024": "New Year's Day",
+		"25/12/2024": "Christmas, and Boxing Day (folded)",
+		"26/12/2024": "Christmas, and Boxing Day (folded)",
+	}
+
+	for _, source := range []string{server.URL, icsFile} {
+		holidays, err := loadHolidaySources([]string{source}, 2024, 2024)
+		require.NoError(t, err)
+		assert.Equal(t, want, holidayKeys(holidays))
+	}
+
+	_, err := loadHolidaySources([]string{"holidays.txt"}, 2024, 2024)
+	require.Error(t, err)
+}
+
+func Test_loadHolidaySources_builtin(t *testing.T) {
+	defer func(calendars configuration.BHCalendars) { configuration.BankHolidaysCalendars = calendars }(configuration.BankHolidaysCalendars)
+	require.NoError(t, configuration.LoadCalendars(2024, 2024))
+
+	holidays, err := loadHolidaySources([]string{"builtin:GB"}, 2024, 2024)
+	require.NoError(t, err)
+	assert.Equal(t, "Good Friday", holidayKeys(holidays)["29/03/2024"])
+
+	_, err = loadHolidaySources([]string{"builtin:XX"}, 2024, 2024)
+	require.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+var includeHourlyBreakdown bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includeHourlyBreakdown, "hourly-breakdown", false, "add a section listing who was on call at each calendar hour of the period, for audits")
+}
+
+// hourlyBreakdown returns the users on call during each calendar hour of the schedule period,
+// in the schedule time zone. Several users are listed when the on-call changed within the hour.
+func hourlyBreakdown(scheduleInfo *api.ScheduleInfo) ([]report.HourOnCall, error) {
+	entries, err := parseRenderedScheduleEntries(scheduleInfo.FinalSchedule.RenderedScheduleEntries, scheduleInfo.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	start := scheduleInfo.Start.In(scheduleInfo.Location)
+	firstHour := time.Date(start.Year(), start.Month(), start.Day(), start.Hour(), 0, 0, 0, scheduleInfo.Location)
+
+	breakdown := make([]report.HourOnCall, 0)
+	for hour := firstHour; hour.Before(scheduleInfo.End); hour = hour.Add(time.Hour) {
+		hourOnCall := report.HourOnCall{Start: hour, Users: make([]string, 0, 1)}
+		for _, entry := range entries {
+			if entry.start.Before(hour.Add(time.Hour)) && entry.end.After(hour) && !contains(hourOnCall.Users, entry.user.Summary) {
+				hourOnCall.Users = append(hourOnCall.Users, entry.user.Summary)
+			}
+		}
+		breakdown = append(breakdown, hourOnCall)
+	}
+	return breakdown, nil
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_hourlyBreakdown(t *testing.T) {
+	scheduleInfo := &api.ScheduleInfo{
+		ID:       "SCHED_1",
+		Location: time.UTC,
+		Start:    time.Date(2022, 8, 1, 8, 0, 0, 0, time.UTC),
+		End:      time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC),
+		FinalSchedule: api.ScheduleLayer{
+			RenderedScheduleEntries: []api.RenderedScheduleEntry{
+				{Start: "2022-08-01T08:00:00Z", End: "2022-08-01T09:30:00Z", User: api.User{ID: "USER_1", Summary: "John Doe"}},
+				{Start: "2022-08-01T09:30:00Z", End: "2022-08-01T11:00:00Z", User: api.User{ID: "USER_2", Summary: "Mary Jane"}},
+			},
+		},
+	}
+
+	breakdown, err := hourlyBreakdown(scheduleInfo)
+	require.NoError(t, err)
+
+	require.Len(t, breakdown, 4)
+	assert.Equal(t, time.Date(2022, 8, 1, 8, 0, 0, 0, time.UTC), breakdown[0].Start)
+	assert.Equal(t, []string{"John Doe"}, breakdown[0].Users)
+	assert.Equal(t, []string{"John Doe", "Mary Jane"}, breakdown[1].Users)
+	assert.Equal(t, []string{"Mary Jane"}, breakdown[2].Users)
+	assert.Empty(t, breakdown[3].Users)
+}
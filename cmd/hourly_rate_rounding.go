@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+var hourlyRateRounding int
+
+func init() {
+	scheduleReportCmd.Flags().IntVar(&hourlyRateRounding, "hourly-rate-rounding", -1, "round the hourly rates to this number of decimal places before multiplying them by the hours, noting the difference it makes (-1 for full precision)")
+}
+
+// roundHourlyRates returns a copy of the prices with the hourly rates rounded half up to the given decimal places.
+func roundHourlyRates(prices *configuration.PricesInfo, decimals int) *configuration.PricesInfo {
+	scale := math.Pow10(decimals)
+	round := func(rate float32) float32 {
+		return float32(math.Round(float64(rate)*scale) / scale)
+	}
+
+	rounded := *prices
+	rounded.WeekDayHourlyPrice = round(prices.WeekDayHourlyPrice)
+	rounded.WeekendDayHourlyPrice = round(prices.WeekendDayHourlyPrice)
+	rounded.BhDayHourlyPrice = round(prices.BhDayHourlyPrice)
+	return &rounded
+}
+
+// setUserAmounts sets the amounts of the user from the hours and the hourly rates. Amounts are calculated with
+// full precision, then rounded to 2 decimal places, preventing messy recurring decimals (e.g., £4.166666 per
+// 30-min interval) in reports.
+func setUserAmounts(user *report.ScheduleUser, prices *configuration.PricesInfo, roundAmount func(float32) float32) {
+	user.TotalAmountWorkHours = roundAmount(user.NumWorkHours * prices.WeekDayHourlyPrice)
+	user.TotalAmountWeekendHours = roundAmount(user.NumWeekendHours * prices.WeekendDayHourlyPrice)
+	user.TotalAmountBankHolidaysHours = roundAmount(user.NumBankHolidaysHours * prices.BhDayHourlyPrice)
+	user.TotalAmount = roundAmount(user.TotalAmountWorkHours + user.TotalAmountWeekendHours + user.TotalAmountBankHolidaysHours)
+}
+
+func hourlyRateRoundingNote(decimals int, roundedTotal, fullPrecisionTotal float32) string {
+	return fmt.Sprintf("Hourly rates rounded to %d decimal places: total %s, %s with full precision rates (difference %s)",
+		decimals, formatCurrencyAmount(roundedTotal), formatCurrencyAmount(fullPrecisionTotal),
+		formatCurrencyAmount(roundCurrency(roundedTotal-fullPrecisionTotal)))
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_roundHourlyRates(t *testing.T) {
+	prices := &configuration.PricesInfo{
+		WeekDayHourlyPrice:    float32(200) / 24,
+		HoursWeekDay:          24,
+		WeekendDayHourlyPrice: float32(300) / 24,
+		HoursWeekendDay:       24,
+		BhDayHourlyPrice:      float32(100) / 3,
+		HoursBhDay:            24,
+	}
+
+	rounded := roundHourlyRates(prices, 2)
+
+	require.Equal(t, float32(8.33), rounded.WeekDayHourlyPrice)
+	require.Equal(t, float32(12.5), rounded.WeekendDayHourlyPrice)
+	require.Equal(t, float32(33.33), rounded.BhDayHourlyPrice)
+	require.Equal(t, 24, rounded.HoursWeekDay)
+	require.Equal(t, float32(200)/24, prices.WeekDayHourlyPrice, "the original prices must not change")
+}
+
+func Test_setUserAmounts_withRoundedRates(t *testing.T) {
+	roundAmount, err := currencyRounder(defaultRoundingMode)
+	require.NoError(t, err)
+	prices := &configuration.PricesInfo{WeekDayHourlyPrice: float32(200) / 24}
+
+	fullPrecision := &report.ScheduleUser{NumWorkHours: 240}
+	setUserAmounts(fullPrecision, prices, roundAmount)
+	rounded := &report.ScheduleUser{NumWorkHours: 240}
+	setUserAmounts(rounded, roundHourlyRates(prices, 2), roundAmount)
+
+	require.Equal(t, float32(2000), fullPrecision.TotalAmount)
+	require.Equal(t, float32(1999.2), rounded.TotalAmount)
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+)
+
+var printHourlyRates bool
+
+// otherUsersLabel identifies the rates applied to the users on call that are not configured in rotationUsers.
+const otherUsersLabel = "(other users)"
+
+var dayTypes = []string{"weekday", "weekend", "bankholiday"}
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&printHourlyRates, "print-hourly-rates", false, "print the effective rate of each user and schedule, and where it is configured, then exit without generating the report")
+}
+
+// printHourlyRates prints the rates that apply to the report schedules without fetching any on-call entries.
+func (pd *pagerDutyClient) printHourlyRates(w io.Writer) error {
+	schedules, err := pd.processArguments()
+	if err != nil {
+		return err
+	}
+	return writeHourlyRates(w, schedules)
+}
+
+func writeHourlyRates(w io.Writer, schedules []Schedule) error {
+	users := append(Config.RotationUsers[:len(Config.RotationUsers):len(Config.RotationUsers)],
+		configuration.RotationUser{Name: otherUsersLabel})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "User\tSchedule\tDay\tDaily rate (%s)\tHourly rate (%s)\tSource\n",
+		Config.RotationPrices.Currency, Config.RotationPrices.Currency)
+	for _, schedule := range schedules {
+		for _, user := range users {
+			for _, dayType := range dayTypes {
+				price, source, err := Config.FindEffectivePriceByDay(dayType, schedule.id, user.UserID)
+				if err != nil {
+					return fmt.Errorf("failed to find the %s rate of schedule %s: %w", dayType, schedule.id, err)
+				}
+				hourlyPrice := float32(price) / float32(Config.DailyWorkingHours(dayType))
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%.2f\t%s\n", user.Name, schedule.id, dayType, price, hourlyPrice, source)
+			}
+		}
+	}
+	return tw.Flush()
+}
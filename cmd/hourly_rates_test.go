@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_writeHourlyRates(t *testing.T) {
+	setConfig(t, configuration.New())
+	Config.RotationPrices = configuration.RotationPrices{
+		Currency: "GBP",
+		DaysInfo: []configuration.RotationPriceDay{
+			{Day: "weekday", Price: 24},
+			{Day: "weekend", Price: 48},
+			{Day: "bankholiday", Price: 72},
+		},
+	}
+	Config.RotationUsers = []configuration.RotationUser{
+		{UserID: "USER_1", Name: "John Doe", Prices: []configuration.RotationPriceDay{{Day: "weekend", Price: 96}}},
+	}
+	Config.ScheduleSettings = []configuration.ScheduleSettings{
+		{Id: "SCHED_1", Prices: []configuration.RotationPriceDay{{Day: "weekday", Price: 12}}},
+	}
+
+	var out bytes.Buffer
+	err := writeHourlyRates(&out, []Schedule{{id: "SCHED_1"}, {id: "SCHED_2"}})
+	require.NoError(t, err)
+
+	lines := make([]string, 0)
+	for _, line := range bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n")) {
+		lines = append(lines, string(bytes.Join(bytes.Fields(line), []byte(" "))))
+	}
+	assert.Equal(t, []string{
+		"User Schedule Day Daily rate (GBP) Hourly rate (GBP) Source",
+		"John Doe SCHED_1 weekday 12 0.50 schedule-config",
+		"John Doe SCHED_1 weekend 96 4.00 user-override",
+		"John Doe SCHED_1 bankholiday 72 3.00 global-default",
+		"(other users) SCHED_1 weekday 12 0.50 schedule-config",
+		"(other users) SCHED_1 weekend 48 2.00 global-default",
+		"(other users) SCHED_1 bankholiday 72 3.00 global-default",
+		"John Doe SCHED_2 weekday 24 1.00 global-default",
+		"John Doe SCHED_2 weekend 96 4.00 user-override",
+		"John Doe SCHED_2 bankholiday 72 3.00 global-default",
+		"(other users) SCHED_2 weekday 24 1.00 global-default",
+		"(other users) SCHED_2 weekend 48 2.00 global-default",
+		"(other users) SCHED_2 bankholiday 72 3.00 global-default",
+	}, lines)
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const (
+	hrStatusColumn = "hr_status"
+	hrStatusActive = "ACTIVE"
+	// hrStatusInactive is the status of the users that left or are unknown to the HR system.
+	hrStatusInactive = "INACTIVE_USER"
+)
+
+var (
+	hrSystemURL         string
+	hrToken             string
+	holdInactiveUserPay bool
+)
+
+func init() {
+	scheduleReportCmd.Flags().StringVar(&hrSystemURL, "cross-reference-hr-system", "", "URL of an HR API listing the employees as a JSON array of {\"email\", \"active\"}, adding an hr_status column flagging the users that aren't active employees")
+	scheduleReportCmd.Flags().StringVar(&hrToken, "hr-token", "", "bearer token to authenticate with the HR API of --cross-reference-hr-system")
+	scheduleReportCmd.Flags().BoolVar(&holdInactiveUserPay, "hold-inactive-user-pay", false, "with --cross-reference-hr-system, don't pay the users that aren't active employees, pending manual review")
+}
+
+type employee struct {
+	Email  string `json:"email"`
+	Active bool   `json:"active"`
+}
+
+// listActiveEmployees returns the lower case email addresses of the active employees in the HR system.
+func listActiveEmployees(url, token string) (map[string]bool, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the employees: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list the employees: %s", resp.Status)
+	}
+
+	var employees []employee
+	if err := json.NewDecoder(resp.Body).Decode(&employees); err != nil {
+		return nil, fmt.Errorf("failed to read the employees: %w", err)
+	}
+	active := make(map[string]bool, len(employees))
+	for _, e := range employees {
+		if e.Active {
+			active[strings.ToLower(e.Email)] = true
+		}
+	}
+	return active, nil
+}
+
+// setHRStatuses flags the users of each schedule that aren't active employees, holding their pay if requested,
+// service amounts included.
+func setHRStatuses(schedulesData []*report.ScheduleData, activeEmployees map[string]bool, hold bool, serviceAmounts scheduleServiceAmounts) {
+	for _, scheduleData := range schedulesData {
+		for _, user := range scheduleData.RotaUsers {
+			if activeEmployees[strings.ToLower(user.EmailAddress)] {
+				setExtraValue(user, hrStatusColumn, hrStatusActive)
+				continue
+			}
+
+			setExtraValue(user, hrStatusColumn, hrStatusInactive)
+			log.Printf("WARNING: user '%s' <%s> of schedule '%s' is not an active employee", user.Name, user.EmailAddress, scheduleData.ID)
+			if hold {
+				scheduleData.Notes = append(scheduleData.Notes, fmt.Sprintf("Pay of %s (%s) held pending manual review: not an active employee",
+					user.Name, formatCurrencyAmount(user.TotalAmount)))
+				user.TotalAmountWorkHours = 0
+				user.TotalAmountWeekendHours = 0
+				user.TotalAmountBankHolidaysHours = 0
+				user.TotalAmount = 0
+				if amountsByUser, ok := serviceAmounts[scheduleData.ID]; ok {
+					amountsByUser[user.Name] = 0
+					setExtraValue(user, serviceAmountColumn, formatAmount(0))
+				}
+			}
+		}
+	}
+}
+
+// setSummaryHRStatuses sets the HR status of the users in the summary, which only know the users by name.
+func setSummaryHRStatuses(data *report.PrintableData) {
+	statusesByUser := make(map[string]string)
+	for _, scheduleData := range data.SchedulesData {
+		for _, user := range scheduleData.RotaUsers {
+			statusesByUser[user.Name] = user.ExtraValues[hrStatusColumn]
+		}
+	}
+	for _, user := range data.UsersSchedulesSummary {
+		setExtraValue(user, hrStatusColumn, statusesByUser[user.Name])
+	}
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_listActiveEmployees(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"email":"John@email.com","active":true},{"email":"mary@email.com","active":false}]`))
+	}))
+	defer server.Close()
+
+	active, err := listActiveEmployees(server.URL, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"john@email.com": true}, active)
+
+	_, err = listActiveEmployees(server.URL, "wrong")
+	require.Error(t, err)
+}
+
+func Test_setHRStatuses(t *testing.T) {
+	tests := []struct {
+		name                  string
+		hold                  bool
+		wantMaryAmount        float32
+		wantMaryServiceAmount float32
+		wantNotes             int
+	}{
+		{name: "flag the inactive users", wantMaryAmount: 50, wantMaryServiceAmount: 10},
+		{name: "hold the pay of the inactive users", hold: true, wantMaryAmount: 0, wantMaryServiceAmount: 0, wantNotes: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConfig(t, configuration.New())
+			john := &report.ScheduleUser{Name: "John Doe", EmailAddress: "john@email.com", TotalAmount: 100}
+			mary := &report.ScheduleUser{Name: "Mary Jane", EmailAddress: "mary@email.com", TotalAmountWorkHours: 50, TotalAmount: 50}
+			scheduleData := &report.ScheduleData{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{john, mary}}
+			data := &report.PrintableData{
+				SchedulesData:         []*report.ScheduleData{scheduleData},
+				UsersSchedulesSummary: []*report.ScheduleUser{{Name: "Mary Jane"}},
+			}
+
+			serviceAmounts := scheduleServiceAmounts{"SCHED_1": {"John Doe": 20, "Mary Jane": 10}}
+
+			setHRStatuses(data.SchedulesData, map[string]bool{"john@email.com": true}, tt.hold, serviceAmounts)
+			setSummaryHRStatuses(data)
+
+			assert.Equal(t, hrStatusActive, john.ExtraValues[hrStatusColumn])
+			assert.Equal(t, hrStatusInactive, mary.ExtraValues[hrStatusColumn])
+			assert.Equal(t, hrStatusInactive, data.UsersSchedulesSummary[0].ExtraValues[hrStatusColumn])
+			assert.Equal(t, float32(100), john.TotalAmount)
+			assert.Equal(t, tt.wantMaryAmount, mary.TotalAmount)
+			assert.Equal(t, float32(20), serviceAmounts.userTotal("John Doe"))
+			assert.Equal(t, tt.wantMaryServiceAmount, serviceAmounts.userTotal("Mary Jane"))
+			assert.Len(t, scheduleData.Notes, tt.wantNotes)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+)
+
+var (
+	gzipAPIResponses bool
+	http2            bool
+	idempotencyKey   bool
+
+	httpMaxIdleConns    int
+	httpMaxConnsPerHost int
+	httpIdleConnTimeout time.Duration
+	dnsCacheTTL         time.Duration
+
+	userAgent string
+	httpProxy urlValue
+	pinCerts  fingerprintsValue
+
+	// insecureSkipVerify is deliberately only read from the command line, never from the
+	// configuration file, so it can't be left enabled by accident.
+	insecureSkipVerify bool
+
+	requestLogFile string
+	debugHTTP      bool
+
+	apiBaseURL      urlValue
+	insecureBaseURL bool
+)
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&gzipAPIResponses, "gzip-api-responses", false, "request gzip compressed responses from the PagerDuty API")
+	rootCmd.PersistentFlags().BoolVar(&http2, "http2", false, "use HTTP/2 for the PagerDuty API calls")
+	rootCmd.PersistentFlags().BoolVar(&idempotencyKey, "idempotency-key", false, "send an X-Idempotency-Key header, a hash of the request, with the PagerDuty API calls that modify data")
+	rootCmd.PersistentFlags().IntVar(&httpMaxIdleConns, "http-max-idle-conns", 10, "maximum number of idle connections to the PagerDuty API")
+	rootCmd.PersistentFlags().IntVar(&httpMaxConnsPerHost, "http-max-conns-per-host", 0, "maximum number of connections to the PagerDuty API (0 is unlimited)")
+	rootCmd.PersistentFlags().DurationVar(&dnsCacheTTL, "dns-cache-ttl", 0, "time the PagerDuty API addresses are cached for, e.g. 30s (0 uses the standard resolver)")
+	rootCmd.PersistentFlags().DurationVar(&httpIdleConnTimeout, "http-idle-conn-timeout", 60*time.Second, "time an idle connection to the PagerDuty API is kept open")
+	rootCmd.PersistentFlags().StringVar(&userAgent, "user-agent",
+		fmt.Sprintf("go-pagerduty-oncall-report/%s (github.com/b3ngriffiths/go-pagerduty-oncall-report)", version),
+		"User-Agent header sent to the PagerDuty API")
+	rootCmd.PersistentFlags().Var(&httpProxy, "http-proxy", "proxy for the PagerDuty API calls, e.g. http://proxy.example.com:8080 (defaults to HTTPS_PROXY, NO_PROXY is honoured)")
+	rootCmd.PersistentFlags().Var(&pinCerts, "pin-cert", "SHA-256 fingerprint of an accepted PagerDuty API certificate, can be repeated for certificate rotation")
+	rootCmd.PersistentFlags().BoolVar(&insecureSkipVerify, "insecure-skip-verify", false, "don't verify the PagerDuty API certificate, only for debugging behind TLS-intercepting proxies")
+	rootCmd.PersistentFlags().StringVar(&requestLogFile, "request-log-file", "", "write every raw PagerDuty API request and response to this file, with the credentials redacted")
+	rootCmd.PersistentFlags().BoolVar(&debugHTTP, "debug-http", false, "log the connection pool settings, the protocol of the new connections with --http2 and the compression ratio with --gzip-api-responses")
+	rootCmd.PersistentFlags().Var(&apiBaseURL, "api-base-url", "base URL of the PagerDuty API, e.g. for API mocks or staging environments (default https://api.pagerduty.com)")
+	rootCmd.PersistentFlags().BoolVar(&insecureBaseURL, "insecure-base-url", false, "allow an http:// --api-base-url")
+}
+
+// newPagerDutyAPIClient returns a PagerDuty API client with the HTTP settings given on the command line, and
+// a function to release its resources, e.g. flush and close the request log file, once the command is done.
+func newPagerDutyAPIClient() (*api.PagerDutyClient, func(), error) {
+	if configErr != nil {
+		return nil, nil, configErr
+	}
+	closeClient := func() {}
+	opts := []api.ClientOption{
+		api.WithConnectionPool(httpMaxIdleConns, httpMaxConnsPerHost, httpIdleConnTimeout),
+		api.WithUserAgent(userAgent),
+	}
+	if len(pinCerts) > 0 {
+		opts = append(opts, api.WithPinnedCertificates(pinCerts))
+	}
+	if insecureSkipVerify {
+		fmt.Fprintln(os.Stderr, "**********************************************************************")
+		fmt.Fprintln(os.Stderr, "WARNING: --insecure-skip-verify is set, the PagerDuty API certificate")
+		fmt.Fprintln(os.Stderr, "is NOT verified and the auth token can be intercepted.")
+		fmt.Fprintln(os.Stderr, "**********************************************************************")
+		opts = append(opts, api.WithInsecureSkipVerify())
+	}
+	if apiBaseURL.url != nil {
+		if apiBaseURL.url.Scheme != "https" && !insecureBaseURL {
+			return nil, nil, fmt.Errorf("--api-base-url must be an https URL, unless --insecure-base-url is set: %s", apiBaseURL.String())
+		}
+		opts = append(opts, api.WithAPIEndpoint(apiBaseURL.String()))
+	}
+	if requestLogFile != "" {
+		file, err := os.Create(requestLogFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("creating the request log file: %w", err)
+		}
+		opts = append(opts, api.WithRequestLog(file))
+		closeClient = func() {
+			if err := file.Sync(); err != nil {
+				log.Printf("WARNING: failed to flush the request log file: %s", err)
+			}
+			if err := file.Close(); err != nil {
+				log.Printf("WARNING: failed to close the request log file: %s", err)
+			}
+		}
+	}
+	if httpProxy.url != nil {
+		opts = append(opts, api.WithProxy(httpProxy.url))
+	}
+	if dnsCacheTTL > 0 {
+		opts = append(opts, api.WithDNSCache(dnsCacheTTL))
+	}
+	if gzipAPIResponses {
+		opts = append(opts, api.WithGzip())
+	}
+	if http2 {
+		opts = append(opts, api.WithHTTP2())
+	}
+	if idempotencyKey {
+		opts = append(opts, api.WithIdempotencyKey())
+	}
+	if debugHTTP {
+		opts = append(opts, api.WithDebugLog())
+	}
+	if printAPICallSummary {
+		apiCallStats = api.NewCallStats()
+		opts = append(opts, api.WithCallStats(apiCallStats))
+	}
+	return api.NewPagerDutyAPIClient(Config.PdAuthToken, opts...), closeClient, nil
+}
+
+// urlValue is a flag value holding an absolute URL.
+type urlValue struct {
+	url *url.URL
+}
+
+func (v *urlValue) String() string {
+	if v.url == nil {
+		return ""
+	}
+	return v.url.String()
+}
+
+func (v *urlValue) Set(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("%q is not an absolute URL", value)
+	}
+	v.url = u
+	return nil
+}
+
+func (v *urlValue) Type() string {
+	return "url"
+}
+
+// fingerprintsValue is a repeatable flag value holding SHA-256 certificate fingerprints.
+type fingerprintsValue []string
+
+func (v *fingerprintsValue) String() string {
+	return strings.Join(*v, ",")
+}
+
+func (v *fingerprintsValue) Set(value string) error {
+	for _, fingerprint := range strings.Split(value, ",") {
+		normalized, err := api.NormalizeCertFingerprint(strings.TrimSpace(fingerprint))
+		if err != nil {
+			return err
+		}
+		*v = append(*v, normalized)
+	}
+	return nil
+}
+
+func (v *fingerprintsValue) Type() string {
+	return "fingerprints"
+}
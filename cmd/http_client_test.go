@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_urlValue_Set(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "absolute URL", value: "http://proxy.example.com:8080"},
+		{name: "no scheme", value: "proxy.example.com:8080", wantErr: true},
+		{name: "no host", value: "http://", wantErr: true},
+		{name: "invalid", value: "http://%zz", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v urlValue
+			err := v.Set(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, v.String())
+		})
+	}
+}
+
+func Test_fingerprintsValue_Set(t *testing.T) {
+	first := strings.Repeat("AB:", 31) + "AB"
+	second := strings.Repeat("cd", 32)
+
+	var v fingerprintsValue
+	require.NoError(t, v.Set(first+","+second))
+	require.NoError(t, v.Set(second))
+	assert.Equal(t, fingerprintsValue{strings.Repeat("ab", 32), second, second}, v)
+
+	require.Error(t, v.Set("not-a-fingerprint"))
+}
+
+func Test_newPagerDutyAPIClient_apiBaseURL(t *testing.T) {
+	defer func() {
+		apiBaseURL = urlValue{}
+		insecureBaseURL = false
+	}()
+	setConfig(t, configuration.New())
+
+	tests := []struct {
+		name     string
+		baseURL  string
+		insecure bool
+		wantErr  bool
+	}{
+		{name: "https", baseURL: "https://api.pagerduty.example.com"},
+		{name: "http", baseURL: "http://localhost:8080", wantErr: true},
+		{name: "http allowed", baseURL: "http://localhost:8080", insecure: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, apiBaseURL.Set(tt.baseURL))
+			insecureBaseURL = tt.insecure
+
+			_, closeClient, err := newPagerDutyAPIClient()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			closeClient()
+		})
+	}
+}
+
+func Test_newPagerDutyAPIClient_configError(t *testing.T) {
+	defer func() { configErr = nil }()
+	setConfig(t, configuration.New())
+	configErr = errors.New("can't read config")
+
+	_, _, err := newPagerDutyAPIClient()
+	assert.EqualError(t, err, "can't read config")
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+var (
+	generateInvoice bool
+	invoiceNumber   string
+	invoiceDueDate  string
+	invoiceClient   string
+	invoiceTaxRate  float32
+	invoiceLogo     string
+)
+
+// maxInvoiceLogoSize is the largest logo embedded in the invoice, in bytes.
+const maxInvoiceLogoSize = 1 << 20
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&generateInvoice, "generate-invoice", false, "also write an invoice pdf with a line per schedule, for contractor-style on-call arrangements")
+	scheduleReportCmd.Flags().StringVar(&invoiceNumber, "invoice-number", "", "number of the invoice written by --generate-invoice, e.g. INV-2024-001")
+	scheduleReportCmd.Flags().StringVar(&invoiceDueDate, "due-date", "", "payment due date of the invoice written by --generate-invoice, as YYYY-MM-DD")
+	scheduleReportCmd.Flags().StringVar(&invoiceClient, "invoice-client", "", "client the invoice written by --generate-invoice is addressed to (default the account name)")
+	scheduleReportCmd.Flags().StringVar(&invoiceLogo, "invoice-logo", "", "PNG or JPEG image of up to 1 MB shown in the top-left corner of the invoice written by --generate-invoice")
+	scheduleReportCmd.Flags().Float32Var(&invoiceTaxRate, "invoice-tax-rate", 0, "tax percentage added to the invoice written by --generate-invoice, e.g. 20")
+}
+
+// newInvoice returns the invoice described by the flags.
+func newInvoice() (report.Invoice, error) {
+	if invoiceNumber == "" {
+		return report.Invoice{}, fmt.Errorf("--invoice-number is required to generate an invoice")
+	}
+	if invoiceDueDate == "" {
+		return report.Invoice{}, fmt.Errorf("--due-date is required to generate an invoice")
+	}
+	dueDate, err := time.Parse("2006-01-02", invoiceDueDate)
+	if err != nil {
+		return report.Invoice{}, fmt.Errorf("invalid invoice due date %q, use YYYY-MM-DD: %w", invoiceDueDate, err)
+	}
+	if invoiceTaxRate < 0 {
+		return report.Invoice{}, fmt.Errorf("invoice tax rate %v must not be negative", invoiceTaxRate)
+	}
+
+	if invoiceLogo != "" {
+		if err := validateInvoiceLogo(invoiceLogo); err != nil {
+			return report.Invoice{}, err
+		}
+	}
+
+	client := invoiceClient
+	if client == "" {
+		client = Config.AccountName
+		if accountNameOverride != "" {
+			client = accountNameOverride
+		}
+	}
+	return report.Invoice{
+		Number:   invoiceNumber,
+		DueDate:  dueDate,
+		Client:   client,
+		TaxRate:  invoiceTaxRate,
+		Currency: invoiceCurrency(),
+		Decimals: amountDecimals(),
+		Logo:     invoiceLogo,
+	}, nil
+}
+
+// validateInvoiceLogo checks the logo is an image the invoice can embed.
+func validateInvoiceLogo(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("invalid invoice logo: %w", err)
+	}
+	if info.Size() > maxInvoiceLogoSize {
+		return fmt.Errorf("invoice logo %s is %d bytes, more than 1 MB", path, info.Size())
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png", ".jpg", ".jpeg":
+		return nil
+	default:
+		return fmt.Errorf("invoice logo %s is not supported, use a PNG or JPEG image", path)
+	}
+}
+
+func writeInvoice(invoice report.Invoice, data *report.PrintableData) error {
+	message, err := report.WriteInvoice(invoice, data, directory)
+	if err != nil {
+		return fmt.Errorf("failed to write the invoice: %w", err)
+	}
+	log.Println(message)
+	return nil
+}
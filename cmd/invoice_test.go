@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newInvoice(t *testing.T) {
+	defer func() {
+		invoiceNumber, invoiceDueDate, invoiceClient, invoiceTaxRate = "", "", "", 0
+	}()
+
+	tests := []struct {
+		name    string
+		number  string
+		dueDate string
+		client  string
+		taxRate float32
+		want    report.Invoice
+		wantErr string
+	}{
+		{
+			name:    "Client from the account name",
+			number:  "INV-2024-001",
+			dueDate: "2024-02-15",
+			taxRate: 20,
+			want: report.Invoice{Number: "INV-2024-001", DueDate: time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC),
+				Client: "Acme", TaxRate: 20, Currency: "£", Decimals: 2},
+		},
+		{
+			name:    "Client given",
+			number:  "INV-2024-001",
+			dueDate: "2024-02-15",
+			client:  "Globex",
+			want: report.Invoice{Number: "INV-2024-001", DueDate: time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC),
+				Client: "Globex", Currency: "£", Decimals: 2},
+		},
+		{name: "Missing number", dueDate: "2024-02-15", wantErr: "--invoice-number is required"},
+		{name: "Missing due date", number: "INV-2024-001", wantErr: "--due-date is required"},
+		{name: "Invalid due date", number: "INV-2024-001", dueDate: "15/02/2024", wantErr: "invalid invoice due date"},
+		{name: "Negative tax", number: "INV-2024-001", dueDate: "2024-02-15", taxRate: -5, wantErr: "must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConfig(t, configuration.New())
+			Config.AccountName = "Acme"
+			Config.RotationPrices.Currency = "£"
+			invoiceNumber, invoiceDueDate, invoiceClient, invoiceTaxRate = tt.number, tt.dueDate, tt.client, tt.taxRate
+
+			got, err := newInvoice()
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_writeInvoice(t *testing.T) {
+	defer func() { directory = "" }()
+	directory = t.TempDir()
+	invoice := report.Invoice{Number: "INV/2024/001", DueDate: time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC),
+		Client: "Acme", TaxRate: 20, Currency: "£", Decimals: 2}
+	data := &report.PrintableData{
+		Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		SchedulesData: []*report.ScheduleData{
+			{ID: "SCHED_1", Name: "Primary", RotaUsers: []*report.ScheduleUser{
+				{Name: "John Doe", NumWorkHours: 40, TotalAmount: 100},
+				{Name: "Mary Jane", NumWeekendHours: 16, TotalAmount: 80.5},
+			}},
+		},
+	}
+
+	require.NoError(t, writeInvoice(invoice, data))
+
+	info, err := os.Stat(filepath.Join(directory, "invoice.INV_2024_001.pdf"))
+	require.NoError(t, err)
+	assert.NotZero(t, info.Size())
+}
+
+func Test_validateInvoiceLogo(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, size int) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, make([]byte, size), 0600))
+		return path
+	}
+
+	assert.NoError(t, validateInvoiceLogo(write("logo.PNG", 1024)))
+	assert.ErrorContains(t, validateInvoiceLogo(filepath.Join(dir, "missing.png")), "invalid invoice logo")
+	assert.ErrorContains(t, validateInvoiceLogo(write("big.png", maxInvoiceLogoSize+1)), "more than 1 MB")
+	assert.ErrorContains(t, validateInvoiceLogo(write("logo.svg", 1024)), "use a PNG or JPEG image")
+}
+
+func Test_writeInvoice_logo(t *testing.T) {
+	defer func() { directory = "" }()
+	directory = t.TempDir()
+	logo := filepath.Join(directory, "logo.png")
+	file, err := os.Create(logo)
+	require.NoError(t, err)
+	require.NoError(t, png.Encode(file, image.NewRGBA(image.Rect(0, 0, 200, 100))))
+	require.NoError(t, file.Close())
+
+	invoice := report.Invoice{Number: "INV-2024-001", DueDate: time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC), Logo: logo}
+	data := &report.PrintableData{
+		Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	require.NoError(t, writeInvoice(invoice, data))
+	assert.FileExists(t, filepath.Join(directory, "invoice.INV-2024-001.pdf"))
+
+	invoice.Logo = filepath.Join(directory, "missing.png")
+	assert.ErrorContains(t, writeInvoice(invoice, data), "failed to load the invoice logo")
+}
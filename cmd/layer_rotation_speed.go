@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+)
+
+var includeLayerRotationSpeed bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includeLayerRotationSpeed, "include-layer-rotation-speed", false, "add a note to each schedule with the average number of days between the shifts of the same user in each of its layers")
+}
+
+// layerRotationSpeedNote returns a note with the average days between two consecutive shifts of the same user
+// in each layer of the schedule.
+func layerRotationSpeedNote(scheduleInfo *api.ScheduleInfo) (string, error) {
+	speeds := make([]string, 0, len(scheduleInfo.ScheduleLayers))
+	for i, layer := range scheduleInfo.ScheduleLayers {
+		name := layer.Name
+		if name == "" {
+			name = fmt.Sprintf("Layer %d", i+1)
+		}
+		days, err := avgRotationPeriodDays(layer)
+		if err != nil {
+			return "", fmt.Errorf("failed to compute the rotation speed of schedule %s: %w", scheduleInfo.ID, err)
+		}
+		if days == 0 {
+			speeds = append(speeds, fmt.Sprintf("%s unknown", name))
+			continue
+		}
+		speeds = append(speeds, fmt.Sprintf("%s %.1f", name, days))
+	}
+	if len(speeds) == 0 {
+		return "avg_rotation_period_days: no layers", nil
+	}
+	return "avg_rotation_period_days: " + strings.Join(speeds, ", "), nil
+}
+
+// avgRotationPeriodDays returns the average days between the starts of two consecutive shifts of the same user
+// in the layer, 0 when no user has two shifts.
+func avgRotationPeriodDays(layer api.ScheduleLayer) (float64, error) {
+	recurrences, err := shiftRecurrences(layer)
+	if err != nil || len(recurrences) == 0 {
+		return 0, err
+	}
+	var total time.Duration
+	for _, recurrence := range recurrences {
+		total += recurrence
+	}
+	return total.Hours() / 24 / float64(len(recurrences)), nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_layerRotationSpeedNote(t *testing.T) {
+	john := api.User{ID: "USER_1", Summary: "John Doe"}
+	mary := api.User{ID: "USER_2", Summary: "Mary Jane"}
+	daily := api.ScheduleLayer{
+		Name: "Daily",
+		RenderedScheduleEntries: []api.RenderedScheduleEntry{
+			{Start: "2022-08-01T00:00:00Z", End: "2022-08-02T00:00:00Z", User: john},
+			{Start: "2022-08-02T00:00:00Z", End: "2022-08-03T00:00:00Z", User: mary},
+			{Start: "2022-08-03T00:00:00Z", End: "2022-08-04T00:00:00Z", User: john},
+			{Start: "2022-08-04T00:00:00Z", End: "2022-08-05T00:00:00Z", User: mary},
+		},
+	}
+	uneven := api.ScheduleLayer{
+		RenderedScheduleEntries: []api.RenderedScheduleEntry{
+			{Start: "2022-08-01T00:00:00Z", End: "2022-08-08T00:00:00Z", User: john},
+			{Start: "2022-08-08T00:00:00Z", End: "2022-08-15T00:00:00Z", User: mary},
+			{Start: "2022-08-15T00:00:00Z", End: "2022-08-22T00:00:00Z", User: john},
+			{Start: "2022-08-22T00:00:00Z", End: "2022-08-29T00:00:00Z", User: john},
+		},
+	}
+	once := api.ScheduleLayer{
+		Name:                    "Backup",
+		RenderedScheduleEntries: []api.RenderedScheduleEntry{{Start: "2022-08-01T00:00:00Z", End: "2022-09-01T00:00:00Z", User: mary}},
+	}
+
+	tests := []struct {
+		name    string
+		layers  []api.ScheduleLayer
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "layers rotating at different speeds",
+			layers: []api.ScheduleLayer{daily, uneven, once},
+			want:   "avg_rotation_period_days: Daily 2.0, Layer 2 14.0, Backup unknown",
+		},
+		{name: "no layers", want: "avg_rotation_period_days: no layers"},
+		{
+			name:    "invalid entry",
+			layers:  []api.ScheduleLayer{{RenderedScheduleEntries: []api.RenderedScheduleEntry{{Start: "yesterday", User: john}}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			note, err := layerRotationSpeedNote(&api.ScheduleInfo{ID: "SCHED_1", ScheduleLayers: tt.layers})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, note)
+		})
+	}
+}
@@ -3,8 +3,6 @@ package cmd
 import (
 	"fmt"
 
-	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
-
 	"github.com/spf13/cobra"
 )
 
@@ -14,7 +12,12 @@ var listServicesCmd = &cobra.Command{
 	Long:  "Get the list of services configured in PagerDuty",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		pd := &pagerDutyClient{client: api.NewPagerDutyAPIClient(Config.PdAuthToken)}
+		client, closeClient, err := newPagerDutyAPIClient()
+		if err != nil {
+			return err
+		}
+		defer closeClient()
+		pd := &pagerDutyClient{client: client}
 		return pd.listServices(args[0])
 	},
 }
@@ -3,8 +3,6 @@ package cmd
 import (
 	"fmt"
 
-	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
-
 	"github.com/spf13/cobra"
 )
 
@@ -13,7 +11,12 @@ var listTeamsCmd = &cobra.Command{
 	Short: "list teams on PagerDuty",
 	Long:  "Get the list of teams configured in PagerDuty",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		pd := &pagerDutyClient{client: api.NewPagerDutyAPIClient(Config.PdAuthToken)}
+		client, closeClient, err := newPagerDutyAPIClient()
+		if err != nil {
+			return err
+		}
+		defer closeClient()
+		pd := &pagerDutyClient{client: client}
 		return pd.listTeams()
 	},
 }
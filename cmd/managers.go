@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const (
+	managerEmailColumn = "manager_email"
+	managerNameColumn  = "manager_name"
+)
+
+var includeManager bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includeManager, "include-manager", false, "add manager_email and manager_name columns with the email and name of each user's manager, as configured in managerEmailMap")
+}
+
+// setManagers sets the manager of each user, so payroll systems can route the approvals.
+// PagerDuty has no manager data, so the managers come from managerEmailMap and their names
+// from PagerDuty when they are users too.
+func (pd *pagerDutyClient) setManagers(data *report.PrintableData) error {
+	users := data.UsersSchedulesSummary
+	for _, scheduleData := range data.SchedulesData {
+		users = append(users[:len(users):len(users)], scheduleData.RotaUsers...)
+	}
+
+	for _, user := range users {
+		managerEmail := Config.FindManagerEmail(user.EmailAddress)
+		managerName := ""
+		if managerEmail != "" {
+			name, err := pd.getManagerName(managerEmail)
+			if err != nil {
+				return fmt.Errorf("failed to get the manager of %s: %w", user.EmailAddress, err)
+			}
+			managerName = name
+		}
+		setExtraValue(user, managerEmailColumn, managerEmail)
+		setExtraValue(user, managerNameColumn, managerName)
+	}
+	return nil
+}
+
+func (pd *pagerDutyClient) getManagerName(email string) (string, error) {
+	if len(pd.cachedUsers) == 0 {
+		err := pd.loadUsersInMemoryCache()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	for _, user := range pd.cachedUsers {
+		if strings.EqualFold(user.Email, email) {
+			return pd.getUserName(user.ID)
+		}
+	}
+	return "", nil
+}
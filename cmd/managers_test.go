@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_pagerDutyClient_setManagers(t *testing.T) {
+	tests := []struct {
+		name      string
+		mockSetup func(*clientMock)
+		want      map[string]string
+		wantErr   bool
+	}{
+		{
+			name: "Manager is a PagerDuty user",
+			mockSetup: func(mock *clientMock) {
+				mock.On("ListUsers").Once().Return([]*api.User{{ID: "USER_9", Name: "Jane Boss", Email: "Boss@email.com"}}, nil)
+			},
+			want: map[string]string{managerEmailColumn: "boss@email.com", managerNameColumn: "Jane Boss"},
+		},
+		{
+			name: "Manager is not a PagerDuty user",
+			mockSetup: func(mock *clientMock) {
+				mock.On("ListUsers").Once().Return([]*api.User{{ID: "USER_1", Email: "john@email.com"}}, nil)
+			},
+			want: map[string]string{managerEmailColumn: "boss@email.com", managerNameColumn: ""},
+		},
+		{
+			name: "Fails to list the users",
+			mockSetup: func(mock *clientMock) {
+				mock.On("ListUsers").Once().Return(nil, errors.New("failed"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConfig(t, configuration.New())
+			Config.ManagerEmailMap = map[string]string{"john@email.com": "boss@email.com"}
+			mockedClient := &clientMock{}
+			tt.mockSetup(mockedClient)
+
+			pd := pagerDutyClient{client: mockedClient}
+			john := &report.ScheduleUser{Name: "John Doe", EmailAddress: "John@email.com"}
+			mary := &report.ScheduleUser{Name: "Mary Jane", EmailAddress: "mary@email.com"}
+			data := &report.PrintableData{
+				SchedulesData:         []*report.ScheduleData{{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{john, mary}}},
+				UsersSchedulesSummary: []*report.ScheduleUser{{Name: "John Doe", EmailAddress: "John@email.com"}},
+			}
+
+			err := pd.setManagers(data)
+			mockedClient.AssertExpectations(t)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, john.ExtraValues)
+			assert.Equal(t, tt.want, data.UsersSchedulesSummary[0].ExtraValues)
+			assert.Equal(t, map[string]string{"manager_email": "", "manager_name": ""}, mary.ExtraValues)
+		})
+	}
+}
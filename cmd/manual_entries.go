@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+)
+
+const (
+	sourceOfTruthAPI    = "api"
+	sourceOfTruthConfig = "config"
+)
+
+var overrideSourceOfTruth string
+
+func init() {
+	scheduleReportCmd.Flags().StringVar(&overrideSourceOfTruth, "override-source-of-truth", sourceOfTruthAPI, "who was on call when the PagerDuty data and the configured manualEntries overlap: api, config")
+}
+
+type onCallEntry struct {
+	start time.Time
+	end   time.Time
+	user  api.User
+}
+
+// applyManualEntries merges the configured manual entries into the PagerDuty entries of the
+// schedule. Where both overlap, the manual entries only win when the config is the source of truth,
+// otherwise they just fill the periods PagerDuty has no data for.
+func (pd *pagerDutyClient) applyManualEntries(scheduleInfo *api.ScheduleInfo) error {
+	manualEntries := Config.FindManualEntriesByScheduleID(scheduleInfo.ID)
+	if len(manualEntries) == 0 {
+		return nil
+	}
+
+	entries, err := parseRenderedScheduleEntries(scheduleInfo.FinalSchedule.RenderedScheduleEntries, scheduleInfo.Location)
+	if err != nil {
+		return fmt.Errorf("failed to parse the entries of schedule %s: %w", scheduleInfo.ID, err)
+	}
+
+	overrides := make([]onCallEntry, 0, len(manualEntries))
+	for _, manualEntry := range manualEntries {
+		start, err := time.Parse(time.RFC822, manualEntry.Start)
+		if err != nil {
+			return fmt.Errorf("error parsing manual entry start time of schedule %s: %w", scheduleInfo.ID, err)
+		}
+		end, err := time.Parse(time.RFC822, manualEntry.End)
+		if err != nil {
+			return fmt.Errorf("error parsing manual entry end time of schedule %s: %w", scheduleInfo.ID, err)
+		}
+		userID := manualEntry.UserId
+		if userID == "" {
+			userID, err = pd.getUserIDByEmail(manualEntry.UserEmail)
+			if err != nil {
+				return fmt.Errorf("failed to apply manual entry of schedule %s: %w", scheduleInfo.ID, err)
+			}
+		}
+		name, err := pd.getUserName(userID)
+		if err != nil {
+			return fmt.Errorf("failed to apply manual entry of schedule %s: %w", scheduleInfo.ID, err)
+		}
+
+		log.Printf("[%s] manual entry from %s to %s: %s", scheduleInfo.ID, start, end, name)
+		overrides = append(overrides, onCallEntry{
+			start: start,
+			end:   end,
+			user:  api.User{ID: userID, Summary: name},
+		})
+	}
+	overrides = clipOnCallEntries(overrides, scheduleInfo.Start, scheduleInfo.End)
+
+	if overrideSourceOfTruth == sourceOfTruthConfig {
+		entries = overrideOnCallEntries(entries, overrides)
+	} else {
+		entries = overrideOnCallEntries(overrides, entries)
+	}
+	scheduleInfo.FinalSchedule.RenderedScheduleEntries = formatRenderedScheduleEntries(entries)
+	return nil
+}
+
+// clipOnCallEntries trims the entries to the given period, dropping the ones outside of it.
+func clipOnCallEntries(entries []onCallEntry, periodStart, periodEnd time.Time) []onCallEntry {
+	clipped := make([]onCallEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.start.Before(periodStart) {
+			entry.start = periodStart
+		}
+		if entry.end.After(periodEnd) {
+			entry.end = periodEnd
+		}
+		if entry.start.Before(entry.end) {
+			clipped = append(clipped, entry)
+		}
+	}
+	return clipped
+}
+
+// overrideOnCallEntries cuts the overrides out of the entries, then adds the overrides.
+// The result is sorted by start time.
+func overrideOnCallEntries(entries, overrides []onCallEntry) []onCallEntry {
+	for _, override := range overrides {
+		remaining := make([]onCallEntry, 0, len(entries)+1)
+		for _, entry := range entries {
+			if !entry.start.Before(override.end) || !entry.end.After(override.start) {
+				remaining = append(remaining, entry)
+				continue
+			}
+			if entry.start.Before(override.start) {
+				remaining = append(remaining, onCallEntry{start: entry.start, end: override.start, user: entry.user})
+			}
+			if entry.end.After(override.end) {
+				remaining = append(remaining, onCallEntry{start: override.end, end: entry.end, user: entry.user})
+			}
+		}
+		entries = remaining
+	}
+
+	entries = append(entries, overrides...)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].start.Before(entries[j].start)
+	})
+	return entries
+}
+
+func parseRenderedScheduleEntries(renderedEntries []api.RenderedScheduleEntry, location *time.Location) ([]onCallEntry, error) {
+	entries := make([]onCallEntry, 0, len(renderedEntries))
+	for _, renderedEntry := range renderedEntries {
+		start, err := time.ParseInLocation(time.RFC3339, renderedEntry.Start, location)
+		if err != nil {
+			return nil, err
+		}
+		end, err := time.ParseInLocation(time.RFC3339, renderedEntry.End, location)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, onCallEntry{start: start, end: end, user: renderedEntry.User})
+	}
+	return entries, nil
+}
+
+func formatRenderedScheduleEntries(entries []onCallEntry) []api.RenderedScheduleEntry {
+	renderedEntries := make([]api.RenderedScheduleEntry, 0, len(entries))
+	for _, entry := range entries {
+		renderedEntries = append(renderedEntries, api.RenderedScheduleEntry{
+			Start: entry.start.Format(time.RFC3339),
+			End:   entry.end.Format(time.RFC3339),
+			User:  entry.user,
+		})
+	}
+	return renderedEntries
+}
+
+func (pd *pagerDutyClient) getUserIDByEmail(email string) (string, error) {
+	if len(pd.cachedUsers) == 0 {
+		err := pd.loadUsersInMemoryCache()
+		if err != nil {
+			return "", fmt.Errorf("failed to get user with email %s: %w", email, err)
+		}
+	}
+
+	for _, user := range pd.cachedUsers {
+		if strings.EqualFold(user.Email, email) {
+			return user.ID, nil
+		}
+	}
+	return "", fmt.Errorf("user with email %s not found", email)
+}
+
+// getUserName returns the name of the user as configured in rotationUsers, or as set in PagerDuty.
+func (pd *pagerDutyClient) getUserName(userID string) (string, error) {
+	for _, rotationUser := range Config.RotationUsers {
+		if rotationUser.UserID == userID && rotationUser.Name != "" {
+			return rotationUser.Name, nil
+		}
+	}
+
+	if len(pd.cachedUsers) == 0 {
+		err := pd.loadUsersInMemoryCache()
+		if err != nil {
+			return "", fmt.Errorf("failed to get user with id %s name: %w", userID, err)
+		}
+	}
+
+	for _, user := range pd.cachedUsers {
+		if user.ID == userID {
+			return user.Name, nil
+		}
+	}
+	return "", fmt.Errorf("user with id %s not found", userID)
+}
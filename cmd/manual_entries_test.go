@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_pagerDutyClient_applyManualEntries(t *testing.T) {
+	newScheduleInfo := func() *api.ScheduleInfo {
+		return &api.ScheduleInfo{
+			ID:       "SCHED_1",
+			Location: time.UTC,
+			Start:    time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC),
+			End:      time.Date(2022, 8, 8, 0, 0, 0, 0, time.UTC),
+			FinalSchedule: api.ScheduleLayer{
+				RenderedScheduleEntries: []api.RenderedScheduleEntry{
+					{Start: "2022-08-01T00:00:00Z", End: "2022-08-04T00:00:00Z", User: api.User{ID: "USER_1", Summary: "John Doe"}},
+					{Start: "2022-08-04T00:00:00Z", End: "2022-08-08T00:00:00Z", User: api.User{ID: "USER_2", Summary: "Mary Jane"}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		sourceOfTruth string
+		manualEntries []configuration.ManualEntry
+		gap           bool
+		mockSetup     func(*clientMock)
+		want          []api.RenderedScheduleEntry
+		wantErr       bool
+	}{
+		{
+			name:          "PagerDuty entries win over overlapping manual entries when PagerDuty is the source of truth",
+			sourceOfTruth: sourceOfTruthAPI,
+			manualEntries: []configuration.ManualEntry{
+				{ScheduleId: "SCHED_1", UserId: "USER_3", Start: "02 Aug 22 00:00 UTC", End: "03 Aug 22 00:00 UTC"},
+			},
+			want: newScheduleInfo().FinalSchedule.RenderedScheduleEntries,
+		},
+		{
+			name:          "Manual entry fills the gaps in PagerDuty data when PagerDuty is the source of truth",
+			sourceOfTruth: sourceOfTruthAPI,
+			manualEntries: []configuration.ManualEntry{
+				{ScheduleId: "SCHED_1", UserEmail: "roger@email.com", Start: "31 Jul 22 00:00 UTC", End: "09 Aug 22 00:00 UTC"},
+			},
+			gap: true,
+			mockSetup: func(mock *clientMock) {
+				mock.On("ListUsers").Once().Return([]*api.User{{ID: "USER_3", Email: "Roger@email.com"}}, nil)
+			},
+			want: []api.RenderedScheduleEntry{
+				{Start: "2022-08-01T00:00:00Z", End: "2022-08-04T00:00:00Z", User: api.User{ID: "USER_1", Summary: "John Doe"}},
+				{Start: "2022-08-04T00:00:00Z", End: "2022-08-05T00:00:00Z", User: api.User{ID: "USER_3", Summary: "Roger Solé"}},
+				{Start: "2022-08-05T00:00:00Z", End: "2022-08-08T00:00:00Z", User: api.User{ID: "USER_2", Summary: "Mary Jane"}},
+			},
+		},
+		{
+			name:          "Manual entry with an unknown email",
+			sourceOfTruth: sourceOfTruthConfig,
+			manualEntries: []configuration.ManualEntry{
+				{ScheduleId: "SCHED_1", UserEmail: "nobody@email.com", Start: "02 Aug 22 00:00 UTC", End: "03 Aug 22 00:00 UTC"},
+			},
+			mockSetup: func(mock *clientMock) {
+				mock.On("ListUsers").Once().Return([]*api.User{{ID: "USER_3", Email: "roger@email.com"}}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:          "Manual entry splits a PagerDuty entry",
+			sourceOfTruth: sourceOfTruthConfig,
+			manualEntries: []configuration.ManualEntry{
+				{ScheduleId: "SCHED_1", UserId: "USER_3", Start: "02 Aug 22 00:00 UTC", End: "03 Aug 22 00:00 UTC"},
+				{ScheduleId: "SCHED_2", UserId: "USER_3", Start: "05 Aug 22 00:00 UTC", End: "06 Aug 22 00:00 UTC"},
+			},
+			want: []api.RenderedScheduleEntry{
+				{Start: "2022-08-01T00:00:00Z", End: "2022-08-02T00:00:00Z", User: api.User{ID: "USER_1", Summary: "John Doe"}},
+				{Start: "2022-08-02T00:00:00Z", End: "2022-08-03T00:00:00Z", User: api.User{ID: "USER_3", Summary: "Roger Solé"}},
+				{Start: "2022-08-03T00:00:00Z", End: "2022-08-04T00:00:00Z", User: api.User{ID: "USER_1", Summary: "John Doe"}},
+				{Start: "2022-08-04T00:00:00Z", End: "2022-08-08T00:00:00Z", User: api.User{ID: "USER_2", Summary: "Mary Jane"}},
+			},
+		},
+		{
+			name:          "Manual entry spanning two PagerDuty entries is clipped to the schedule period",
+			sourceOfTruth: sourceOfTruthConfig,
+			manualEntries: []configuration.ManualEntry{
+				{ScheduleId: "SCHED_1", UserId: "USER_3", Start: "03 Aug 22 12:00 UTC", End: "10 Aug 22 00:00 UTC"},
+			},
+			want: []api.RenderedScheduleEntry{
+				{Start: "2022-08-01T00:00:00Z", End: "2022-08-03T12:00:00Z", User: api.User{ID: "USER_1", Summary: "John Doe"}},
+				{Start: "2022-08-03T12:00:00Z", End: "2022-08-08T00:00:00Z", User: api.User{ID: "USER_3", Summary: "Roger Solé"}},
+			},
+		},
+		{
+			name:          "Malformed manual entry",
+			sourceOfTruth: sourceOfTruthConfig,
+			manualEntries: []configuration.ManualEntry{
+				{ScheduleId: "SCHED_1", UserId: "USER_3", Start: "2022-08-02", End: "03 Aug 22 00:00 UTC"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConfig(t, configuration.New())
+			Config.RotationUsers = []configuration.RotationUser{{UserID: "USER_3", Name: "Roger Solé"}}
+			Config.ManualEntries = tt.manualEntries
+			overrideSourceOfTruth = tt.sourceOfTruth
+			defer func() { overrideSourceOfTruth = sourceOfTruthAPI }()
+
+			scheduleInfo := newScheduleInfo()
+			if tt.gap {
+				scheduleInfo.FinalSchedule.RenderedScheduleEntries[1].Start = "2022-08-05T00:00:00Z"
+			}
+			mockedClient := &clientMock{}
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockedClient)
+			}
+
+			pd := pagerDutyClient{client: mockedClient}
+			err := pd.applyManualEntries(scheduleInfo)
+			mockedClient.AssertExpectations(t)
+
+			if tt.wantErr == true {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, scheduleInfo.FinalSchedule.RenderedScheduleEntries)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import "math"
+
+var formatAmountsAsIntegers bool
+
+// minorUnitNames are the names of the minor units of the currencies not divided in cents.
+var minorUnitNames = map[string]string{
+	"GBP": "pence",
+	"JPY": "yen",
+	"KRW": "won",
+	"ISK": "krona",
+	"KWD": "fils",
+	"BHD": "fils",
+}
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&formatAmountsAsIntegers, "format-amounts-as-integers", false, "write the amounts of the csv output as whole numbers of the minor unit of the currency, e.g. pence, for payroll systems")
+}
+
+// minorUnit returns the name of the minor unit of the configured currency, cents unless known otherwise,
+// and how many of them make a unit, following the decimal places of the currency.
+func minorUnit() (string, int) {
+	perUnit := int(math.Pow10(amountDecimals()))
+	code, err := currencyCode()
+	if err != nil {
+		return "cents", perUnit
+	}
+	if name, ok := minorUnitNames[code]; ok {
+		return name, perUnit
+	}
+	return "cents", perUnit
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_minorUnit(t *testing.T) {
+	tests := []struct {
+		currency    string
+		wantName    string
+		wantPerUnit int
+	}{
+		{currency: "£", wantName: "pence", wantPerUnit: 100},
+		{currency: "€", wantName: "cents", wantPerUnit: 100},
+		{currency: "¥", wantName: "yen", wantPerUnit: 1},
+		{currency: "₿", wantName: "cents", wantPerUnit: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.currency, func(t *testing.T) {
+			setConfig(t, configuration.New())
+			Config.RotationPrices.Currency = tt.currency
+
+			name, perUnit := minorUnit()
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantPerUnit, perUnit)
+		})
+	}
+}
+
+func Test_writeReports_formatAmountsAsIntegers(t *testing.T) {
+	defer func() {
+		formatAmountsAsIntegers = false
+		directory = ""
+	}()
+	setConfig(t, configuration.New())
+	Config.RotationPrices.Currency = "£"
+	directory = t.TempDir()
+	formatAmountsAsIntegers = true
+
+	data := &report.PrintableData{
+		Start: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+		UsersSchedulesSummary: []*report.ScheduleUser{
+			{Name: "John Doe", TotalAmountWorkHours: 250.5, TotalAmountWeekendHours: 0.07, TotalAmount: 250.57},
+		},
+	}
+
+	_, err := writeReports(data, []string{"csv"})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(directory, "pagerduty_oncall_report.1-2021-Summary.csv"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Total Weekday Amount (pence)")
+	assert.Contains(t, string(content), ",25050,7,0,25057\n")
+}
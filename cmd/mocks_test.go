@@ -3,6 +3,8 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,6 +15,29 @@ type clientMock struct {
 	mock.Mock
 }
 
+// GetEscalationPolicy provides a mock function with given fields: escalationPolicyID
+func (_m *clientMock) GetEscalationPolicy(escalationPolicyID string) (*api.EscalationPolicy, error) {
+	ret := _m.Called(escalationPolicyID)
+
+	var r0 *api.EscalationPolicy
+	if rf, ok := ret.Get(0).(func(string) *api.EscalationPolicy); ok {
+		r0 = rf(escalationPolicyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.EscalationPolicy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(escalationPolicyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSchedule provides a mock function with given fields: scheduleID, startDate, endDate
 func (_m *clientMock) GetSchedule(scheduleID string, startDate string, endDate string) (*api.Schedule, error) {
 	ret := _m.Called(scheduleID, startDate, endDate)
@@ -36,6 +61,29 @@ func (_m *clientMock) GetSchedule(scheduleID string, startDate string, endDate s
 	return r0, r1
 }
 
+// ListAlerts provides a mock function with given fields: serviceIDs, since, until
+func (_m *clientMock) ListAlerts(serviceIDs []string, since time.Time, until time.Time) ([]*api.Alert, error) {
+	ret := _m.Called(serviceIDs, since, until)
+
+	var r0 []*api.Alert
+	if rf, ok := ret.Get(0).(func([]string, time.Time, time.Time) []*api.Alert); ok {
+		r0 = rf(serviceIDs, since, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*api.Alert)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string, time.Time, time.Time) error); ok {
+		r1 = rf(serviceIDs, since, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListUsers provides a mock function with given fields:
 func (_m *clientMock) ListUsers() ([]*api.User, error) {
 	ret := _m.Called()
@@ -105,6 +153,29 @@ func (_m *clientMock) ListServices(_a0 string) ([]*api.Service, error) {
 	return r0, r1
 }
 
+// SearchSchedules provides a mock function with given fields: query
+func (_m *clientMock) SearchSchedules(query string) ([]*api.Schedule, error) {
+	ret := _m.Called(query)
+
+	var r0 []*api.Schedule
+	if rf, ok := ret.Get(0).(func(string) []*api.Schedule); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*api.Schedule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListSchedules provides a mock function with given fields:
 func (_m *clientMock) ListSchedules() ([]*api.Schedule, error) {
 	ret := _m.Called()
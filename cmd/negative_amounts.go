@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+var (
+	warnOnNegativeAmount bool
+	noFailOnError        bool
+)
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&warnOnNegativeAmount, "warn-on-negative-amount", false, "check for negative amounts, a calculation error, noting them in the schedules and aborting the report")
+	scheduleReportCmd.Flags().BoolVar(&noFailOnError, "no-fail-on-error", false, "write the report even when --warn-on-negative-amount finds calculation errors")
+}
+
+// negativeAmounts returns a description of each negative amount of the schedule users.
+func negativeAmounts(scheduleData *report.ScheduleData) []string {
+	problems := make([]string, 0)
+	for _, user := range scheduleData.RotaUsers {
+		amounts := []struct {
+			name   string
+			amount float32
+		}{
+			{"weekday", user.TotalAmountWorkHours},
+			{"weekend", user.TotalAmountWeekendHours},
+			{"bank holiday", user.TotalAmountBankHolidaysHours},
+			{"total", user.TotalAmount},
+		}
+		for _, amount := range amounts {
+			if amount.amount < 0 {
+				problems = append(problems, fmt.Sprintf("negative %s amount %s for %s", amount.name, formatCurrencyAmount(amount.amount), user.Name))
+			}
+		}
+	}
+	return problems
+}
+
+// checkNegativeAmounts notes the negative amounts of each schedule as calculation errors, which can't
+// happen with positive rates and hours, and fails unless --no-fail-on-error is set.
+func checkNegativeAmounts(data *report.PrintableData) error {
+	var errorCount int
+	for _, scheduleData := range data.SchedulesData {
+		for _, problem := range negativeAmounts(scheduleData) {
+			log.Printf("ERROR: schedule '%s' has a %s", scheduleData.ID, problem)
+			scheduleData.Notes = append(scheduleData.Notes, "Calculation error: "+problem)
+			errorCount++
+		}
+	}
+
+	if errorCount > 0 && !noFailOnError {
+		return fmt.Errorf("found %d calculation error(s) with negative amounts, use --no-fail-on-error to write the report anyway", errorCount)
+	}
+	return nil
+}
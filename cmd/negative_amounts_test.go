@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkNegativeAmounts(t *testing.T) {
+	defer func() { noFailOnError = false }()
+	setConfig(t, configuration.New())
+	Config.RotationPrices.Currency = "£"
+
+	tests := []struct {
+		name          string
+		amount        float32
+		noFailOnError bool
+		wantErr       bool
+		wantNotes     []string
+	}{
+		{name: "positive amounts", amount: 100, wantNotes: []string{}},
+		{name: "negative amount", amount: -10, wantErr: true, wantNotes: []string{
+			"Calculation error: negative weekday amount £-10.00 for John Doe",
+			"Calculation error: negative total amount £-10.00 for John Doe",
+		}},
+		{name: "negative amount without failing", amount: -10, noFailOnError: true, wantNotes: []string{
+			"Calculation error: negative weekday amount £-10.00 for John Doe",
+			"Calculation error: negative total amount £-10.00 for John Doe",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noFailOnError = tt.noFailOnError
+			scheduleData := &report.ScheduleData{
+				ID: "SCHED_1",
+				RotaUsers: []*report.ScheduleUser{
+					{Name: "John Doe", TotalAmountWorkHours: tt.amount, TotalAmount: tt.amount},
+				},
+				Notes: make([]string, 0),
+			}
+
+			err := checkNegativeAmounts(&report.PrintableData{SchedulesData: []*report.ScheduleData{scheduleData}})
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantNotes, scheduleData.Notes)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+// normalizedHoursColumn is named after the value rather than "hours", so it isn't mistaken for the hours on call.
+const normalizedHoursColumn = "normalized_hours"
+
+var normalizeAmountsToHours bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&normalizeAmountsToHours, "normalize-amounts-to-hours", false, "add the amount of each user divided by their weekday hourly rate, to compare the on-call burden regardless of the rates")
+}
+
+// normalizedHours returns the weekday hours that are paid the given amount at the given hourly rate.
+func normalizedHours(amount, weekdayHourlyRate float32) float32 {
+	if weekdayHourlyRate == 0 {
+		return 0
+	}
+	return amount / weekdayHourlyRate
+}
+
+func setNormalizedHours(user *report.ScheduleUser, weekdayHourlyRate float32) {
+	setExtraValue(user, normalizedHoursColumn, fmt.Sprintf("%.2f", normalizedHours(user.TotalAmount, weekdayHourlyRate)))
+}
+
+// setSummaryNormalizedHours sets the normalized hours of the users in the summary, adding up the ones of each
+// schedule as every schedule may pay a different rate.
+func setSummaryNormalizedHours(data *report.PrintableData) error {
+	hoursByUser := make(map[string]float64)
+	for _, scheduleData := range data.SchedulesData {
+		for _, user := range scheduleData.RotaUsers {
+			hours, err := strconv.ParseFloat(user.ExtraValues[normalizedHoursColumn], 32)
+			if err != nil {
+				return fmt.Errorf("invalid normalized hours for %s in schedule %s: %w", user.Name, scheduleData.ID, err)
+			}
+			hoursByUser[user.Name] += hours
+		}
+	}
+	for _, user := range data.UsersSchedulesSummary {
+		setExtraValue(user, normalizedHoursColumn, fmt.Sprintf("%.2f", hoursByUser[user.Name]))
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_normalizedHours(t *testing.T) {
+	assert.Equal(t, float32(12.5), normalizedHours(125, 10))
+	assert.Equal(t, float32(0), normalizedHours(125, 0))
+}
+
+func Test_setSummaryNormalizedHours(t *testing.T) {
+	john := &report.ScheduleUser{Name: "John Doe", TotalAmount: 100}
+	setNormalizedHours(john, 8)
+	otherJohn := &report.ScheduleUser{Name: "John Doe", TotalAmount: 30}
+	setNormalizedHours(otherJohn, 12)
+	mary := &report.ScheduleUser{Name: "Mary Jane", TotalAmount: 45}
+	setNormalizedHours(mary, 12)
+
+	data := &report.PrintableData{
+		SchedulesData: []*report.ScheduleData{
+			{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{john}},
+			{ID: "SCHED_2", RotaUsers: []*report.ScheduleUser{otherJohn, mary}},
+		},
+		UsersSchedulesSummary: []*report.ScheduleUser{{Name: "John Doe"}, {Name: "Mary Jane"}},
+	}
+	require.NoError(t, setSummaryNormalizedHours(data))
+
+	assert.Equal(t, "12.50", john.ExtraValues[normalizedHoursColumn])
+	assert.Equal(t, "2.50", otherJohn.ExtraValues[normalizedHoursColumn])
+	assert.Equal(t, "3.75", mary.ExtraValues[normalizedHoursColumn])
+	assert.Equal(t, "15.00", data.UsersSchedulesSummary[0].ExtraValues[normalizedHoursColumn])
+	assert.Equal(t, "3.75", data.UsersSchedulesSummary[1].ExtraValues[normalizedHoursColumn])
+}
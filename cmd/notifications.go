@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/notification"
+)
+
+var (
+	opsGenieAPIKey string
+	opsGenieTeam   string
+
+	victorOpsAPIKey      string
+	victorOpsRoutingKey  string
+	victorOpsMessageType string
+
+	pdAlertServiceID string
+
+	statusPageAPIKey           string
+	statusPagePageID           string
+	statusPageExpectedDuration time.Duration
+)
+
+func init() {
+	scheduleReportCmd.Flags().StringVar(&opsGenieAPIKey, "opsgenie-api-key", "", "OpsGenie API key used to raise an alert when the report generation fails")
+	scheduleReportCmd.Flags().StringVar(&opsGenieTeam, "opsgenie-team", "", "OpsGenie team the failure alert is assigned to")
+	scheduleReportCmd.Flags().StringVar(&victorOpsAPIKey, "victorops-api-key", "", "VictorOps REST integration API key")
+	scheduleReportCmd.Flags().StringVar(&victorOpsRoutingKey, "victorops-routing-key", "", "VictorOps routing key used to raise an incident when the report generation fails")
+	scheduleReportCmd.Flags().StringVar(&victorOpsMessageType, "victorops-message-type", "CRITICAL", "VictorOps message type for failures: CRITICAL, WARNING, INFO")
+	scheduleReportCmd.Flags().StringVar(&pdAlertServiceID, "pd-alert-service-id", "", "raise a PagerDuty incident when the report generation fails, identified by this service ID; the service is the one of the PD_ALERT_ROUTING_KEY integration, which is required")
+	scheduleReportCmd.Flags().StringVar(&statusPageAPIKey, "statuspage-api-key", "", "Statuspage API key used to open a maintenance window while the report is generated")
+	scheduleReportCmd.Flags().StringVar(&statusPagePageID, "statuspage-page-id", "", "Statuspage page where the maintenance window is created")
+	scheduleReportCmd.Flags().DurationVar(&statusPageExpectedDuration, "statuspage-expected-duration", 30*time.Minute, "expected duration of the report run, used for the maintenance window")
+}
+
+func reportNotifiers() ([]notification.Notifier, error) {
+	notifiers := make([]notification.Notifier, 0)
+	if opsGenieAPIKey != "" {
+		notifiers = append(notifiers, notification.NewOpsGenieNotifier(opsGenieAPIKey, opsGenieTeam))
+	}
+	if victorOpsRoutingKey != "" {
+		if victorOpsAPIKey == "" {
+			return nil, fmt.Errorf("--victorops-api-key is required when --victorops-routing-key is set")
+		}
+		messageType := strings.ToUpper(victorOpsMessageType)
+		if !contains(notification.VictorOpsMessageTypes, messageType) {
+			return nil, fmt.Errorf("victorops message type %s not supported, use one of: %s",
+				victorOpsMessageType, strings.Join(notification.VictorOpsMessageTypes, ", "))
+		}
+		notifiers = append(notifiers, notification.NewVictorOpsNotifier(victorOpsAPIKey, victorOpsRoutingKey, messageType, Config.AccountName))
+	}
+	if pdAlertServiceID != "" {
+		if Config.PdAlertRoutingKey == "" {
+			return nil, fmt.Errorf("PD_ALERT_ROUTING_KEY is required when --pd-alert-service-id is set")
+		}
+		notifiers = append(notifiers, notification.NewPagerDutyNotifier(Config.PdAlertRoutingKey, pdAlertServiceID))
+	}
+	return notifiers, nil
+}
+
+// notifyReportResult raises an alert on every configured notifier when the report failed,
+// and resolves any open alert when it succeeded. Notification errors are only logged so
+// they never hide the report result.
+func (pd *pagerDutyClient) notifyReportResult(notifiers []notification.Notifier, reportErr error) {
+	if len(notifiers) == 0 {
+		return
+	}
+
+	var failure *notification.Failure
+	if reportErr != nil {
+		scheduleIDs := make([]string, 0, len(pd.reportSchedules))
+		for _, schedule := range pd.reportSchedules {
+			scheduleIDs = append(scheduleIDs, schedule.id)
+		}
+		if len(scheduleIDs) == 0 {
+			scheduleIDs = rawSchedules
+		}
+
+		failure = &notification.Failure{
+			Err:         reportErr,
+			ScheduleIDs: scheduleIDs,
+			PeriodLabel: periodLabel,
+		}
+		// the period is unknown when the run failed before the schedules were processed
+		if len(pd.reportSchedules) > 0 {
+			failure.Start, failure.End = reportTimeRange(pd.reportSchedules)
+		}
+	}
+
+	for _, notifier := range notifiers {
+		var err error
+		if failure != nil {
+			err = notifier.NotifyFailure(failure)
+		} else {
+			err = notifier.NotifySuccess()
+		}
+		if err != nil {
+			log.Println("Error sending report notification:", err)
+		}
+	}
+}
+
+// withStatusPageMaintenance runs the report inside a Statuspage maintenance window when
+// Statuspage is configured. Failing to open or close the window does not fail the report.
+func withStatusPageMaintenance(run func() error) error {
+	if statusPageAPIKey == "" || statusPagePageID == "" {
+		return run()
+	}
+
+	maintenance := notification.NewStatusPageMaintenance(statusPageAPIKey, statusPagePageID)
+	if err := maintenance.Start(statusPageExpectedDuration); err != nil {
+		log.Println("Error opening the Statuspage maintenance window:", err)
+	}
+
+	err := run()
+
+	if completeErr := maintenance.Complete(); completeErr != nil {
+		log.Println("Error closing the Statuspage maintenance window:", completeErr)
+	}
+	return err
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const (
+	onCallFrequencyColumn = "on_call_frequency"
+	rotationRateColumn    = "rotation_rate"
+
+	// onCallWeeksValue keeps the weeks each user was on call in a schedule, which is not a column,
+	// so the summary doesn't count twice the weeks the user was on call in several schedules.
+	onCallWeeksValue = "on_call_weeks"
+)
+
+var includeOnCallFrequency bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includeOnCallFrequency, "include-on-call-frequency", false, "add an on_call_frequency column with the number of calendar weeks each user was on call, and a rotation_rate column with the percentage of the weeks in the period")
+}
+
+// onCallWeeks returns the Mondays, in the location, of the weeks with on-call time in any of the periods.
+func onCallWeeks(periods []*api.UserRotaPeriod, location *time.Location) []time.Time {
+	weeks := make(map[time.Time]bool)
+	for _, period := range periods {
+		if !period.End.After(period.Start) {
+			continue
+		}
+		for _, week := range weeksBetween(period.Start, period.End, location) {
+			weeks[week] = true
+		}
+	}
+
+	sorted := make([]time.Time, 0, len(weeks))
+	for week := range weeks {
+		sorted = append(sorted, week)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+	return sorted
+}
+
+// weeksBetween returns the Mondays, in the location, of the weeks between start, included, and end, excluded.
+func weeksBetween(start, end time.Time, location *time.Location) []time.Time {
+	if location == nil {
+		location = time.UTC
+	}
+	var weeks []time.Time
+	last := weekStart(end.Add(-time.Nanosecond), location)
+	for week := weekStart(start, location); !week.After(last); week = week.AddDate(0, 0, 7) {
+		weeks = append(weeks, week)
+	}
+	return weeks
+}
+
+// weekStart returns the Monday of the week of the time in the location, as midnight UTC so weeks can be compared.
+func weekStart(t time.Time, location *time.Location) time.Time {
+	day := calendarDay(t, location)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+func formatWeeks(weeks []time.Time) string {
+	dates := make([]string, 0, len(weeks))
+	for _, week := range weeks {
+		dates = append(dates, week.Format("2006-01-02"))
+	}
+	return strings.Join(dates, ",")
+}
+
+// setOnCallFrequency sets the weeks the user was on call out of the ones in the period.
+func setOnCallFrequency(user *report.ScheduleUser, weeks []time.Time, periodWeeks int) {
+	setExtraValue(user, onCallWeeksValue, formatWeeks(weeks))
+	setExtraValue(user, onCallFrequencyColumn, fmt.Sprint(len(weeks)))
+	rate := 0.0
+	if periodWeeks > 0 {
+		rate = float64(len(weeks)) / float64(periodWeeks) * 100
+	}
+	setExtraValue(user, rotationRateColumn, fmt.Sprintf("%.1f%%", rate))
+}
+
+// setSummaryOnCallFrequencies sets the on-call weeks of the users in the summary, the weeks they were on call in
+// any schedule out of the weeks of the report period.
+func setSummaryOnCallFrequencies(data *report.PrintableData) error {
+	weeksByUser := make(map[string]map[time.Time]bool)
+	for _, scheduleData := range data.SchedulesData {
+		for _, user := range scheduleData.RotaUsers {
+			if weeksByUser[user.Name] == nil {
+				weeksByUser[user.Name] = make(map[time.Time]bool)
+			}
+			value := user.ExtraValues[onCallWeeksValue]
+			if value == "" {
+				continue
+			}
+			for _, date := range strings.Split(value, ",") {
+				week, err := time.Parse("2006-01-02", date)
+				if err != nil {
+					return fmt.Errorf("invalid on-call week for %s in schedule %s: %w", user.Name, scheduleData.ID, err)
+				}
+				weeksByUser[user.Name][week] = true
+			}
+		}
+	}
+
+	periodWeeks := len(weeksBetween(data.Start, data.End, time.UTC))
+	for _, user := range data.UsersSchedulesSummary {
+		weeks := make([]time.Time, 0, len(weeksByUser[user.Name]))
+		for week := range weeksByUser[user.Name] {
+			weeks = append(weeks, week)
+		}
+		sort.Slice(weeks, func(i, j int) bool { return weeks[i].Before(weeks[j]) })
+		setOnCallFrequency(user, weeks, periodWeeks)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_onCallWeeks(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	require.NoError(t, err)
+	// 3 July 2023 is a Monday
+	utc := func(d, h int) time.Time { return time.Date(2023, 7, d, h, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name     string
+		periods  []*api.UserRotaPeriod
+		location *time.Location
+		want     string
+	}{
+		{name: "no periods", want: ""},
+		{
+			name: "several periods in a week",
+			periods: []*api.UserRotaPeriod{
+				{Start: utc(3, 8), End: utc(3, 20)},
+				{Start: utc(7, 8), End: utc(7, 20)},
+			},
+			want: "2023-07-03",
+		},
+		{
+			name: "week without on-call time",
+			periods: []*api.UserRotaPeriod{
+				{Start: utc(8, 8), End: utc(10, 0)},
+				{Start: utc(20, 8), End: utc(20, 20)},
+			},
+			want: "2023-07-03,2023-07-17",
+		},
+		{
+			name:     "weeks of the schedule time zone",
+			periods:  []*api.UserRotaPeriod{{Start: utc(9, 20), End: utc(9, 22)}},
+			location: sydney,
+			want:     "2023-07-10",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatWeeks(onCallWeeks(tt.periods, tt.location)))
+		})
+	}
+}
+
+func Test_setSummaryOnCallFrequencies(t *testing.T) {
+	user := func(weeks string) *report.ScheduleUser {
+		return &report.ScheduleUser{Name: "John Doe", ExtraValues: map[string]string{onCallWeeksValue: weeks}}
+	}
+	summary := &report.ScheduleUser{Name: "John Doe"}
+	data := &report.PrintableData{
+		Start: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC),
+		SchedulesData: []*report.ScheduleData{
+			{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{user("2023-07-03,2023-07-17")}},
+			{ID: "SCHED_2", RotaUsers: []*report.ScheduleUser{user("2023-07-17,2023-07-24")}},
+		},
+		UsersSchedulesSummary: []*report.ScheduleUser{summary},
+	}
+
+	require.NoError(t, setSummaryOnCallFrequencies(data))
+	assert.Equal(t, "3", summary.ExtraValues[onCallFrequencyColumn])
+	// July 2023 spans 6 weeks, from the one of Monday 26 June to the one of Monday 31 July
+	assert.Equal(t, "50.0%", summary.ExtraValues[rotationRateColumn])
+}
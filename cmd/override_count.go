@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const (
+	overrideCountColumn = "override_count"
+	overrideHoursColumn = "override_hours"
+)
+
+var includeOverrideCount bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includeOverrideCount, "include-override-count", false, "add the number of overrides of each user and the hours on call through them")
+}
+
+type overrideUsage struct {
+	count int
+	hours float32
+}
+
+// setOverrideCounts sets how many times each user of the schedule was on call through an override,
+// rather than the base rotation, and for how long. Overrides of the user already on call are left out.
+func setOverrideCounts(scheduleData *report.ScheduleData, scheduleInfo *api.ScheduleInfo) error {
+	usageByUser := make(map[string]overrideUsage)
+	for _, entry := range scheduleInfo.OverrideSubschedule.RenderedScheduleEntries {
+		start, err := time.ParseInLocation(time.RFC3339, entry.Start, scheduleInfo.Location)
+		if err != nil {
+			return fmt.Errorf("failed to load the overrides of schedule %s: %w", scheduleInfo.ID, err)
+		}
+		end, err := time.ParseInLocation(time.RFC3339, entry.End, scheduleInfo.Location)
+		if err != nil {
+			return fmt.Errorf("failed to load the overrides of schedule %s: %w", scheduleInfo.ID, err)
+		}
+
+		covered, err := layeredUserAt(scheduleInfo.ScheduleLayers, start, scheduleInfo.Location)
+		if err != nil {
+			return fmt.Errorf("failed to load the overrides of schedule %s: %w", scheduleInfo.ID, err)
+		}
+		if covered != nil && covered.ID == entry.User.ID {
+			continue
+		}
+
+		usage := usageByUser[entry.User.Summary]
+		usage.count++
+		usage.hours += intervalHours(start, end)
+		usageByUser[entry.User.Summary] = usage
+	}
+
+	for _, user := range scheduleData.RotaUsers {
+		usage := usageByUser[user.Name]
+		setExtraValue(user, overrideCountColumn, strconv.Itoa(usage.count))
+		setExtraValue(user, overrideHoursColumn, fmt.Sprintf("%.2f", usage.hours))
+	}
+	return nil
+}
+
+// setSummaryOverrideCounts sets the overrides of the users in the summary, adding up the ones of each schedule.
+func setSummaryOverrideCounts(data *report.PrintableData) error {
+	usageByUser := make(map[string]overrideUsage)
+	for _, scheduleData := range data.SchedulesData {
+		for _, user := range scheduleData.RotaUsers {
+			count, err := strconv.Atoi(user.ExtraValues[overrideCountColumn])
+			if err != nil {
+				return fmt.Errorf("invalid override count for %s in schedule %s: %w", user.Name, scheduleData.ID, err)
+			}
+			hours, err := strconv.ParseFloat(user.ExtraValues[overrideHoursColumn], 32)
+			if err != nil {
+				return fmt.Errorf("invalid override hours for %s in schedule %s: %w", user.Name, scheduleData.ID, err)
+			}
+			usage := usageByUser[user.Name]
+			usage.count += count
+			usage.hours += float32(hours)
+			usageByUser[user.Name] = usage
+		}
+	}
+	for _, user := range data.UsersSchedulesSummary {
+		usage := usageByUser[user.Name]
+		setExtraValue(user, overrideCountColumn, strconv.Itoa(usage.count))
+		setExtraValue(user, overrideHoursColumn, fmt.Sprintf("%.2f", usage.hours))
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_setOverrideCounts(t *testing.T) {
+	john := api.User{ID: "USER_1", Summary: "John Doe"}
+	mary := api.User{ID: "USER_2", Summary: "Mary Jane"}
+	scheduleInfo := &api.ScheduleInfo{
+		ID:       "SCHED_1",
+		Location: time.UTC,
+		ScheduleLayers: []api.ScheduleLayer{
+			{
+				RenderedScheduleEntries: []api.RenderedScheduleEntry{
+					{Start: "2022-08-01T00:00:00Z", End: "2022-08-08T00:00:00Z", User: john},
+					{Start: "2022-08-08T00:00:00Z", End: "2022-08-15T00:00:00Z", User: mary},
+				},
+			},
+		},
+		OverrideSubschedule: api.ScheduleLayer{
+			RenderedScheduleEntries: []api.RenderedScheduleEntry{
+				{Start: "2022-08-02T00:00:00Z", End: "2022-08-02T12:00:00Z", User: mary},
+				{Start: "2022-08-03T00:00:00Z", End: "2022-08-04T00:00:00Z", User: mary},
+				{Start: "2022-08-09T00:00:00Z", End: "2022-08-09T06:30:00Z", User: mary}, // already on call
+				{Start: "2022-08-10T00:00:00Z", End: "2022-08-10T06:30:00Z", User: john},
+			},
+		},
+	}
+	scheduleData := &report.ScheduleData{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{
+		{Name: "John Doe"}, {Name: "Mary Jane"},
+	}}
+
+	require.NoError(t, setOverrideCounts(scheduleData, scheduleInfo))
+
+	assert.Equal(t, "1", scheduleData.RotaUsers[0].ExtraValues[overrideCountColumn])
+	assert.Equal(t, "6.50", scheduleData.RotaUsers[0].ExtraValues[overrideHoursColumn])
+	assert.Equal(t, "2", scheduleData.RotaUsers[1].ExtraValues[overrideCountColumn])
+	assert.Equal(t, "36.00", scheduleData.RotaUsers[1].ExtraValues[overrideHoursColumn])
+
+	data := &report.PrintableData{
+		SchedulesData:         []*report.ScheduleData{scheduleData, scheduleData},
+		UsersSchedulesSummary: []*report.ScheduleUser{{Name: "John Doe"}, {Name: "Mary Jane"}},
+	}
+	require.NoError(t, setSummaryOverrideCounts(data))
+	assert.Equal(t, "2", data.UsersSchedulesSummary[0].ExtraValues[overrideCountColumn])
+	assert.Equal(t, "13.00", data.UsersSchedulesSummary[0].ExtraValues[overrideHoursColumn])
+	assert.Equal(t, "4", data.UsersSchedulesSummary[1].ExtraValues[overrideCountColumn])
+	assert.Equal(t, "72.00", data.UsersSchedulesSummary[1].ExtraValues[overrideHoursColumn])
+}
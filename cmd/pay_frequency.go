@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const (
+	payFrequencyColumn = "pay_frequency"
+	// unsetPayFrequency is the pay frequency of the schedules without one configured.
+	unsetPayFrequency = "unset"
+)
+
+var (
+	includePayFrequencyColumn bool
+
+	paymentFrequencies = []string{"weekly", "bi-weekly", "monthly"}
+)
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includePayFrequencyColumn, "include-pay-frequency-column", false, "add a pay_frequency column with the paymentFrequency configured for each schedule, warning about the schedules without one")
+}
+
+// payFrequency returns the payment frequency configured for the schedule, or unset.
+func payFrequency(scheduleID string) string {
+	settings := Config.FindScheduleSettingsByID(scheduleID)
+	if settings == nil || settings.PaymentFrequency == "" {
+		return unsetPayFrequency
+	}
+	return settings.PaymentFrequency
+}
+
+// setPayFrequencies sets the payment frequency of the users of each schedule and the frequencies of the
+// schedules of each user in the summary.
+func setPayFrequencies(data *report.PrintableData) {
+	frequenciesByUser := make(map[string][]string)
+	for _, scheduleData := range data.SchedulesData {
+		frequency := payFrequency(scheduleData.ID)
+		if frequency == unsetPayFrequency {
+			log.Printf("WARNING: schedule '%s' (%s) has no paymentFrequency configured", scheduleData.Name, scheduleData.ID)
+		}
+		for _, user := range scheduleData.RotaUsers {
+			setExtraValue(user, payFrequencyColumn, frequency)
+			if !contains(frequenciesByUser[user.Name], frequency) {
+				frequenciesByUser[user.Name] = append(frequenciesByUser[user.Name], frequency)
+			}
+		}
+	}
+	for _, user := range data.UsersSchedulesSummary {
+		frequencies := frequenciesByUser[user.Name]
+		sort.Strings(frequencies)
+		setExtraValue(user, payFrequencyColumn, strings.Join(frequencies, ", "))
+	}
+}
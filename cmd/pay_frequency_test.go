@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_setPayFrequencies(t *testing.T) {
+	setConfig(t, configuration.New())
+	Config.ScheduleSettings = []configuration.ScheduleSettings{
+		{Id: "SCHED_1", PaymentFrequency: "weekly"},
+		{Id: "SCHED_2", PaymentFrequency: "monthly"},
+	}
+	john := func() *report.ScheduleUser { return &report.ScheduleUser{Name: "John Doe"} }
+	data := &report.PrintableData{
+		SchedulesData: []*report.ScheduleData{
+			{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{john()}},
+			{ID: "SCHED_2", RotaUsers: []*report.ScheduleUser{john(), {Name: "Mary Jane"}}},
+			{ID: "SCHED_3", RotaUsers: []*report.ScheduleUser{john()}},
+		},
+		UsersSchedulesSummary: []*report.ScheduleUser{john(), {Name: "Mary Jane"}},
+	}
+
+	setPayFrequencies(data)
+
+	assert.Equal(t, "weekly", data.SchedulesData[0].RotaUsers[0].ExtraValues[payFrequencyColumn])
+	assert.Equal(t, "monthly", data.SchedulesData[1].RotaUsers[1].ExtraValues[payFrequencyColumn])
+	assert.Equal(t, "unset", data.SchedulesData[2].RotaUsers[0].ExtraValues[payFrequencyColumn])
+	assert.Equal(t, "monthly, unset, weekly", data.UsersSchedulesSummary[0].ExtraValues[payFrequencyColumn])
+	assert.Equal(t, "monthly", data.UsersSchedulesSummary[1].ExtraValues[payFrequencyColumn])
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const (
+	payTagColumn = "pay_tag"
+	payTagHigh   = "HIGH"
+	payTagNormal = "NORMAL"
+	payTagLow    = "LOW"
+)
+
+var (
+	tagHighAbove float32
+	tagLowBelow  float32
+)
+
+func init() {
+	scheduleReportCmd.Flags().Float32Var(&tagHighAbove, "tag-high-above", 0, "add a pay_tag column tagging the amounts above this one as HIGH, unless configured for the schedule (0 to disable)")
+	scheduleReportCmd.Flags().Float32Var(&tagLowBelow, "tag-low-below", 0, "add a pay_tag column tagging the amounts below this one as LOW, unless configured for the schedule (0 to disable)")
+}
+
+// payTag returns whether the amount is high, low or normal. A zero threshold disables the corresponding tag.
+func payTag(amount, highAbove, lowBelow float32) string {
+	if highAbove > 0 && amount > highAbove {
+		return payTagHigh
+	}
+	if lowBelow > 0 && amount < lowBelow {
+		return payTagLow
+	}
+	return payTagNormal
+}
+
+// payTagsEnabled returns whether any pay tag threshold is set, globally or for a schedule.
+func payTagsEnabled() bool {
+	if tagHighAbove > 0 || tagLowBelow > 0 {
+		return true
+	}
+	for _, settings := range Config.ScheduleSettings {
+		if settings.TagHighAbove > 0 || settings.TagLowBelow > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// schedulePayTagThresholds returns the pay tag thresholds configured for the schedule, falling back to the
+// command line ones.
+func schedulePayTagThresholds(scheduleID string) (float32, float32) {
+	highAbove, lowBelow := tagHighAbove, tagLowBelow
+	if settings := Config.FindScheduleSettingsByID(scheduleID); settings != nil {
+		if settings.TagHighAbove > 0 {
+			highAbove = settings.TagHighAbove
+		}
+		if settings.TagLowBelow > 0 {
+			lowBelow = settings.TagLowBelow
+		}
+	}
+	return highAbove, lowBelow
+}
+
+// setPayTags tags the amount of the users of each schedule with the schedule thresholds, and of the users in
+// the summary with the command line ones.
+func setPayTags(data *report.PrintableData) {
+	for _, scheduleData := range data.SchedulesData {
+		highAbove, lowBelow := schedulePayTagThresholds(scheduleData.ID)
+		for _, user := range scheduleData.RotaUsers {
+			setExtraValue(user, payTagColumn, payTag(user.TotalAmount, highAbove, lowBelow))
+		}
+	}
+	for _, user := range data.UsersSchedulesSummary {
+		setExtraValue(user, payTagColumn, payTag(user.TotalAmount, tagHighAbove, tagLowBelow))
+	}
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_payTag(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    float32
+		highAbove float32
+		lowBelow  float32
+		want      string
+	}{
+		{name: "high", amount: 600, highAbove: 500, lowBelow: 50, want: payTagHigh},
+		{name: "normal", amount: 500, highAbove: 500, lowBelow: 50, want: payTagNormal},
+		{name: "low", amount: 10, highAbove: 500, lowBelow: 50, want: payTagLow},
+		{name: "thresholds disabled", amount: 10, want: payTagNormal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, payTag(tt.amount, tt.highAbove, tt.lowBelow))
+		})
+	}
+}
+
+func Test_setPayTags(t *testing.T) {
+	defer func() { tagHighAbove, tagLowBelow = 0, 0 }()
+	setConfig(t, configuration.New())
+	Config.ScheduleSettings = []configuration.ScheduleSettings{{Id: "SCHED_2", TagHighAbove: 100}}
+	tagHighAbove, tagLowBelow = 500, 50
+
+	scheduleUser := &report.ScheduleUser{Name: "John Doe", TotalAmount: 200}
+	otherScheduleUser := &report.ScheduleUser{Name: "John Doe", TotalAmount: 200}
+	summaryUser := &report.ScheduleUser{Name: "John Doe", TotalAmount: 400}
+	data := &report.PrintableData{
+		SchedulesData: []*report.ScheduleData{
+			{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{scheduleUser}},
+			{ID: "SCHED_2", RotaUsers: []*report.ScheduleUser{otherScheduleUser}},
+		},
+		UsersSchedulesSummary: []*report.ScheduleUser{summaryUser},
+	}
+
+	assert.True(t, payTagsEnabled())
+	setPayTags(data)
+
+	assert.Equal(t, payTagNormal, scheduleUser.ExtraValues[payTagColumn])
+	assert.Equal(t, payTagHigh, otherScheduleUser.ExtraValues[payTagColumn])
+	assert.Equal(t, payTagNormal, summaryUser.ExtraValues[payTagColumn])
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+// paymentRejectedExitCode is the exit code when the amounts aren't approved, so scripts can tell a rejection from a failure.
+const paymentRejectedExitCode = 7
+
+var errPaymentRejected = errors.New("payment not approved, no report written")
+
+var paymentApprovalRequired bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&paymentApprovalRequired, "payment-approval-required", false, "show the amount of each user and ask for approval before writing the report, exiting with code 7 if not approved")
+}
+
+// approvePayment shows the amount to pay each user and the grand total, and asks whether to approve them.
+// Anything but yes is a rejection.
+func approvePayment(in io.Reader, out io.Writer, data *report.PrintableData) (bool, error) {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "User\tTotal amount\t\n")
+	for _, user := range data.UsersSchedulesSummary {
+		fmt.Fprintf(tw, "%s\t%s\t\n", user.Name, formatCurrencyAmount(user.TotalAmount))
+	}
+	fmt.Fprintf(tw, "Grand total\t%s\t\n", formatCurrencyAmount(grandTotalAmount(data)))
+	if err := tw.Flush(); err != nil {
+		return false, err
+	}
+
+	fmt.Fprint(out, "Approve? [y/N] ")
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read the approval: %w", err)
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
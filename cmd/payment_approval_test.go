@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_approvePayment(t *testing.T) {
+	setConfig(t, configuration.New())
+	Config.RotationPrices.Currency = "£"
+	data := &report.PrintableData{
+		UsersSchedulesSummary: []*report.ScheduleUser{
+			{Name: "John Doe", TotalAmount: 700},
+			{Name: "Mary Jane", TotalAmount: 500},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "yes", input: "y\n", want: true},
+		{name: "yes in full and upper case", input: "YES\n", want: true},
+		{name: "no", input: "N\n", want: false},
+		{name: "empty answer", input: "\n", want: false},
+		{name: "no input", input: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			approved, err := approvePayment(strings.NewReader(tt.input), &out, data)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, approved)
+			require.Contains(t, out.String(), "John Doe")
+			require.Contains(t, out.String(), "£700.00")
+			require.Contains(t, out.String(), "£1200.00")
+			require.Contains(t, out.String(), "Approve? [y/N]")
+		})
+	}
+}
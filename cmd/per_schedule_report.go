@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+var perScheduleReport bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&perScheduleReport, "per-schedule-report", false, "also write a standalone report per schedule, in a directory named after the schedule ID, listed in a manifest json file")
+}
+
+type scheduleReportManifest struct {
+	Start     string                        `json:"start"`
+	End       string                        `json:"end"`
+	Schedules []scheduleReportManifestEntry `json:"schedules"`
+}
+
+type scheduleReportManifestEntry struct {
+	ID    string   `json:"id"`
+	Name  string   `json:"name"`
+	Files []string `json:"files"`
+}
+
+// scheduleReportData returns the report data of a single schedule, its users being the summary.
+func scheduleReportData(data *report.PrintableData, scheduleData *report.ScheduleData) *report.PrintableData {
+	scheduleReport := *data
+	scheduleReport.SchedulesData = []*report.ScheduleData{scheduleData}
+	scheduleReport.UsersSchedulesSummary = make([]*report.ScheduleUser, 0, len(scheduleData.RotaUsers))
+	for _, user := range scheduleData.RotaUsers {
+		userCopy := *user
+		scheduleReport.UsersSchedulesSummary = append(scheduleReport.UsersSchedulesSummary, &userCopy)
+	}
+	return &scheduleReport
+}
+
+// writePerScheduleReports writes the report of each schedule in every format to its own directory under the
+// output one, and a manifest listing the files written.
+func writePerScheduleReports(data *report.PrintableData, formats []string) error {
+	outputDirectory := directory
+	defer func() { directory = outputDirectory }()
+
+	manifest := scheduleReportManifest{
+		Start:     data.Start.Format("2006-01-02"),
+		End:       data.End.Format("2006-01-02"),
+		Schedules: make([]scheduleReportManifestEntry, 0, len(data.SchedulesData)),
+	}
+	for _, scheduleData := range data.SchedulesData {
+		scheduleDirectory := filepath.Join(outputDirectory, report.SanitizeFileName(scheduleData.ID))
+		if err := os.MkdirAll(scheduleDirectory, 0o755); err != nil {
+			return fmt.Errorf("failed to create the report directory of schedule %s: %w", scheduleData.ID, err)
+		}
+
+		directory = scheduleDirectory
+		files, err := writeReports(scheduleReportData(data, scheduleData), formats)
+		if err != nil {
+			return fmt.Errorf("failed to write the report of schedule %s: %w", scheduleData.ID, err)
+		}
+		sort.Strings(files)
+		manifest.Schedules = append(manifest.Schedules, scheduleReportManifestEntry{ID: scheduleData.ID, Name: scheduleData.Name, Files: files})
+	}
+
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	filename := filepath.Join(outputDirectory, fmt.Sprintf("pagerduty_oncall_report.%d-%d-manifest.json", data.Start.Month(), data.Start.Year()))
+	if err := os.WriteFile(filename, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write the report manifest: %w", err)
+	}
+	log.Printf("Report manifest successfully generated: file://%s", filename)
+	return nil
+}
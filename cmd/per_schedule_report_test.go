@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_writePerScheduleReports(t *testing.T) {
+	defer func() { directory = "" }()
+	setConfig(t, configuration.New())
+	Config.RotationPrices.Currency = "£"
+	outputDirectory := t.TempDir()
+	directory = outputDirectory
+
+	data := &report.PrintableData{
+		Start: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+		SchedulesData: []*report.ScheduleData{
+			{ID: "SCHED_1", Name: "Primary", RotaUsers: []*report.ScheduleUser{{Name: "John Doe", TotalAmount: 100}}},
+			{ID: "SCHED 2", Name: "Secondary", RotaUsers: []*report.ScheduleUser{{Name: "Mary Jane", TotalAmount: 50}}},
+		},
+		UsersSchedulesSummary: []*report.ScheduleUser{{Name: "John Doe", TotalAmount: 100}, {Name: "Mary Jane", TotalAmount: 50}},
+	}
+
+	// files of a previous run aren't listed
+	require.NoError(t, os.MkdirAll(filepath.Join(outputDirectory, "SCHED_2"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(outputDirectory, "SCHED_2", "pagerduty_oncall_report.12-2020-Summary.csv"), nil, 0o644))
+
+	require.NoError(t, writePerScheduleReports(data, []string{"console", "csv"}))
+	assert.Equal(t, outputDirectory, directory)
+
+	content, err := os.ReadFile(filepath.Join(outputDirectory, "pagerduty_oncall_report.1-2021-manifest.json"))
+	require.NoError(t, err)
+	var manifest scheduleReportManifest
+	require.NoError(t, json.Unmarshal(content, &manifest))
+	require.Len(t, manifest.Schedules, 2)
+	assert.Equal(t, "SCHED 2", manifest.Schedules[1].ID)
+	assert.Equal(t, []string{
+		filepath.Join(outputDirectory, "SCHED_2", "pagerduty_oncall_report.1-2021-Secondary-SCHED 2.csv"),
+		filepath.Join(outputDirectory, "SCHED_2", "pagerduty_oncall_report.1-2021-Summary.csv"),
+	}, manifest.Schedules[1].Files)
+
+	summary, err := os.ReadFile(filepath.Join(outputDirectory, "SCHED_1", "pagerduty_oncall_report.1-2021-Summary.csv"))
+	require.NoError(t, err)
+	assert.Contains(t, string(summary), "John Doe")
+	assert.NotContains(t, string(summary), "Mary Jane")
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	periodCurrentMonth      = "current-month"
+	periodLastMonth         = "last-month"
+	periodYearToDate        = "ytd"
+	periodCurrentQuarter    = "current-quarter"
+	periodLastQuarter       = "last-quarter"
+	periodCurrentFiscalYear = "current-fiscal-year"
+	periodLastFiscalYear    = "last-fiscal-year"
+)
+
+var (
+	period          string
+	fiscalYearStart int
+	monthStartDay   int
+)
+
+func init() {
+	scheduleReportCmd.Flags().StringVar(&period, "period", "", "report period instead of reportTimeRange: current-month, last-month, ytd, current-quarter, last-quarter, current-fiscal-year, last-fiscal-year")
+	scheduleReportCmd.Flags().IntVar(&fiscalYearStart, "fiscal-year-start", 1, "month the fiscal year starts in (1-12), for the ytd, quarter and fiscal year periods")
+	scheduleReportCmd.Flags().IntVar(&monthStartDay, "month-start-day", 1, "day the month starts on (1-28), for the current-month and last-month periods, e.g. 15 for months from the 15th to the 14th")
+}
+
+// periodRange returns the start and end dates of the period preset at the given time. Quarters and years
+// follow the fiscal year, months start on monthStartDay, and the periods to date end at the start of the current day.
+func periodRange(preset string, fiscalYearStartMonth, monthStartDay int, now time.Time) (time.Time, time.Time, error) {
+	if fiscalYearStartMonth < 1 || fiscalYearStartMonth > 12 {
+		return time.Time{}, time.Time{}, fmt.Errorf("fiscal year start %d must be a month between 1 and 12", fiscalYearStartMonth)
+	}
+	// later days don't exist in every month
+	if monthStartDay < 1 || monthStartDay > 28 {
+		return time.Time{}, time.Time{}, fmt.Errorf("month start day %d must be a day between 1 and 28", monthStartDay)
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	currentMonth := time.Date(now.Year(), now.Month(), monthStartDay, 0, 0, 0, 0, time.UTC)
+	if now.Day() < monthStartDay {
+		currentMonth = currentMonth.AddDate(0, -1, 0)
+	}
+
+	// months elapsed since the fiscal year started, 0 to 11
+	monthsIntoYear := (int(now.Month()) - fiscalYearStartMonth + 12) % 12
+	fiscalYear := thisMonth.AddDate(0, -monthsIntoYear, 0)
+	quarter := thisMonth.AddDate(0, -(monthsIntoYear % 3), 0)
+
+	switch preset {
+	case periodCurrentMonth:
+		return currentMonth, currentMonth.AddDate(0, 1, 0), nil
+	case periodLastMonth:
+		return currentMonth.AddDate(0, -1, 0), currentMonth, nil
+	case periodYearToDate:
+		return fiscalYear, today, nil
+	case periodCurrentQuarter:
+		return quarter, quarter.AddDate(0, 3, 0), nil
+	case periodLastQuarter:
+		return quarter.AddDate(0, -3, 0), quarter, nil
+	case periodCurrentFiscalYear:
+		return fiscalYear, fiscalYear.AddDate(1, 0, 0), nil
+	case periodLastFiscalYear:
+		return fiscalYear.AddDate(-1, 0, 0), fiscalYear, nil
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("period %s not supported, use %s, %s, %s, %s, %s, %s or %s", preset,
+			periodCurrentMonth, periodLastMonth, periodYearToDate, periodCurrentQuarter, periodLastQuarter, periodCurrentFiscalYear, periodLastFiscalYear)
+	}
+}
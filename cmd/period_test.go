@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_periodRange(t *testing.T) {
+	now := time.Date(2024, time.May, 15, 10, 30, 0, 0, time.UTC)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name            string
+		preset          string
+		fiscalYearStart int
+		monthStartDay   int
+		wantStart       time.Time
+		wantEnd         time.Time
+		wantErr         bool
+	}{
+		{name: "last month", preset: "last-month", fiscalYearStart: 4, wantStart: date(2024, time.April, 1), wantEnd: date(2024, time.May, 1)},
+		{name: "current month", preset: "current-month", fiscalYearStart: 1, wantStart: date(2024, time.May, 1), wantEnd: date(2024, time.June, 1)},
+		{name: "last month starting on the 15th", preset: "last-month", fiscalYearStart: 1, monthStartDay: 15, wantStart: date(2024, time.April, 15), wantEnd: date(2024, time.May, 15)},
+		{name: "current month starting on the 20th", preset: "current-month", fiscalYearStart: 1, monthStartDay: 20, wantStart: date(2024, time.April, 20), wantEnd: date(2024, time.May, 20)},
+		{name: "ytd of calendar year", preset: "ytd", fiscalYearStart: 1, wantStart: date(2024, time.January, 1), wantEnd: date(2024, time.May, 15)},
+		{name: "ytd of fiscal year", preset: "ytd", fiscalYearStart: 4, wantStart: date(2024, time.April, 1), wantEnd: date(2024, time.May, 15)},
+		{name: "ytd of fiscal year started last year", preset: "ytd", fiscalYearStart: 7, wantStart: date(2023, time.July, 1), wantEnd: date(2024, time.May, 15)},
+		{name: "current calendar quarter", preset: "current-quarter", fiscalYearStart: 1, wantStart: date(2024, time.April, 1), wantEnd: date(2024, time.July, 1)},
+		{name: "current fiscal quarter", preset: "current-quarter", fiscalYearStart: 2, wantStart: date(2024, time.May, 1), wantEnd: date(2024, time.August, 1)},
+		{name: "last fiscal quarter", preset: "last-quarter", fiscalYearStart: 2, wantStart: date(2024, time.February, 1), wantEnd: date(2024, time.May, 1)},
+		{name: "current fiscal year", preset: "current-fiscal-year", fiscalYearStart: 4, wantStart: date(2024, time.April, 1), wantEnd: date(2025, time.April, 1)},
+		{name: "last fiscal year", preset: "last-fiscal-year", fiscalYearStart: 4, wantStart: date(2023, time.April, 1), wantEnd: date(2024, time.April, 1)},
+		{name: "unknown preset", preset: "next-month", fiscalYearStart: 1, wantErr: true},
+		{name: "invalid fiscal year start", preset: "ytd", fiscalYearStart: 13, wantErr: true},
+		{name: "invalid month start day", preset: "last-month", fiscalYearStart: 1, monthStartDay: 31, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monthStartDay := tt.monthStartDay
+			if monthStartDay == 0 {
+				monthStartDay = 1
+			}
+			start, end, err := periodRange(tt.preset, tt.fiscalYearStart, monthStartDay, now)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"time"
+)
+
+const memoryProfileSampleInterval = 100 * time.Millisecond
+
+var profileMemory bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&profileMemory, "profile-memory", false, "print heap statistics to stderr after the report generation")
+}
+
+// memoryProfiler samples the heap in the background to keep track of its peak usage,
+// as runtime.MemStats only reports the current values.
+type memoryProfiler struct {
+	mu       sync.Mutex
+	peakHeap uint64
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func startMemoryProfiler() *memoryProfiler {
+	p := &memoryProfiler{
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(p.done)
+		ticker := time.NewTicker(memoryProfileSampleInterval)
+		defer ticker.Stop()
+		for {
+			p.sample()
+			select {
+			case <-ticker.C:
+			case <-p.stop:
+				return
+			}
+		}
+	}()
+
+	return p
+}
+
+func (p *memoryProfiler) sample() runtime.MemStats {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if stats.HeapInuse > p.peakHeap {
+		p.peakHeap = stats.HeapInuse
+	}
+	return stats
+}
+
+// Stop stops sampling and writes the final heap statistics to the given writer.
+func (p *memoryProfiler) Stop(w io.Writer) {
+	close(p.stop)
+	<-p.done
+	stats := p.sample()
+
+	const memRowFormat = "| %-16s | %16v |"
+	fmt.Fprintln(w, " -------------------------------------")
+	fmt.Fprintln(w, fmt.Sprintf(memRowFormat, "MEMORY STAT", "VALUE"))
+	fmt.Fprintln(w, " -------------------------------------")
+	fmt.Fprintln(w, fmt.Sprintf(memRowFormat, "TotalAlloc", formatBytes(stats.TotalAlloc)))
+	fmt.Fprintln(w, fmt.Sprintf(memRowFormat, "HeapInuse", formatBytes(stats.HeapInuse)))
+	fmt.Fprintln(w, fmt.Sprintf(memRowFormat, "Peak HeapInuse", formatBytes(p.peakHeap)))
+	fmt.Fprintln(w, fmt.Sprintf(memRowFormat, "NumGC", stats.NumGC))
+	fmt.Fprintln(w, " -------------------------------------")
+}
+
+func formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
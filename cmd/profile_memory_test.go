@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_memoryProfiler(t *testing.T) {
+	profiler := startMemoryProfiler()
+
+	var output bytes.Buffer
+	profiler.Stop(&output)
+
+	assert.NotZero(t, profiler.peakHeap)
+	assert.Contains(t, output.String(), "TotalAlloc")
+	assert.Contains(t, output.String(), "HeapInuse")
+	assert.Contains(t, output.String(), "Peak HeapInuse")
+	assert.Contains(t, output.String(), "NumGC")
+}
+
+func Test_formatBytes(t *testing.T) {
+	tests := []struct {
+		input uint64
+		want  string
+	}{
+		{input: 512, want: "512 B"},
+		{input: 2048, want: "2.0 KiB"},
+		{input: 5 * 1024 * 1024, want: "5.0 MiB"},
+		{input: 3 * 1024 * 1024 * 1024, want: "3.0 GiB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatBytes(tt.input))
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/viper"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+)
+
+var rateContractFile string
+
+// rateContract holds the agreed daily rate ranges, per day type, of all the schedules and of
+// specific ones.
+type rateContract struct {
+	Rates     []contractRate
+	Schedules []scheduleContract
+}
+
+type scheduleContract struct {
+	Id    string
+	Rates []contractRate // overrides the contract rates for the day types present
+}
+
+type contractRate struct {
+	Day string
+	Min int
+	Max int // no upper bound if 0
+}
+
+func (r contractRate) String() string {
+	if r.Max == 0 {
+		return fmt.Sprintf("%d+", r.Min)
+	}
+	return fmt.Sprintf("%d-%d", r.Min, r.Max)
+}
+
+func init() {
+	scheduleReportCmd.Flags().StringVar(&rateContractFile, "test-rates-against-contract", "", "check that the configured rates of the report schedules are within the ranges of the given contract yaml file, then exit without generating the report")
+}
+
+func loadRateContract(path string) (*rateContract, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read the rate contract: %w", err)
+	}
+
+	contract := &rateContract{}
+	if err := v.Unmarshal(contract); err != nil {
+		return nil, fmt.Errorf("failed to read the rate contract: %w", err)
+	}
+	for _, rate := range contract.Rates {
+		if !contains(dayTypes, rate.Day) {
+			return nil, fmt.Errorf("rate contract: day %s not supported, use %v", rate.Day, dayTypes)
+		}
+	}
+	for _, schedule := range contract.Schedules {
+		for _, rate := range schedule.Rates {
+			if !contains(dayTypes, rate.Day) {
+				return nil, fmt.Errorf("rate contract: schedule %s: day %s not supported, use %v", schedule.Id, rate.Day, dayTypes)
+			}
+		}
+	}
+	return contract, nil
+}
+
+// findRate returns the agreed range of the day type on the schedule, if any.
+func (c *rateContract) findRate(scheduleID, dayType string) (contractRate, bool) {
+	for _, schedule := range c.Schedules {
+		if schedule.Id != scheduleID {
+			continue
+		}
+		for _, rate := range schedule.Rates {
+			if rate.Day == dayType {
+				return rate, true
+			}
+		}
+	}
+	for _, rate := range c.Rates {
+		if rate.Day == dayType {
+			return rate, true
+		}
+	}
+	return contractRate{}, false
+}
+
+// testRatesAgainstContract checks the rates of the report schedules against the contract file
+// without fetching any on-call entries.
+func (pd *pagerDutyClient) testRatesAgainstContract(w io.Writer) error {
+	contract, err := loadRateContract(rateContractFile)
+	if err != nil {
+		return err
+	}
+	schedules, err := pd.processArguments()
+	if err != nil {
+		return err
+	}
+	return checkRatesAgainstContract(w, contract, schedules)
+}
+
+// checkRatesAgainstContract prints the daily rates outside the agreed ranges, failing if there are any.
+func checkRatesAgainstContract(w io.Writer, contract *rateContract, schedules []Schedule) error {
+	users := append(Config.RotationUsers[:len(Config.RotationUsers):len(Config.RotationUsers)],
+		configuration.RotationUser{Name: otherUsersLabel})
+
+	violations := 0
+	for _, schedule := range schedules {
+		for _, user := range users {
+			for _, dayType := range dayTypes {
+				rate, ok := contract.findRate(schedule.id, dayType)
+				if !ok {
+					continue
+				}
+				price, source, err := Config.FindEffectivePriceByDay(dayType, schedule.id, user.UserID)
+				if err != nil {
+					return fmt.Errorf("failed to find the %s rate of schedule %s: %w", dayType, schedule.id, err)
+				}
+				if price < rate.Min || (rate.Max > 0 && price > rate.Max) {
+					violations++
+					fmt.Fprintf(w, "%s %s %s rate %d (%s) outside the agreed range %s\n",
+						schedule.id, user.Name, dayType, price, source, rate)
+				}
+			}
+		}
+	}
+
+	if violations > 0 {
+		return fmt.Errorf("%d rate(s) outside the contract %s", violations, rateContractFile)
+	}
+	fmt.Fprintln(w, "rates within the contract")
+	return nil
+}
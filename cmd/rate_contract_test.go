@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkRatesAgainstContract(t *testing.T) {
+	setConfig(t, configuration.New())
+	Config.RotationPrices = configuration.RotationPrices{
+		Currency: "£",
+		DaysInfo: []configuration.RotationPriceDay{
+			{Day: "weekday", Price: 24},
+			{Day: "weekend", Price: 48},
+			{Day: "bankholiday", Price: 72},
+		},
+	}
+	Config.RotationUsers = []configuration.RotationUser{
+		{UserID: "USER_1", Name: "John Doe", Prices: []configuration.RotationPriceDay{{Day: "weekend", Price: 96}}},
+	}
+
+	path := filepath.Join(t.TempDir(), "contract.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+rates:
+  - day: weekday
+    min: 20
+    max: 30
+  - day: weekend
+    min: 40
+    max: 60
+schedules:
+  - id: SCHED_2
+    rates:
+      - day: weekday
+        min: 30
+`), 0600))
+	contract, err := loadRateContract(path)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		schedules []Schedule
+		want      string
+		wantErr   string
+	}{
+		{
+			name:      "User override above the range",
+			schedules: []Schedule{{id: "SCHED_1"}},
+			want:      "SCHED_1 John Doe weekend rate 96 (user-override) outside the agreed range 40-60\n",
+			wantErr:   "1 rate(s) outside the contract",
+		},
+		{
+			name:      "Schedule range below the minimum",
+			schedules: []Schedule{{id: "SCHED_2"}},
+			want: "SCHED_2 John Doe weekday rate 24 (global-default) outside the agreed range 30+\n" +
+				"SCHED_2 John Doe weekend rate 96 (user-override) outside the agreed range 40-60\n" +
+				"SCHED_2 (other users) weekday rate 24 (global-default) outside the agreed range 30+\n",
+			wantErr: "3 rate(s) outside the contract",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := checkRatesAgainstContract(&out, contract, tt.schedules)
+
+			assert.Equal(t, tt.want, out.String())
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("Rates within the contract", func(t *testing.T) {
+		Config.RotationUsers = nil
+		var out bytes.Buffer
+		err := checkRatesAgainstContract(&out, contract, []Schedule{{id: "SCHED_1"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, "rates within the contract\n", out.String())
+	})
+}
+
+func Test_loadRateContract_unsupportedDay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contract.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("rates:\n  - day: holiday\n    min: 1\n"), 0600))
+
+	_, err := loadRateContract(path)
+	assert.ErrorContains(t, err, "day holiday not supported")
+}
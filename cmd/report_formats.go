@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"runtime"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+var (
+	parallelFormats bool
+	formatWorkers   int
+	perUserReport   bool
+	amountColumn    string
+	hoursColumn     string
+)
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&parallelFormats, "parallel-formats", false, "write the output formats concurrently")
+	scheduleReportCmd.Flags().IntVar(&formatWorkers, "format-workers", 0, "number of formats written at the same time with --parallel-formats (0 uses the number of CPUs)")
+	scheduleReportCmd.Flags().BoolVar(&perUserReport, "per-user-report", false, "also write a csv file per user, named after their email address, with their rows of every schedule and their total")
+	scheduleReportCmd.Flags().StringVar(&amountColumn, "amount-column-name", "", "header of the total amount column of the csv output, e.g. GROSS_PAY_GBP for a payroll import (overrides output.amountColumnName)")
+	scheduleReportCmd.Flags().StringVar(&hoursColumn, "hours-column-name", "", "name of the hours columns of the csv output after the day type instead of Hours, e.g. ON_CALL_HOURS for \"Weekday ON_CALL_HOURS\" (overrides output.hoursColumnName)")
+}
+
+// supportedFormats returns the given output formats without duplicates, replacing the
+// unsupported ones with console.
+func supportedFormats(formats []string) []string {
+	result := make([]string, 0, len(formats))
+	for _, format := range formats {
+		if !contains([]string{"console", "pdf", "csv"}, format) {
+			log.Printf("output format %s not supported. Defaulting to 'console'", format)
+			format = "console"
+		}
+		if !contains(result, format) {
+			result = append(result, format)
+		}
+	}
+	if perUserReport && !contains(result, "csv") {
+		log.Printf("the per user reports are csv files, adding the 'csv' output format")
+		result = append(result, "csv")
+	}
+	return result
+}
+
+// parseOutputSeparator returns the field separator of the csv output, accepting "\t" as written in
+// the shell for tab-separated files.
+func parseOutputSeparator(separator string) (rune, error) {
+	if separator == `\t` {
+		return '\t', nil
+	}
+	runes := []rune(separator)
+	if len(runes) != 1 || runes[0] == '"' || runes[0] == '\r' || runes[0] == '\n' || runes[0] == utf8.RuneError {
+		return 0, fmt.Errorf("output separator %q not supported, use a single character other than quotes and line breaks", separator)
+	}
+	return runes[0], nil
+}
+
+func newReportWriter(format string, out io.Writer) report.Writer {
+	switch format {
+	case "pdf":
+		return report.NewPDFReport(reportCurrency(), amountDecimals(), directory)
+	case "csv":
+		options := report.CsvOptions{
+			UseCRLF: outputLineEnding == "crlf",
+			Comma:   csvSeparator,
+			PerUser: perUserReport,
+
+			AmountColumnName: columnName(amountColumn, Config.Output.AmountColumnName),
+			HoursColumnName:  columnName(hoursColumn, Config.Output.HoursColumnName),
+		}
+		if formatAmountsAsIntegers {
+			options.MinorUnit, options.MinorUnitsPerUnit = minorUnit()
+		}
+		return report.NewCsvReport(reportCurrency(), amountDecimals(), directory, out, options)
+	default:
+		return report.NewConsoleReport(reportCurrency(), amountDecimals(), out)
+	}
+}
+
+// columnName returns the column name given in the flag, or else the configured one.
+func columnName(flag, configured string) string {
+	if flag != "" {
+		return flag
+	}
+	return configured
+}
+
+// writeReports writes the report in every format, one after the other or, with
+// --parallel-formats, concurrently with up to --format-workers formats at a time, and
+// returns the files written.
+func writeReports(data *report.PrintableData, formats []string) ([]string, error) {
+	files := make([]string, 0)
+	if !parallelFormats || len(formats) == 1 {
+		for _, format := range formats {
+			written, err := writeReport(newReportWriter(format, os.Stdout), data)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, written...)
+		}
+		return files, nil
+	}
+
+	workers := formatWorkers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	// Each format writes its console output to its own buffer, printed in order once
+	// all of them are done so the outputs don't interleave.
+	outputs := make([]bytes.Buffer, len(formats))
+	written := make([][]string, len(formats))
+	errs := make([]error, len(formats))
+	semaphore := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, format := range formats {
+		wg.Add(1)
+		go func(i int, format string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			written[i], errs[i] = writeReport(newReportWriter(format, &outputs[i]), copyPrintableData(data))
+		}(i, format)
+	}
+	wg.Wait()
+
+	for i := range formats {
+		_, _ = outputs[i].WriteTo(os.Stdout)
+	}
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("writing the %s report: %w", formats[i], err)
+		}
+		files = append(files, written[i]...)
+	}
+	return files, nil
+}
+
+// writeReport generates the report with the writer and returns the files it wrote, if any.
+func writeReport(writer report.Writer, data *report.PrintableData) ([]string, error) {
+	message, err := writer.GenerateReport(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(message) > 0 {
+		log.Println(message)
+	}
+	if fileWriter, ok := writer.(report.FileWriter); ok {
+		return fileWriter.Files(), nil
+	}
+	return nil, nil
+}
+
+// copyPrintableData returns a copy of the data the report writers can sort without
+// affecting each other.
+func copyPrintableData(data *report.PrintableData) *report.PrintableData {
+	dataCopy := *data
+	dataCopy.UsersSchedulesSummary = append([]*report.ScheduleUser(nil), data.UsersSchedulesSummary...)
+	return &dataCopy
+}
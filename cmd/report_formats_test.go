@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_supportedFormats(t *testing.T) {
+	assert.Equal(t, []string{"csv", "console", "pdf"}, supportedFormats([]string{"csv", "html", "console", "pdf", "csv"}))
+}
+
+func Test_writeReports(t *testing.T) {
+	defer func() {
+		parallelFormats = false
+		formatWorkers = 0
+		directory = ""
+	}()
+	setConfig(t, configuration.New())
+	Config.RotationPrices.Currency = "£"
+	directory = t.TempDir()
+	parallelFormats = true
+	formatWorkers = 1
+
+	data := &report.PrintableData{
+		Start: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+		UsersSchedulesSummary: []*report.ScheduleUser{
+			{Name: "Will Smith"},
+			{Name: "John Doe"},
+		},
+	}
+
+	_, err := writeReports(data, []string{"console", "csv"})
+	require.NoError(t, err)
+
+	_, err = os.Stat(filepath.Join(directory, "pagerduty_oncall_report.1-2021-Summary.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "Will Smith", data.UsersSchedulesSummary[0].Name)
+}
+
+func Test_parseOutputSeparator(t *testing.T) {
+	tests := []struct {
+		separator string
+		want      rune
+		wantErr   bool
+	}{
+		{separator: ",", want: ','},
+		{separator: `\t`, want: '\t'},
+		{separator: "\t", want: '\t'},
+		{separator: "|", want: '|'},
+		{separator: "", wantErr: true},
+		{separator: "||", wantErr: true},
+		{separator: `"`, wantErr: true},
+		{separator: "\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.separator, func(t *testing.T) {
+			got, err := parseOutputSeparator(tt.separator)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_writeReports_outputSeparator(t *testing.T) {
+	defer func() {
+		csvSeparator = 0
+		directory = ""
+	}()
+	setConfig(t, configuration.New())
+	Config.RotationPrices.Currency = "£"
+	directory = t.TempDir()
+	csvSeparator = '\t'
+
+	data := &report.PrintableData{
+		Start:                 time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:                   time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+		UsersSchedulesSummary: []*report.ScheduleUser{{Name: "John Doe", EmailAddress: "john.doe@example.com"}},
+	}
+
+	_, err := writeReports(data, []string{"csv"})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(directory, "pagerduty_oncall_report.1-2021-Summary.csv"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "User\tEmail\tWeekday Hours")
+	assert.Contains(t, string(content), "John Doe\tjohn.doe@example.com\t0")
+}
+
+func Test_writeReports_amountColumnName(t *testing.T) {
+	defer func() {
+		amountColumn = ""
+		directory = ""
+	}()
+	setConfig(t, configuration.New())
+	Config.RotationPrices.Currency = "£"
+	directory = t.TempDir()
+	amountColumn = "GROSS_PAY_GBP"
+
+	data := &report.PrintableData{
+		Start: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+		UsersSchedulesSummary: []*report.ScheduleUser{
+			{Name: "John Doe", TotalAmountWorkHours: 100, TotalAmount: 100},
+		},
+	}
+
+	_, err := writeReports(data, []string{"csv"})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(directory, "pagerduty_oncall_report.1-2021-Summary.csv"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Total Weekday Amount (£),Total Weekend Amount (£),Total Bank Holiday Amount (£),GROSS_PAY_GBP\n")
+}
+
+func Test_writeReports_hoursColumnName(t *testing.T) {
+	defer func() {
+		hoursColumn = ""
+		directory = ""
+	}()
+	setConfig(t, configuration.New())
+	Config.RotationPrices.Currency = "£"
+	Config.Output = configuration.Output{AmountColumnName: "GROSS_PAY", HoursColumnName: "HRS"}
+	directory = t.TempDir()
+	hoursColumn = "ON_CALL_HOURS"
+
+	data := &report.PrintableData{
+		Start:                 time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:                   time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+		UsersSchedulesSummary: []*report.ScheduleUser{{Name: "John Doe"}},
+	}
+
+	_, err := writeReports(data, []string{"csv"})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(directory, "pagerduty_oncall_report.1-2021-Summary.csv"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "User,Email,Weekday ON_CALL_HOURS,Weekday Days,Weekend ON_CALL_HOURS,Weekend Days,Bank Holiday ON_CALL_HOURS,Bank Holiday Days,")
+	assert.Contains(t, string(content), ",GROSS_PAY\n")
+}
+
+func Test_writeReports_perUserReport(t *testing.T) {
+	defer func() {
+		perUserReport = false
+		directory = ""
+	}()
+	setConfig(t, configuration.New())
+	Config.RotationPrices.Currency = "£"
+	directory = t.TempDir()
+	perUserReport = true
+
+	data := &report.PrintableData{
+		Start: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+		SchedulesData: []*report.ScheduleData{
+			{ID: "SCHED_1", Name: "Primary", RotaUsers: []*report.ScheduleUser{
+				{Name: "John Doe", EmailAddress: "john.doe@example.com", TotalAmount: 100},
+				{Name: "Mary Jane", EmailAddress: "mary.jane@example.com", TotalAmount: 50},
+			}},
+			{ID: "SCHED_2", Name: "Secondary", RotaUsers: []*report.ScheduleUser{
+				{Name: "John Doe", EmailAddress: "john.doe@example.com", TotalAmount: 20},
+			}},
+		},
+		UsersSchedulesSummary: []*report.ScheduleUser{
+			{Name: "John Doe", EmailAddress: "john.doe@example.com", TotalAmount: 120},
+			{Name: "Mary Jane", EmailAddress: "mary.jane@example.com", TotalAmount: 50},
+		},
+	}
+
+	assert.Equal(t, []string{"console", "csv"}, supportedFormats([]string{"console"}))
+	_, err := writeReports(data, []string{"csv"})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(directory, "pagerduty_oncall_report.1-2021-user-john.doe@example.com.csv"))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 4)
+	assert.True(t, strings.HasSuffix(lines[0], ",Schedule"))
+	assert.True(t, strings.HasSuffix(lines[1], ",100.00,Primary"))
+	assert.True(t, strings.HasSuffix(lines[2], ",20.00,Secondary"))
+	assert.True(t, strings.HasSuffix(lines[3], ",120.00,Total"))
+
+	_, err = os.Stat(filepath.Join(directory, "pagerduty_oncall_report.1-2021-user-mary.jane@example.com.csv"))
+	require.NoError(t, err)
+}
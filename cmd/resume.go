@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const resumeFile = ".pagerduty-report-resume"
+
+var resume bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&resume, "resume", false, "save the progress of the report run when interrupted, and continue an interrupted run of the same schedules, periods and rotation prices from the schedule it stopped at")
+}
+
+// reportProgress holds the data of the schedules processed so far, saved when a report run is
+// interrupted so the next run can carry on from there.
+type reportProgress struct {
+	Schedules        []progressSchedule
+	RotationPrices   configuration.RotationPrices
+	NextSchedule     int
+	SchedulesData    []*report.ScheduleData
+	ScheduleServices map[string]map[string]bool
+	ServiceAmounts   scheduleServiceAmounts
+}
+
+// progressSchedule is a schedule of the report and the period it is reported for.
+type progressSchedule struct {
+	ID    string
+	Start string
+	End   string
+}
+
+func newReportProgress(schedules []Schedule) *reportProgress {
+	progressSchedules := make([]progressSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		progressSchedules = append(progressSchedules, progressSchedule{
+			ID:    schedule.id,
+			Start: schedule.startDate.Format(time.RFC3339),
+			End:   schedule.endDate.Format(time.RFC3339),
+		})
+	}
+
+	return &reportProgress{
+		Schedules:        progressSchedules,
+		RotationPrices:   Config.RotationPrices,
+		SchedulesData:    make([]*report.ScheduleData, 0),
+		ScheduleServices: make(map[string]map[string]bool),
+		ServiceAmounts:   make(scheduleServiceAmounts),
+	}
+}
+
+// loadReportProgress reads the progress saved by an interrupted run of the same report, or
+// starts from scratch when there is none.
+func loadReportProgress(path string, schedules []Schedule) (*reportProgress, error) {
+	progress := newReportProgress(schedules)
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		log.Printf("No interrupted report run to resume, starting from the first schedule")
+		return progress, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resume file: %w", err)
+	}
+
+	saved := &reportProgress{}
+	err = json.Unmarshal(content, saved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse resume file %s: %w", path, err)
+	}
+	if fmt.Sprint(saved.Schedules) != fmt.Sprint(progress.Schedules) {
+		return nil, fmt.Errorf("resume file %s belongs to a report of different schedules or periods %v - remove it to start over", path, saved.Schedules)
+	}
+	if fmt.Sprint(saved.RotationPrices) != fmt.Sprint(progress.RotationPrices) {
+		return nil, fmt.Errorf("resume file %s belongs to a report with different rotation prices %v - remove it to start over", path, saved.RotationPrices)
+	}
+
+	log.Printf("Resuming interrupted report run from schedule %d of %d", saved.NextSchedule+1, len(saved.Schedules))
+	return saved, nil
+}
+
+func (p *reportProgress) save(path string) error {
+	content, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0600)
+}
+
+// interrupt saves the progress and returns the error ending the interrupted run.
+func (p *reportProgress) interrupt(path string) error {
+	err := p.save(path)
+	if err != nil {
+		return fmt.Errorf("report interrupted, failed to save its progress: %w", err)
+	}
+	return fmt.Errorf("report interrupted at schedule %d of %d, run it again with --resume to continue",
+		p.NextSchedule+1, len(p.Schedules))
+}
+
+// notifyInterrupt returns a channel closed on the first SIGINT or SIGTERM, so the current schedule
+// can be completed before stopping. Further signals terminate the process as usual.
+func notifyInterrupt() (<-chan struct{}, func()) {
+	signals := make(chan os.Signal, 1)
+	interrupted := make(chan struct{})
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		if sig, ok := <-signals; ok {
+			signal.Stop(signals)
+			log.Printf("Received %s, stopping after the current schedule", sig)
+			close(interrupted)
+		}
+	}()
+
+	return interrupted, func() {
+		signal.Stop(signals)
+		close(signals)
+	}
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_loadReportProgress(t *testing.T) {
+	setConfig(t, configuration.New())
+	Config.RotationPrices = configuration.RotationPrices{Currency: "£", DaysInfo: []configuration.RotationPriceDay{{Day: "weekday", Price: 10}}}
+	start := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)
+	schedules := []Schedule{
+		{id: "SCHED_1", startDate: start, endDate: end},
+		{id: "SCHED_2", startDate: start, endDate: end},
+		{id: "SCHED_3", startDate: start, endDate: end},
+	}
+	path := filepath.Join(t.TempDir(), resumeFile)
+
+	progress, err := loadReportProgress(path, schedules)
+	require.NoError(t, err)
+	assert.Equal(t, 0, progress.NextSchedule)
+	assert.Empty(t, progress.SchedulesData)
+
+	progress.NextSchedule = 2
+	progress.SchedulesData = append(progress.SchedulesData, &report.ScheduleData{
+		ID:        "SCHED_1",
+		RotaUsers: []*report.ScheduleUser{{Name: "John Doe", TotalAmount: 100}},
+	})
+	progress.ServiceAmounts["SCHED_1"] = map[string]float32{"John Doe": 5}
+	err = progress.interrupt(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--resume")
+
+	resumed, err := loadReportProgress(path, schedules)
+	require.NoError(t, err)
+	assert.Equal(t, progress, resumed)
+
+	_, err = loadReportProgress(path, schedules[1:])
+	assert.ErrorContains(t, err, "different schedules or periods")
+
+	nextMonth := []Schedule{
+		{id: "SCHED_1", startDate: end, endDate: end.AddDate(0, 1, 0)},
+		{id: "SCHED_2", startDate: end, endDate: end.AddDate(0, 1, 0)},
+		{id: "SCHED_3", startDate: end, endDate: end.AddDate(0, 1, 0)},
+	}
+	_, err = loadReportProgress(path, nextMonth)
+	assert.ErrorContains(t, err, "different schedules or periods")
+
+	Config.RotationPrices.DaysInfo[0].Price = 12
+	_, err = loadReportProgress(path, schedules)
+	assert.ErrorContains(t, err, "different rotation prices")
+
+	Config.RotationPrices = configuration.RotationPrices{Currency: "€", DaysInfo: []configuration.RotationPriceDay{{Day: "weekday", Price: 10}}}
+	_, err = loadReportProgress(path, schedules)
+	assert.ErrorContains(t, err, "different rotation prices")
+}
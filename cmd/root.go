@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
@@ -16,6 +18,8 @@ import (
 var (
 	cfgFile string
 	Config  *configuration.Configuration
+	// configErr is the error loading the configuration, returned by the commands
+	configErr error
 )
 
 type client interface {
@@ -23,15 +27,21 @@ type client interface {
 	ListTeams() ([]*api.Team, error)
 	ListServices(string) ([]*api.Service, error)
 	ListSchedules() ([]*api.Schedule, error)
+	SearchSchedules(query string) ([]*api.Schedule, error)
 	GetSchedule(scheduleID, startDate, endDate string) (*api.Schedule, error)
+	GetEscalationPolicy(escalationPolicyID string) (*api.EscalationPolicy, error)
+	ListAlerts(serviceIDs []string, since, until time.Time) ([]*api.Alert, error)
 }
 
 type pagerDutyClient struct {
 	client client
 
-	cachedUsers []*api.User
+	cachedUsers              []*api.User
+	cachedEscalationPolicies map[string]*api.EscalationPolicy
 
 	defaultUserTimezone string
+
+	reportSchedules []Schedule
 }
 
 func init() {
@@ -43,6 +53,21 @@ func init() {
 }
 
 func initConfig() {
+	configErr = loadConfig()
+}
+
+// loadConfig loads the configuration file and the environment variables. Config is set even when
+// it fails, with what could be loaded, for the report to notify the failure.
+func loadConfig() error {
+	Config = configuration.New()
+	viper.AutomaticEnv()
+	if err := viper.BindEnv("PD_AUTH_TOKEN"); err != nil {
+		return err
+	}
+	if err := viper.BindEnv("PD_ALERT_ROUTING_KEY"); err != nil {
+		return err
+	}
+
 	// Don't forget to read model either from cfgFile or from home directory!
 	if cfgFile != "" {
 		// Use model file from the flag.
@@ -52,7 +77,7 @@ func initConfig() {
 		// Find home directory.
 		home, err := homedir.Dir()
 		if err != nil {
-			log.Fatal("Can't get the homedir: ", err)
+			return fmt.Errorf("can't get the homedir: %w", err)
 		}
 
 		viper.AddConfigPath(home)
@@ -62,22 +87,20 @@ func initConfig() {
 
 	viper.SetConfigType("yaml")
 
-	if err := viper.ReadInConfig(); err != nil {
-		log.Fatal("Can't read config: ", err)
-	}
-
-	viper.AutomaticEnv()
-	if err := viper.BindEnv("PD_AUTH_TOKEN"); err != nil {
-		log.Fatal(err)
-	}
-
-	Config = configuration.New()
+	readErr := viper.ReadInConfig()
 	err := viper.Unmarshal(&Config)
 	if err != nil {
-		log.Fatalf("%v, %#v", err, Config)
+		return fmt.Errorf("%v, %#v", err, Config)
 	}
+	if readErr != nil {
+		return fmt.Errorf("can't read config: %w", readErr)
+	}
+	return nil
 }
 
+// version is set at build time with -ldflags "-X github.com/form3tech-oss/go-pagerduty-oncall-report/cmd.version=..."
+var version = "dev"
+
 var rootCmd = &cobra.Command{
 	Use:   "pd-report",
 	Short: "Easily generate PagerDuty reports",
@@ -86,9 +109,27 @@ from your PagerDuty account.`,
 	SilenceUsage: true,
 }
 
+// exitCodeError makes the command exit with the given code instead of 1.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
+		var exitErr *exitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+)
+
+// rotationLengthTolerance is how much the inferred rotation length can differ from the configured one.
+const rotationLengthTolerance = 0.1
+
+var inferRotationLength bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&inferRotationLength, "schedule-rotation-length", false, "infer how often the users of each schedule are on call again, warning when it differs from the rotationLength configured for the schedule")
+}
+
+// rotationLengthNote returns a note with the rotation length of the schedule inferred from its layers,
+// logging a warning when it differs from the configured one by more than rotationLengthTolerance.
+func rotationLengthNote(scheduleInfo *api.ScheduleInfo) (string, error) {
+	inferred, err := inferScheduleRotationLength(scheduleInfo)
+	if err != nil {
+		return "", fmt.Errorf("failed to infer the rotation length of schedule %s: %w", scheduleInfo.ID, err)
+	}
+
+	var configured time.Duration
+	if settings := Config.FindScheduleSettingsByID(scheduleInfo.ID); settings != nil && settings.RotationLength != "" {
+		configured, err = parseRotationLength(settings.RotationLength)
+		if err != nil {
+			return "", fmt.Errorf("invalid rotation length for schedule %s: %w", scheduleInfo.ID, err)
+		}
+	}
+
+	if inferred == 0 {
+		return "Rotation length: unknown, no user is on call twice in the period", nil
+	}
+	if configured == 0 {
+		return fmt.Sprintf("Rotation length: %s", formatRotationLength(inferred)), nil
+	}
+
+	if diff := float64(inferred-configured) / float64(configured); diff > rotationLengthTolerance || diff < -rotationLengthTolerance {
+		log.Printf("WARNING: schedule '%s' (%s) rotates every %s, configured rotation length is %s",
+			scheduleInfo.Name, scheduleInfo.ID, formatRotationLength(inferred), formatRotationLength(configured))
+	}
+	return fmt.Sprintf("Rotation length: %s (configured %s)", formatRotationLength(inferred), formatRotationLength(configured)), nil
+}
+
+// inferScheduleRotationLength returns the median time between the starts of two consecutive shifts of the
+// same user in the schedule layers, or 0 when no user has two shifts. The layers are used rather than the
+// final schedule so overrides don't distort the result.
+func inferScheduleRotationLength(scheduleInfo *api.ScheduleInfo) (time.Duration, error) {
+	recurrences := make([]time.Duration, 0)
+	for _, layer := range scheduleInfo.ScheduleLayers {
+		layerRecurrences, err := shiftRecurrences(layer)
+		if err != nil {
+			return 0, err
+		}
+		recurrences = append(recurrences, layerRecurrences...)
+	}
+
+	if len(recurrences) == 0 {
+		return 0, nil
+	}
+	sort.Slice(recurrences, func(i, j int) bool {
+		return recurrences[i] < recurrences[j]
+	})
+	return recurrences[len(recurrences)/2], nil
+}
+
+// shiftRecurrences returns the times between the starts of two consecutive shifts of the same user in the layer.
+func shiftRecurrences(layer api.ScheduleLayer) ([]time.Duration, error) {
+	shiftStarts := make(map[string][]time.Time)
+	var previousUserID string
+	var previousEnd time.Time
+	for _, entry := range layer.RenderedScheduleEntries {
+		start, err := time.Parse(time.RFC3339, entry.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := time.Parse(time.RFC3339, entry.End)
+		if err != nil {
+			return nil, err
+		}
+		// consecutive entries of the same user are the same shift
+		if entry.User.ID != previousUserID || !start.Equal(previousEnd) {
+			shiftStarts[entry.User.ID] = append(shiftStarts[entry.User.ID], start)
+		}
+		previousUserID, previousEnd = entry.User.ID, end
+	}
+
+	recurrences := make([]time.Duration, 0)
+	for _, starts := range shiftStarts {
+		for i := 1; i < len(starts); i++ {
+			recurrences = append(recurrences, starts[i].Sub(starts[i-1]))
+		}
+	}
+	return recurrences, nil
+}
+
+// parseRotationLength parses a rotation length in hours, days or weeks, e.g. "12h", "3d" or "2w".
+func parseRotationLength(length string) (time.Duration, error) {
+	units := map[string]time.Duration{"h": time.Hour, "d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+	length = strings.TrimSpace(length)
+	if len(length) < 2 {
+		return 0, fmt.Errorf("rotation length %q must be a number of hours, days or weeks, e.g. 12h, 3d or 2w", length)
+	}
+	unit, ok := units[length[len(length)-1:]]
+	value, err := strconv.ParseFloat(length[:len(length)-1], 64)
+	if !ok || err != nil || value <= 0 {
+		return 0, fmt.Errorf("rotation length %q must be a number of hours, days or weeks, e.g. 12h, 3d or 2w", length)
+	}
+	return time.Duration(value * float64(unit)), nil
+}
+
+// formatRotationLength formats the length in the largest unit that divides it.
+func formatRotationLength(length time.Duration) string {
+	switch {
+	case length%(7*24*time.Hour) == 0:
+		return fmt.Sprintf("%dw", length/(7*24*time.Hour))
+	case length%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", length/(24*time.Hour))
+	default:
+		return fmt.Sprintf("%gh", length.Hours())
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_rotationLengthNote(t *testing.T) {
+	john := api.User{ID: "USER_1", Summary: "John Doe"}
+	mary := api.User{ID: "USER_2", Summary: "Mary Jane"}
+	weeklyRotation := []api.ScheduleLayer{
+		{
+			RenderedScheduleEntries: []api.RenderedScheduleEntry{
+				{Start: "2022-08-01T00:00:00Z", End: "2022-08-04T00:00:00Z", User: john},
+				{Start: "2022-08-04T00:00:00Z", End: "2022-08-08T00:00:00Z", User: john},
+				{Start: "2022-08-08T00:00:00Z", End: "2022-08-15T00:00:00Z", User: mary},
+				{Start: "2022-08-15T00:00:00Z", End: "2022-08-22T00:00:00Z", User: john},
+				{Start: "2022-08-22T00:00:00Z", End: "2022-08-29T00:00:00Z", User: mary},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		layers         []api.ScheduleLayer
+		rotationLength string
+		want           string
+		wantErr        bool
+	}{
+		{name: "inferred only", layers: weeklyRotation, want: "Rotation length: 2w"},
+		{name: "matches the configured length", layers: weeklyRotation, rotationLength: "14d", want: "Rotation length: 2w (configured 2w)"},
+		{name: "differs from the configured length", layers: weeklyRotation, rotationLength: "1w", want: "Rotation length: 2w (configured 1w)"},
+		{name: "nobody on call twice", layers: weeklyRotation[:0], want: "Rotation length: unknown, no user is on call twice in the period"},
+		{name: "invalid configured length", layers: weeklyRotation, rotationLength: "weekly", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConfig(t, configuration.New())
+			Config.ScheduleSettings = []configuration.ScheduleSettings{{Id: "SCHED_1", RotationLength: tt.rotationLength}}
+
+			note, err := rotationLengthNote(&api.ScheduleInfo{ID: "SCHED_1", ScheduleLayers: tt.layers})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, note)
+		})
+	}
+}
+
+func Test_parseRotationLength(t *testing.T) {
+	tests := []struct {
+		length  string
+		want    time.Duration
+		wantErr bool
+	}{
+		{length: "12h", want: 12 * time.Hour},
+		{length: "1.5d", want: 36 * time.Hour},
+		{length: "2w", want: 14 * 24 * time.Hour},
+		{length: "2", wantErr: true},
+		{length: "0d", wantErr: true},
+		{length: "1m", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.length, func(t *testing.T) {
+			got, err := parseRotationLength(tt.length)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_formatRotationLength(t *testing.T) {
+	assert.Equal(t, "1w", formatRotationLength(7*24*time.Hour))
+	assert.Equal(t, "3d", formatRotationLength(72*time.Hour))
+	assert.Equal(t, "12h", formatRotationLength(12*time.Hour))
+	assert.Equal(t, "1.5h", formatRotationLength(90*time.Minute))
+}
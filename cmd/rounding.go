@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+)
+
+const defaultRoundingMode = "half_up"
+
+var roundingModes = map[string]func(float64) float64{
+	"half_up":   math.Round,
+	"half_even": math.RoundToEven,
+	"half_down": func(x float64) float64 { return math.Copysign(math.Ceil(math.Abs(x)-0.5), x) },
+}
+
+// currencyRounder returns a function rounding amounts to the decimal places of the currency with the given mode.
+func currencyRounder(mode string) (func(float32) float32, error) {
+	round, ok := roundingModes[mode]
+	if !ok {
+		return nil, fmt.Errorf("rounding mode %s not supported", mode)
+	}
+
+	decimals := amountDecimals()
+	return func(amount float32) float32 {
+		return roundToDecimals(round, amount, decimals)
+	}, nil
+}
+
+// reportRoundingMode returns the globally configured rounding mode, or the default one.
+func reportRoundingMode() string {
+	if Config.RoundingMode == "" {
+		return defaultRoundingMode
+	}
+	return Config.RoundingMode
+}
+
+// scheduleRoundingMode returns the rounding mode configured for the schedule, falling back
+// to the global one.
+func scheduleRoundingMode(scheduleID string) string {
+	settings := Config.FindScheduleSettingsByID(scheduleID)
+	if settings == nil || settings.RoundingMode == "" {
+		return reportRoundingMode()
+	}
+	return settings.RoundingMode
+}
@@ -2,8 +2,14 @@ package cmd
 
 import (
 	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRoundCurrency(t *testing.T) {
@@ -193,4 +199,120 @@ func TestRoundCurrency_MultipleShifts(t *testing.T) {
 
 	// Total should be clean
 	assert.Equal(t, float32(203.33), total, "Sum of multiple shifts")
-}
\ No newline at end of file
+}
+
+func Test_scheduleRoundingMode(t *testing.T) {
+	setConfig(t, configuration.New())
+	Config.RoundingMode = "half_even"
+	Config.ScheduleSettings = []configuration.ScheduleSettings{
+		{Id: "SCHED_1", RoundingMode: "half_up"},
+		{Id: "SCHED_2", RoundingMode: "half_down"},
+		{Id: "SCHED_3", UserSortKey: "hours_desc"},
+	}
+
+	tests := []struct {
+		name       string
+		scheduleID string
+		amount     float32
+		expected   float32
+	}{
+		{
+			name:       "Half up schedule rounds .125 up",
+			scheduleID: "SCHED_1",
+			amount:     0.125,
+			expected:   0.13,
+		},
+		{
+			name:       "Half down schedule rounds .375 down",
+			scheduleID: "SCHED_2",
+			amount:     0.375,
+			expected:   0.37,
+		},
+		{
+			name:       "Half down schedule rounds .376 up",
+			scheduleID: "SCHED_2",
+			amount:     0.376,
+			expected:   0.38,
+		},
+		{
+			name:       "Schedule without rounding mode uses the global half even",
+			scheduleID: "SCHED_3",
+			amount:     0.125,
+			expected:   0.12,
+		},
+		{
+			name:       "Schedule without settings uses the global half even",
+			scheduleID: "SCHED_4",
+			amount:     0.375,
+			expected:   0.38,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roundAmount, err := currencyRounder(scheduleRoundingMode(tt.scheduleID))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, roundAmount(tt.amount))
+		})
+	}
+}
+
+func Test_currencyRounder_unknownMode(t *testing.T) {
+	_, err := currencyRounder("ceiling")
+	require.Error(t, err)
+}
+
+func Test_generateScheduleData_roundingModePerSchedule(t *testing.T) {
+	config := configuration.New()
+	config.RoundingMode = "half_even"
+	config.RotationInfo = configuration.RotationInfo{DailyRotationStartsAt: 8, CheckRotationChangeEvery: 30}
+	// 3 a day is 0.125 an hour, half way between 0.12 and 0.13
+	config.RotationPrices = configuration.RotationPrices{Currency: "£", DaysInfo: []configuration.RotationPriceDay{
+		{Day: "weekday", Price: 3}, {Day: "weekend", Price: 3}, {Day: "bankholiday", Price: 3},
+	}}
+	config.RotationUsers = []configuration.RotationUser{{UserID: "USER_1", HolidaysCalendar: "uk"}}
+	config.ScheduleSettings = []configuration.ScheduleSettings{
+		{Id: "SCHED_1", RoundingMode: "half_up"},
+		{Id: "SCHED_2", RoundingMode: "half_down"},
+	}
+	setConfig(t, config)
+	calendars := configuration.BankHolidaysCalendars
+	t.Cleanup(func() { configuration.BankHolidaysCalendars = calendars })
+	configuration.BankHolidaysCalendars = configuration.BHCalendars{"uk-2024": {DaysMaps: map[string]configuration.BankHoliday{}}}
+
+	mockedClient := &clientMock{}
+	mockedClient.On("ListUsers").Once().Return([]*api.User{
+		{ID: "USER_1", Name: "John Doe", Email: "john.doe@email.com", Timezone: "UTC"},
+	}, nil)
+	pd := &pagerDutyClient{client: mockedClient}
+	pricesInfo, err := Config.GetPricesInfo()
+	require.NoError(t, err)
+
+	start := time.Date(2024, time.January, 1, 8, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.February, 1, 8, 0, 0, 0, time.UTC)
+	schedulesData := make([]*report.ScheduleData, 0)
+	for _, scheduleID := range []string{"SCHED_1", "SCHED_2"} {
+		// one weekday hour on call
+		scheduleInfo := &api.ScheduleInfo{ID: scheduleID, Location: time.UTC, Start: start, End: end,
+			FinalSchedule: api.ScheduleLayer{RenderedScheduleEntries: []api.RenderedScheduleEntry{
+				{Start: "2024-01-10T10:00:00Z", End: "2024-01-10T11:00:00Z", User: api.User{ID: "USER_1", Summary: "John Doe"}},
+			}}}
+		usersRotationData, err := getUsersRotationData(scheduleInfo)
+		require.NoError(t, err)
+
+		scheduleData, err := pd.generateScheduleData(scheduleInfo, usersRotationData, pricesInfo,
+			Schedule{id: scheduleID, startDate: start, endDate: end})
+		require.NoError(t, err)
+		schedulesData = append(schedulesData, scheduleData)
+	}
+	roundSummaryAmount, err := currencyRounder(reportRoundingMode())
+	require.NoError(t, err)
+	summary := calculateSummaryData(schedulesData, pricesInfo, roundSummaryAmount)
+
+	mockedClient.AssertExpectations(t)
+	assert.Equal(t, float32(0.13), schedulesData[0].RotaUsers[0].TotalAmount, "half up schedule")
+	assert.Equal(t, float32(0.12), schedulesData[1].RotaUsers[0].TotalAmount, "half down schedule")
+	require.Len(t, summary, 1)
+	assert.Equal(t, float32(2), summary[0].NumWorkHours)
+	assert.Equal(t, float32(0.25), summary[0].TotalAmount, "the summary adds up the amounts of both rounding modes")
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+)
+
+var scheduleHealthCheck bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&scheduleHealthCheck, "schedule-health-check", false, "check the configuration of each schedule in PagerDuty (layers, users, handoff times and time zone), then exit without generating the report")
+}
+
+// printScheduleHealth prints a table with the configuration problems of the report schedules in PagerDuty.
+// It checks the data quality of the schedules only, not whether they cover the whole period.
+func (pd *pagerDutyClient) printScheduleHealth(w io.Writer) error {
+	schedules, err := pd.processArguments()
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Schedule\tName\tLayers\tHealth")
+	for _, schedule := range schedules {
+		scheduleInfo, err := pd.client.GetSchedule(schedule.id, "", "")
+		if err != nil {
+			return fmt.Errorf("failed to get schedule %s: %w", schedule.id, err)
+		}
+
+		health := "OK"
+		if problems := scheduleHealthProblems(scheduleInfo); len(problems) > 0 {
+			health = strings.Join(problems, "; ")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", scheduleInfo.ID, scheduleInfo.Name, len(scheduleInfo.ScheduleLayers), health)
+	}
+	return tw.Flush()
+}
+
+// scheduleHealthProblems returns the problems of the schedule configuration.
+func scheduleHealthProblems(schedule *api.Schedule) []string {
+	problems := make([]string, 0)
+	if len(schedule.ScheduleLayers) == 0 {
+		problems = append(problems, "no layers")
+	}
+	for i, layer := range schedule.ScheduleLayers {
+		name := layer.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+		if len(layer.UserIDs) == 0 {
+			problems = append(problems, fmt.Sprintf("layer %s has no users", name))
+		}
+		if layer.RotationVirtualStart == "" {
+			problems = append(problems, fmt.Sprintf("layer %s has no handoff time", name))
+		} else if _, err := time.Parse(time.RFC3339, layer.RotationVirtualStart); err != nil {
+			problems = append(problems, fmt.Sprintf("layer %s has an invalid handoff time %s", name, layer.RotationVirtualStart))
+		}
+	}
+	if schedule.TimeZone == "" {
+		problems = append(problems, "no time zone")
+	} else if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+		problems = append(problems, fmt.Sprintf("invalid time zone %s", schedule.TimeZone))
+	}
+	return problems
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_scheduleHealthProblems(t *testing.T) {
+	healthyLayer := api.ScheduleLayer{Name: "Primary", RotationVirtualStart: "2024-01-01T09:00:00Z", UserIDs: []string{"USER_1"}}
+
+	tests := []struct {
+		name     string
+		schedule *api.Schedule
+		want     []string
+	}{
+		{
+			name:     "healthy schedule",
+			schedule: &api.Schedule{TimeZone: "Europe/London", ScheduleLayers: []api.ScheduleLayer{healthyLayer}},
+			want:     []string{},
+		},
+		{
+			name:     "no layers nor time zone",
+			schedule: &api.Schedule{},
+			want:     []string{"no layers", "no time zone"},
+		},
+		{
+			name: "unhealthy layers and invalid time zone",
+			schedule: &api.Schedule{TimeZone: "Europe/Nowhere", ScheduleLayers: []api.ScheduleLayer{
+				healthyLayer,
+				{Name: "Secondary", RotationVirtualStart: "yesterday"},
+				{},
+			}},
+			want: []string{
+				"layer Secondary has no users",
+				"layer Secondary has an invalid handoff time yesterday",
+				"layer #3 has no users",
+				"layer #3 has no handoff time",
+				"invalid time zone Europe/Nowhere",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, scheduleHealthProblems(tt.schedule))
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package cmd
+
+var scheduleLabelOverrides map[string]string
+
+func init() {
+	scheduleReportCmd.Flags().StringToStringVar(&scheduleLabelOverrides, "label-override", map[string]string{}, "name to show for a schedule in all the outputs and file names instead of its PagerDuty name, as <scheduleID>=<label>")
+}
+
+// scheduleLabel returns the label overriding the name of the schedule, or its name when there is none.
+func scheduleLabel(scheduleID, name string) string {
+	if label, ok := scheduleLabelOverrides[scheduleID]; ok && label != "" {
+		return label
+	}
+	return name
+}
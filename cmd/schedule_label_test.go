@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_scheduleLabel(t *testing.T) {
+	defer func() { scheduleLabelOverrides = map[string]string{} }()
+	scheduleLabelOverrides = map[string]string{"SCHED_1": "prod-eu-primary", "SCHED_2": ""}
+
+	assert.Equal(t, "prod-eu-primary", scheduleLabel("SCHED_1", "Production EU (primary)"))
+	assert.Equal(t, "Staging", scheduleLabel("SCHED_2", "Staging"))
+	assert.Equal(t, "Development", scheduleLabel("SCHED_3", "Development"))
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+)
+
+var (
+	scheduleNamePattern    string
+	allScheduleNameMatches bool
+)
+
+func init() {
+	scheduleReportCmd.Flags().StringVar(&scheduleNamePattern, "schedule-id-from-name", "", "report the schedule whose name matches the pattern instead of --schedules, e.g. \"SRE*\" (* and ? are wildcards, case-insensitive)")
+	scheduleReportCmd.Flags().BoolVar(&allScheduleNameMatches, "schedule-id-from-name-all", false, "report all the schedules matching --schedule-id-from-name instead of failing when there are several")
+}
+
+// scheduleNameQuery returns the text before the first wildcard of the pattern, for PagerDuty to filter the
+// schedules by.
+func scheduleNameQuery(pattern string) string {
+	if i := strings.IndexAny(pattern, "*?"); i >= 0 {
+		return strings.TrimSpace(pattern[:i])
+	}
+	return pattern
+}
+
+// scheduleNameMatcher translates the name pattern to a case-insensitive regexp where * matches any
+// characters, including the / some schedule names have, and ? any single one.
+func scheduleNameMatcher(pattern string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString("(?is)^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String())
+}
+
+// scheduleIDsFromName returns the IDs of the schedules matching the name pattern, failing when there are none,
+// or several and not all of them are wanted.
+func (pd *pagerDutyClient) scheduleIDsFromName(pattern string, all bool) ([]string, error) {
+	matcher := scheduleNameMatcher(pattern)
+	schedules, err := pd.client.SearchSchedules(scheduleNameQuery(pattern))
+	if err != nil {
+		return nil, fmt.Errorf("error searching the schedules named %q: %w", pattern, err)
+	}
+
+	var ids, matches []string
+	for _, schedule := range schedules {
+		if matcher.MatchString(schedule.Name) {
+			ids = append(ids, schedule.ID)
+			matches = append(matches, fmt.Sprintf("[%s] %s", schedule.ID, schedule.Name))
+		}
+	}
+
+	switch {
+	case len(ids) == 0:
+		return nil, fmt.Errorf("no schedule named %q", pattern)
+	case len(ids) > 1 && !all:
+		return nil, fmt.Errorf("%d schedules named %q, pass the ID of the one to report with --schedules or use --schedule-id-from-name-all:\n  %s",
+			len(ids), pattern, strings.Join(matches, "\n  "))
+	}
+	log.Printf("Schedules named %q: %s", pattern, strings.Join(matches, ", "))
+	return ids, nil
+}
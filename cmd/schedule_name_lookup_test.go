@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_scheduleNameQuery(t *testing.T) {
+	assert.Equal(t, "SRE", scheduleNameQuery("SRE*"))
+	assert.Equal(t, "SRE", scheduleNameQuery("SRE ?rimary"))
+	assert.Equal(t, "Platform", scheduleNameQuery("Platform"))
+	assert.Equal(t, "[EU] SRE", scheduleNameQuery("[EU] SRE*"))
+}
+
+func Test_scheduleIDsFromName(t *testing.T) {
+	schedules := []*api.Schedule{
+		{ID: "SCHED_1", Name: "SRE Primary"},
+		{ID: "SCHED_2", Name: "SRE Secondary"},
+		{ID: "SCHED_3", Name: "Platform SRE"},
+	}
+
+	tests := []struct {
+		name       string
+		pattern    string
+		all        bool
+		schedules  []*api.Schedule
+		searchErr  error
+		want       []string
+		wantErr    string
+		wantSearch string
+	}{
+		{
+			name:       "Single match",
+			pattern:    "sre prim*",
+			schedules:  schedules,
+			want:       []string{"SCHED_1"},
+			wantSearch: "sre prim",
+		},
+		{
+			name:       "Several matches",
+			pattern:    "SRE*",
+			schedules:  schedules,
+			wantErr:    "2 schedules named \"SRE*\", pass the ID of the one to report with --schedules or use --schedule-id-from-name-all:\n  [SCHED_1] SRE Primary\n  [SCHED_2] SRE Secondary",
+			wantSearch: "SRE",
+		},
+		{
+			name:       "All matches",
+			pattern:    "SRE*",
+			all:        true,
+			schedules:  schedules,
+			want:       []string{"SCHED_1", "SCHED_2"},
+			wantSearch: "SRE",
+		},
+		{
+			name:       "No match",
+			pattern:    "DBA*",
+			wantErr:    "no schedule named \"DBA*\"",
+			wantSearch: "DBA",
+		},
+		{
+			name:       "Failed to search the schedules",
+			pattern:    "SRE*",
+			searchErr:  errors.New("unauthorized"),
+			wantErr:    "unauthorized",
+			wantSearch: "SRE",
+		},
+		{
+			name:       "Wildcard matching a slash",
+			pattern:    "Payments*Primary",
+			schedules:  []*api.Schedule{{ID: "SCHED_4", Name: "Payments / EU / Primary"}},
+			want:       []string{"SCHED_4"},
+			wantSearch: "Payments",
+		},
+		{
+			name:       "Brackets matched literally",
+			pattern:    "[EU] SRE*",
+			schedules:  []*api.Schedule{{ID: "SCHED_5", Name: "[EU] SRE Primary"}, {ID: "SCHED_6", Name: "E SRE Primary"}},
+			want:       []string{"SCHED_5"},
+			wantSearch: "[EU] SRE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedClient := &clientMock{}
+			if tt.wantSearch != "" {
+				mockedClient.On("SearchSchedules", tt.wantSearch).Once().Return(tt.schedules, tt.searchErr)
+			}
+			pd := &pagerDutyClient{client: mockedClient}
+
+			got, err := pd.scheduleIDsFromName(tt.pattern, tt.all)
+
+			mockedClient.AssertExpectations(t)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
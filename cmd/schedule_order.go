@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const (
+	scheduleOrderAPI          = "api"
+	scheduleOrderAlphabetical = "alphabetical"
+	scheduleOrderConfig       = "config"
+)
+
+var (
+	scheduleOrder            string
+	groupSchedulesByTimeZone bool
+)
+
+func init() {
+	scheduleReportCmd.Flags().StringVar(&scheduleOrder, "schedule-order", scheduleOrderAPI, "order of the schedules in the report: api (as returned by PagerDuty, or as passed in --schedules), alphabetical, config (as listed in scheduleSettings)")
+	scheduleReportCmd.Flags().BoolVar(&groupSchedulesByTimeZone, "schedule-group-by-timezone", false, "group the schedules of the report by time zone, under a heading for each one, in --schedule-order within each group")
+}
+
+// sortSchedules orders the schedules of the report. With the config order, the schedules
+// not listed in scheduleSettings come last, in the API order.
+func sortSchedules(schedules []*report.ScheduleData, order string) error {
+	switch order {
+	case scheduleOrderAPI:
+	case scheduleOrderAlphabetical:
+		sort.SliceStable(schedules, func(i, j int) bool {
+			if schedules[i].Name != schedules[j].Name {
+				return schedules[i].Name < schedules[j].Name
+			}
+			return schedules[i].ID < schedules[j].ID
+		})
+	case scheduleOrderConfig:
+		positions := make(map[string]int)
+		for i, settings := range Config.ScheduleSettings {
+			if _, ok := positions[settings.Id]; !ok {
+				positions[settings.Id] = i
+			}
+		}
+		position := func(id string) int {
+			if i, ok := positions[id]; ok {
+				return i
+			}
+			return len(Config.ScheduleSettings)
+		}
+		sort.SliceStable(schedules, func(i, j int) bool {
+			return position(schedules[i].ID) < position(schedules[j].ID)
+		})
+	default:
+		return fmt.Errorf("schedule order %s not supported, use api, alphabetical or config", order)
+	}
+	return nil
+}
+
+// groupByTimeZone orders the schedules by time zone, keeping their order within each one, and heads each group
+// with its time zone.
+func groupByTimeZone(schedules []*report.ScheduleData) {
+	sort.SliceStable(schedules, func(i, j int) bool {
+		return schedules[i].TimeZone < schedules[j].TimeZone
+	})
+	for _, schedule := range schedules {
+		timezone := schedule.TimeZone
+		if timezone == "" {
+			timezone = "unknown"
+		}
+		schedule.Group = fmt.Sprintf("Time zone: %s", timezone)
+	}
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sortSchedules(t *testing.T) {
+	tests := []struct {
+		name    string
+		order   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "API order", order: "api", want: []string{"SCHED_3", "SCHED_1", "SCHED_2", "SCHED_4"}},
+		{name: "Alphabetical order", order: "alphabetical", want: []string{"SCHED_1", "SCHED_4", "SCHED_2", "SCHED_3"}},
+		{name: "Config order", order: "config", want: []string{"SCHED_2", "SCHED_1", "SCHED_3", "SCHED_4"}},
+		{name: "Unsupported order", order: "random", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConfig(t, configuration.New())
+			Config.ScheduleSettings = []configuration.ScheduleSettings{{Id: "SCHED_2"}, {Id: "SCHED_1"}}
+			schedules := []*report.ScheduleData{
+				{ID: "SCHED_3", Name: "Platform"},
+				{ID: "SCHED_1", Name: "Database"},
+				{ID: "SCHED_2", Name: "Networking"},
+				{ID: "SCHED_4", Name: "Database"},
+			}
+
+			err := sortSchedules(schedules, tt.order)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			ids := make([]string, 0, len(schedules))
+			for _, schedule := range schedules {
+				ids = append(ids, schedule.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func Test_groupByTimeZone(t *testing.T) {
+	setConfig(t, configuration.New())
+	data := &report.PrintableData{
+		SchedulesData: []*report.ScheduleData{
+			{ID: "SCHED_1", Name: "Platform", TimeZone: "Europe/London"},
+			{ID: "SCHED_2", Name: "Database", TimeZone: "America/New_York"},
+			{ID: "SCHED_3", Name: "Networking", TimeZone: "Europe/London"},
+		},
+	}
+
+	groupByTimeZone(data.SchedulesData)
+
+	ids := make([]string, 0, len(data.SchedulesData))
+	for _, schedule := range data.SchedulesData {
+		ids = append(ids, schedule.ID)
+	}
+	assert.Equal(t, []string{"SCHED_2", "SCHED_1", "SCHED_3"}, ids)
+	assert.Equal(t, "Time zone: Europe/London", data.SchedulesData[2].Group)
+
+	var out bytes.Buffer
+	_, err := report.NewConsoleReport("£", 2, &out).GenerateReport(data)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(out.String(), "| == Time zone: America/New_York =="))
+	assert.Equal(t, 1, strings.Count(out.String(), "| == Time zone: Europe/London =="))
+}
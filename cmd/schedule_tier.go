@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const (
+	scheduleTierColumn = "schedule_tier"
+	// untieredSchedule is the tier of the schedules without one configured.
+	untieredSchedule = "none"
+)
+
+var (
+	includeScheduleTier bool
+	tierFilter          string
+)
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includeScheduleTier, "include-schedule-tier", false, "add a schedule_tier column with the tier configured for each schedule, and the amount of each user in every tier")
+	scheduleReportCmd.Flags().StringVar(&tierFilter, "tier-filter", "", "only report the schedules of this tier, as configured in scheduleSettings, e.g. tier-1")
+}
+
+// scheduleTier returns the tier configured for the schedule, or none.
+func scheduleTier(scheduleID string) string {
+	settings := Config.FindScheduleSettingsByID(scheduleID)
+	if settings == nil || settings.Tier == "" {
+		return untieredSchedule
+	}
+	return settings.Tier
+}
+
+// tierAmountColumn returns the column with the amount of the users in the schedules of the tier.
+func tierAmountColumn(tier string) string {
+	return tier + "_amount"
+}
+
+// filterSchedulesByTier returns the schedules of the given tier.
+func filterSchedulesByTier(schedules []Schedule, tier string) []Schedule {
+	filtered := make([]Schedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		if strings.EqualFold(scheduleTier(schedule.id), tier) {
+			filtered = append(filtered, schedule)
+		} else {
+			log.Printf("Skipping schedule '%s' not in tier %s", schedule.id, tier)
+		}
+	}
+	return filtered
+}
+
+// setScheduleTiers sets the tier of the users of each schedule, the tiers of the schedules of each user in the
+// summary, and the amount of each user in every tier. It returns the columns added.
+func setScheduleTiers(data *report.PrintableData) []string {
+	tiers := make([]string, 0)
+	tiersByUser := make(map[string][]string)
+	amountsByUser := make(map[string]map[string]float32)
+	for _, scheduleData := range data.SchedulesData {
+		tier := scheduleTier(scheduleData.ID)
+		if !contains(tiers, tier) {
+			tiers = append(tiers, tier)
+		}
+		for _, user := range scheduleData.RotaUsers {
+			setExtraValue(user, scheduleTierColumn, tier)
+			if !contains(tiersByUser[user.Name], tier) {
+				tiersByUser[user.Name] = append(tiersByUser[user.Name], tier)
+			}
+			if amountsByUser[user.Name] == nil {
+				amountsByUser[user.Name] = make(map[string]float32)
+			}
+			amountsByUser[user.Name][tier] += user.TotalAmount
+		}
+	}
+	sort.Strings(tiers)
+
+	for _, scheduleData := range data.SchedulesData {
+		for _, user := range scheduleData.RotaUsers {
+			for _, tier := range tiers {
+				amount := float32(0)
+				if user.ExtraValues[scheduleTierColumn] == tier {
+					amount = user.TotalAmount
+				}
+				setExtraValue(user, tierAmountColumn(tier), formatAmount(amount))
+			}
+		}
+	}
+	for _, user := range data.UsersSchedulesSummary {
+		userTiers := tiersByUser[user.Name]
+		sort.Strings(userTiers)
+		setExtraValue(user, scheduleTierColumn, strings.Join(userTiers, ", "))
+		for _, tier := range tiers {
+			setExtraValue(user, tierAmountColumn(tier), formatAmount(roundCurrency(amountsByUser[user.Name][tier])))
+		}
+	}
+
+	columns := []string{scheduleTierColumn}
+	for _, tier := range tiers {
+		columns = append(columns, tierAmountColumn(tier))
+	}
+	return columns
+}
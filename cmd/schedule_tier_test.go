@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_filterSchedulesByTier(t *testing.T) {
+	setConfig(t, configuration.New())
+	Config.ScheduleSettings = []configuration.ScheduleSettings{{Id: "SCHED_1", Tier: "tier-1"}, {Id: "SCHED_2", Tier: "tier-2"}}
+
+	schedules := []Schedule{{id: "SCHED_1"}, {id: "SCHED_2"}, {id: "SCHED_3"}}
+
+	assert.Equal(t, []Schedule{{id: "SCHED_1"}}, filterSchedulesByTier(schedules, "TIER-1"))
+	assert.Equal(t, []Schedule{{id: "SCHED_3"}}, filterSchedulesByTier(schedules, untieredSchedule))
+}
+
+func Test_setScheduleTiers(t *testing.T) {
+	setConfig(t, configuration.New())
+	Config.ScheduleSettings = []configuration.ScheduleSettings{{Id: "SCHED_1", Tier: "tier-1"}, {Id: "SCHED_2", Tier: "tier-2"}}
+
+	production := &report.ScheduleUser{Name: "John Doe", TotalAmount: 100}
+	staging := &report.ScheduleUser{Name: "John Doe", TotalAmount: 20}
+	summary := &report.ScheduleUser{Name: "John Doe", TotalAmount: 120}
+	data := &report.PrintableData{
+		SchedulesData: []*report.ScheduleData{
+			{ID: "SCHED_2", RotaUsers: []*report.ScheduleUser{staging}},
+			{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{production}},
+		},
+		UsersSchedulesSummary: []*report.ScheduleUser{summary},
+	}
+
+	columns := setScheduleTiers(data)
+
+	assert.Equal(t, []string{scheduleTierColumn, "tier-1_amount", "tier-2_amount"}, columns)
+	assert.Equal(t, map[string]string{scheduleTierColumn: "tier-1", "tier-1_amount": "100.00", "tier-2_amount": "0.00"}, production.ExtraValues)
+	assert.Equal(t, map[string]string{scheduleTierColumn: "tier-1, tier-2", "tier-1_amount": "100.00", "tier-2_amount": "20.00"}, summary.ExtraValues)
+}
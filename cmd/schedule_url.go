@@ -0,0 +1,16 @@
+package cmd
+
+import "fmt"
+
+const pagerDutyAppURL = "https://app.pagerduty.com"
+
+var includeScheduleURL bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includeScheduleURL, "include-schedule-url", false, "link each schedule to its PagerDuty page, in a schedule_url column of the csv output")
+}
+
+// scheduleURL returns the page of the schedule in the PagerDuty web app.
+func scheduleURL(scheduleID string) string {
+	return fmt.Sprintf("%s/schedules/%s", pagerDutyAppURL, scheduleID)
+}
@@ -0,0 +1,11 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_scheduleURL(t *testing.T) {
+	assert.Equal(t, "https://app.pagerduty.com/schedules/PABC123", scheduleURL("PABC123"))
+}
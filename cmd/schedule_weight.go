@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const weightedHoursColumn = "weighted_hours"
+
+var (
+	rawScheduleWeights map[string]string
+	scheduleWeights    map[string]float32
+)
+
+func init() {
+	scheduleReportCmd.Flags().StringToStringVar(&rawScheduleWeights, "schedule-weight", map[string]string{}, "multiplier of the hours of a schedule in the weighted_hours column, as <scheduleID>=<weight>; amounts are not weighted")
+}
+
+// parseScheduleWeights parses the weights of --schedule-weight, which must be positive numbers.
+func parseScheduleWeights(raw map[string]string) (map[string]float32, error) {
+	weights := make(map[string]float32, len(raw))
+	for scheduleID, rawWeight := range raw {
+		weight, err := strconv.ParseFloat(rawWeight, 32)
+		if err != nil || weight <= 0 {
+			return nil, fmt.Errorf("invalid weight %s for schedule %s, use a positive number", rawWeight, scheduleID)
+		}
+		weights[scheduleID] = float32(weight)
+	}
+	return weights, nil
+}
+
+// scheduleWeight returns the weight of the schedule, 1 when not configured.
+func scheduleWeight(scheduleID string) float32 {
+	if weight, ok := scheduleWeights[scheduleID]; ok {
+		return weight
+	}
+	return 1
+}
+
+// setWeightedHours sets the on-call hours of each user multiplied by the weight of the schedule, so the
+// burden of schedules that matter more counts more when comparing users and teams.
+func setWeightedHours(data *report.PrintableData) {
+	hoursByUser := make(map[string]float32)
+	for _, scheduleData := range data.SchedulesData {
+		weight := scheduleWeight(scheduleData.ID)
+		for _, user := range scheduleData.RotaUsers {
+			hours := user.NumTotalHours() * weight
+			setExtraValue(user, weightedHoursColumn, fmt.Sprintf("%.2f", hours))
+			hoursByUser[user.Name] += hours
+		}
+	}
+	for _, user := range data.UsersSchedulesSummary {
+		setExtraValue(user, weightedHoursColumn, fmt.Sprintf("%.2f", hoursByUser[user.Name]))
+	}
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseScheduleWeights(t *testing.T) {
+	weights, err := parseScheduleWeights(map[string]string{"SCHED_1": "2", "SCHED_2": "0.5"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float32{"SCHED_1": 2, "SCHED_2": 0.5}, weights)
+
+	_, err = parseScheduleWeights(map[string]string{"SCHED_1": "two"})
+	require.Error(t, err)
+	_, err = parseScheduleWeights(map[string]string{"SCHED_1": "0"})
+	require.Error(t, err)
+}
+
+func Test_setWeightedHours(t *testing.T) {
+	scheduleWeights = map[string]float32{"SCHED_1": 2, "SCHED_2": 0.5}
+	defer func() { scheduleWeights = nil }()
+
+	data := &report.PrintableData{
+		SchedulesData: []*report.ScheduleData{
+			{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{
+				{Name: "John Doe", NumWorkHours: 10, NumWeekendHours: 2, TotalAmount: 100},
+			}},
+			{ID: "SCHED_2", RotaUsers: []*report.ScheduleUser{
+				{Name: "John Doe", NumWorkHours: 4},
+				{Name: "Mary Jane", NumBankHolidaysHours: 8},
+			}},
+			{ID: "SCHED_3", RotaUsers: []*report.ScheduleUser{
+				{Name: "Mary Jane", NumWorkHours: 3},
+			}},
+		},
+		UsersSchedulesSummary: []*report.ScheduleUser{{Name: "John Doe"}, {Name: "Mary Jane"}},
+	}
+	setWeightedHours(data)
+
+	assert.Equal(t, "24.00", data.SchedulesData[0].RotaUsers[0].ExtraValues[weightedHoursColumn])
+	assert.Equal(t, float32(100), data.SchedulesData[0].RotaUsers[0].TotalAmount)
+	assert.Equal(t, "4.00", data.SchedulesData[1].RotaUsers[1].ExtraValues[weightedHoursColumn])
+	assert.Equal(t, "3.00", data.SchedulesData[2].RotaUsers[0].ExtraValues[weightedHoursColumn])
+	assert.Equal(t, "26.00", data.UsersSchedulesSummary[0].ExtraValues[weightedHoursColumn])
+	assert.Equal(t, "7.00", data.UsersSchedulesSummary[1].ExtraValues[weightedHoursColumn])
+}
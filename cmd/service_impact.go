@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const affectedServicesColumn = "affected_services_count"
+
+var includeServiceImpact bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includeServiceImpact, "include-service-impact", false, "add an affected_services_count column with the number of distinct services escalating to each user's schedules")
+}
+
+// scheduleServiceIDs returns the distinct services escalating to the schedule through its escalation policies.
+func (pd *pagerDutyClient) scheduleServiceIDs(scheduleInfo *api.ScheduleInfo) (map[string]bool, error) {
+	if pd.cachedEscalationPolicies == nil {
+		pd.cachedEscalationPolicies = make(map[string]*api.EscalationPolicy)
+	}
+
+	serviceIDs := make(map[string]bool)
+	for _, escalationPolicyID := range scheduleInfo.EscalationPolicyIDs {
+		escalationPolicy, ok := pd.cachedEscalationPolicies[escalationPolicyID]
+		if !ok {
+			var err error
+			escalationPolicy, err = pd.client.GetEscalationPolicy(escalationPolicyID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get escalation policy %s of schedule %s: %w", escalationPolicyID, scheduleInfo.ID, err)
+			}
+			pd.cachedEscalationPolicies[escalationPolicyID] = escalationPolicy
+		}
+
+		for _, serviceID := range escalationPolicy.ServiceIDs {
+			serviceIDs[serviceID] = true
+		}
+	}
+	return serviceIDs, nil
+}
+
+// setServiceImpact sets the number of affected services of each user, as a measure of their
+// responsibility scope. In the summary, services shared by several schedules are counted once.
+func setServiceImpact(data *report.PrintableData, scheduleServices map[string]map[string]bool) {
+	userServices := make(map[string]map[string]bool)
+	for _, scheduleData := range data.SchedulesData {
+		for _, user := range scheduleData.RotaUsers {
+			setExtraValue(user, affectedServicesColumn, strconv.Itoa(len(scheduleServices[scheduleData.ID])))
+
+			if _, ok := userServices[user.Name]; !ok {
+				userServices[user.Name] = make(map[string]bool)
+			}
+			for serviceID := range scheduleServices[scheduleData.ID] {
+				userServices[user.Name][serviceID] = true
+			}
+		}
+	}
+
+	for _, user := range data.UsersSchedulesSummary {
+		setExtraValue(user, affectedServicesColumn, strconv.Itoa(len(userServices[user.Name])))
+	}
+}
+
+func setExtraValue(user *report.ScheduleUser, column, value string) {
+	if user.ExtraValues == nil {
+		user.ExtraValues = make(map[string]string)
+	}
+	user.ExtraValues[column] = value
+}
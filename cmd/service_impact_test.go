@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_pagerDutyClient_scheduleServiceIDs(t *testing.T) {
+	tests := []struct {
+		name      string
+		mockSetup func(*clientMock)
+		want      map[string]bool
+		wantErr   bool
+	}{
+		{
+			name: "Services shared by the escalation policies are counted once",
+			mockSetup: func(mock *clientMock) {
+				mock.On("GetEscalationPolicy", "EP_1").Once().Return(
+					&api.EscalationPolicy{ID: "EP_1", ServiceIDs: []string{"SERVICE_1", "SERVICE_2"}}, nil)
+				mock.On("GetEscalationPolicy", "EP_2").Once().Return(
+					&api.EscalationPolicy{ID: "EP_2", ServiceIDs: []string{"SERVICE_2", "SERVICE_3"}}, nil)
+			},
+			want: map[string]bool{"SERVICE_1": true, "SERVICE_2": true, "SERVICE_3": true},
+		},
+		{
+			name: "Fails to get an escalation policy",
+			mockSetup: func(mock *clientMock) {
+				mock.On("GetEscalationPolicy", "EP_1").Once().Return(nil, errors.New("failed"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedClient := &clientMock{}
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockedClient)
+			}
+
+			pd := pagerDutyClient{client: mockedClient}
+			got, err := pd.scheduleServiceIDs(&api.ScheduleInfo{ID: "SCHED_1", EscalationPolicyIDs: []string{"EP_1", "EP_2"}})
+
+			if tt.wantErr == true {
+				require.Error(t, err)
+				mockedClient.AssertExpectations(t)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			// the escalation policies are only fetched once
+			_, err = pd.scheduleServiceIDs(&api.ScheduleInfo{ID: "SCHED_2", EscalationPolicyIDs: []string{"EP_2"}})
+			require.NoError(t, err)
+			mockedClient.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_setServiceImpact(t *testing.T) {
+	john := &report.ScheduleUser{Name: "John Doe"}
+	mary := &report.ScheduleUser{Name: "Mary Jane"}
+	data := &report.PrintableData{
+		SchedulesData: []*report.ScheduleData{
+			{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{{Name: "John Doe"}, {Name: "Mary Jane"}}},
+			{ID: "SCHED_2", RotaUsers: []*report.ScheduleUser{{Name: "John Doe"}}},
+		},
+		UsersSchedulesSummary: []*report.ScheduleUser{john, mary},
+	}
+
+	setServiceImpact(data, map[string]map[string]bool{
+		"SCHED_1": {"SERVICE_1": true, "SERVICE_2": true},
+		"SCHED_2": {"SERVICE_2": true, "SERVICE_3": true, "SERVICE_4": true},
+	})
+
+	assert.Equal(t, "2", data.SchedulesData[0].RotaUsers[0].ExtraValues["affected_services_count"])
+	assert.Equal(t, "3", data.SchedulesData[1].RotaUsers[0].ExtraValues[affectedServicesColumn])
+	assert.Equal(t, "4", john.ExtraValues[affectedServicesColumn])
+	assert.Equal(t, "2", mary.ExtraValues[affectedServicesColumn])
+}
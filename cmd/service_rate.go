@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const serviceAmountColumn = "service_amount"
+
+var ratePerService float32
+
+func init() {
+	scheduleReportCmd.Flags().Float32Var(&ratePerService, "rate-per-service", 0, "additional pay per hour on call in the primary (first) layer of a schedule and service escalating to it, in a service_amount column, unless configured for the schedule (0 to disable)")
+}
+
+// scheduleRatePerService returns the rate per service configured for the schedule, or the command line one.
+func scheduleRatePerService(scheduleID string) float32 {
+	settings := Config.FindScheduleSettingsByID(scheduleID)
+	if settings == nil || settings.RatePerService == 0 {
+		return ratePerService
+	}
+	return settings.RatePerService
+}
+
+// primaryLayerHours returns the hours each user, by name, was on call in the primary layer of the schedule,
+// the highest priority one, cut by the overrides. The users on call through the lower layers are the
+// secondary ones. A schedule without layers only has its final schedule.
+func primaryLayerHours(scheduleInfo *api.ScheduleInfo) (map[string]float32, error) {
+	finalEntries, err := parseRenderedScheduleEntries(scheduleInfo.FinalSchedule.RenderedScheduleEntries, scheduleInfo.Location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the final schedule of schedule %s: %w", scheduleInfo.ID, err)
+	}
+	primaryEntries := finalEntries
+	if len(scheduleInfo.ScheduleLayers) > 0 {
+		primaryEntries, err = parseRenderedScheduleEntries(scheduleInfo.ScheduleLayers[0].RenderedScheduleEntries, scheduleInfo.Location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the primary layer of schedule %s: %w", scheduleInfo.ID, err)
+		}
+	}
+
+	hours := make(map[string]float32)
+	for _, finalEntry := range finalEntries {
+		for _, primaryEntry := range primaryEntries {
+			if primaryEntry.user.ID != finalEntry.user.ID {
+				continue
+			}
+			overlap := clipOnCallEntries([]onCallEntry{finalEntry}, primaryEntry.start, primaryEntry.end)
+			for _, entry := range overlap {
+				hours[entry.user.Summary] += intervalHours(entry.start, entry.end)
+			}
+		}
+	}
+	return hours, nil
+}
+
+// scheduleServiceAmounts are the service amounts paid in each schedule, by schedule ID and user name.
+type scheduleServiceAmounts map[string]map[string]float32
+
+// userTotal returns the service amount of the user across all the schedules.
+func (a scheduleServiceAmounts) userTotal(name string) float32 {
+	var total float32
+	for _, amountsByUser := range a {
+		total += amountsByUser[name]
+	}
+	return total
+}
+
+// applyServiceRate pays the users of the schedule an additional rate per hour on call in its primary layer
+// for each of the services escalating to it, recording the amounts.
+func applyServiceRate(scheduleData *report.ScheduleData, primaryHours map[string]float32, numServices int, rate float32,
+	amounts scheduleServiceAmounts) error {
+	roundAmount, err := currencyRounder(scheduleRoundingMode(scheduleData.ID))
+	if err != nil {
+		return fmt.Errorf("invalid rounding mode for schedule %s: %w", scheduleData.ID, err)
+	}
+
+	amountsByUser := make(map[string]float32)
+	for _, user := range scheduleData.RotaUsers {
+		amount := roundAmount(primaryHours[user.Name] * float32(numServices) * rate)
+		user.TotalAmount = roundAmount(user.TotalAmount + amount)
+		setExtraValue(user, serviceAmountColumn, formatAmount(amount))
+		amountsByUser[user.Name] += amount
+	}
+	amounts[scheduleData.ID] = amountsByUser
+	return nil
+}
+
+// setServiceAmounts sets the service amount of the users in the summary, already included in their total.
+func setServiceAmounts(data *report.PrintableData, amounts scheduleServiceAmounts, roundAmount func(float32) float32) {
+	for _, scheduleData := range data.SchedulesData {
+		for _, user := range scheduleData.RotaUsers {
+			if _, ok := user.ExtraValues[serviceAmountColumn]; !ok {
+				setExtraValue(user, serviceAmountColumn, formatAmount(0.0))
+			}
+		}
+	}
+	for _, user := range data.UsersSchedulesSummary {
+		setExtraValue(user, serviceAmountColumn, formatAmount(roundAmount(amounts.userTotal(user.Name))))
+	}
+}
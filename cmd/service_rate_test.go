@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_applyServiceRate(t *testing.T) {
+	setConfig(t, configuration.New())
+	Config.ScheduleSettings = []configuration.ScheduleSettings{{Id: "SCHED_1", RatePerService: 0.5}}
+	ratePerService = 0.25
+	defer func() { ratePerService = 0 }()
+
+	data := &report.PrintableData{
+		SchedulesData: []*report.ScheduleData{
+			{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{
+				{Name: "John Doe", NumWorkHours: 10, NumWeekendHours: 2, TotalAmount: 100},
+			}},
+			{ID: "SCHED_2", RotaUsers: []*report.ScheduleUser{
+				{Name: "John Doe", NumWorkHours: 4, TotalAmount: 20},
+				{Name: "Mary Jane", NumBankHolidaysHours: 8, TotalAmount: 40},
+			}},
+			{ID: "SCHED_3", RotaUsers: []*report.ScheduleUser{
+				{Name: "Mary Jane", NumWorkHours: 8, TotalAmount: 10},
+			}},
+		},
+		UsersSchedulesSummary: []*report.ScheduleUser{{Name: "John Doe"}, {Name: "Mary Jane"}},
+	}
+	assert.Equal(t, float32(0.5), scheduleRatePerService("SCHED_1"))
+	assert.Equal(t, float32(0.25), scheduleRatePerService("SCHED_2"))
+
+	amounts := make(scheduleServiceAmounts)
+	require.NoError(t, applyServiceRate(data.SchedulesData[0], map[string]float32{"John Doe": 12}, 3, scheduleRatePerService("SCHED_1"), amounts))
+	require.NoError(t, applyServiceRate(data.SchedulesData[1], map[string]float32{"John Doe": 4, "Mary Jane": 8}, 2, scheduleRatePerService("SCHED_2"), amounts))
+	setServiceAmounts(data, amounts, roundCurrency)
+
+	john := data.SchedulesData[0].RotaUsers[0]
+	assert.Equal(t, float32(118), john.TotalAmount)
+	assert.Equal(t, "18.00", john.ExtraValues[serviceAmountColumn])
+	mary := data.SchedulesData[1].RotaUsers[1]
+	assert.Equal(t, float32(44), mary.TotalAmount)
+	assert.Equal(t, "4.00", mary.ExtraValues[serviceAmountColumn])
+	assert.Equal(t, "0.00", data.SchedulesData[2].RotaUsers[0].ExtraValues[serviceAmountColumn])
+
+	assert.Equal(t, "20.00", data.UsersSchedulesSummary[0].ExtraValues[serviceAmountColumn])
+	assert.Equal(t, "4.00", data.UsersSchedulesSummary[1].ExtraValues["service_amount"])
+	assert.Equal(t, scheduleServiceAmounts{
+		"SCHED_1": {"John Doe": 18},
+		"SCHED_2": {"John Doe": 2, "Mary Jane": 4},
+	}, amounts)
+}
+
+func Test_applyServiceRate_secondaryLayer(t *testing.T) {
+	setConfig(t, configuration.New())
+	scheduleData := &report.ScheduleData{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{
+		{Name: "John Doe", NumWorkHours: 8, TotalAmount: 80},
+		{Name: "Mary Jane", NumWorkHours: 8, TotalAmount: 80},
+	}}
+
+	amounts := make(scheduleServiceAmounts)
+	require.NoError(t, applyServiceRate(scheduleData, map[string]float32{"John Doe": 8}, 2, 0.5, amounts))
+
+	assert.Equal(t, float32(88), scheduleData.RotaUsers[0].TotalAmount)
+	assert.Equal(t, float32(80), scheduleData.RotaUsers[1].TotalAmount)
+	assert.Equal(t, "0.00", scheduleData.RotaUsers[1].ExtraValues[serviceAmountColumn])
+}
+
+func Test_primaryLayerHours(t *testing.T) {
+	john := api.User{ID: "USER_1", Summary: "John Doe"}
+	mary := api.User{ID: "USER_2", Summary: "Mary Jane"}
+	ann := api.User{ID: "USER_3", Summary: "Ann Smith"}
+	scheduleInfo := &api.ScheduleInfo{
+		ID:       "SCHED_1",
+		Location: time.UTC,
+		ScheduleLayers: []api.ScheduleLayer{
+			{RenderedScheduleEntries: []api.RenderedScheduleEntry{
+				{Start: "2021-01-04T00:00:00Z", End: "2021-01-05T00:00:00Z", User: john},
+				{Start: "2021-01-05T00:00:00Z", End: "2021-01-06T00:00:00Z", User: mary},
+			}},
+			{RenderedScheduleEntries: []api.RenderedScheduleEntry{
+				{Start: "2021-01-06T00:00:00Z", End: "2021-01-07T00:00:00Z", User: ann},
+			}},
+		},
+		FinalSchedule: api.ScheduleLayer{RenderedScheduleEntries: []api.RenderedScheduleEntry{
+			{Start: "2021-01-04T00:00:00Z", End: "2021-01-04T18:00:00Z", User: john},
+			// override of the primary layer by the secondary user
+			{Start: "2021-01-04T18:00:00Z", End: "2021-01-05T00:00:00Z", User: ann},
+			{Start: "2021-01-05T00:00:00Z", End: "2021-01-06T00:00:00Z", User: mary},
+			{Start: "2021-01-06T00:00:00Z", End: "2021-01-07T00:00:00Z", User: ann},
+		}},
+	}
+
+	hours, err := primaryLayerHours(scheduleInfo)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float32{"John Doe": 18, "Mary Jane": 24}, hours)
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const shiftCountColumn = "shift_count"
+
+var includeShiftCount bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includeShiftCount, "include-shift-count", false, "add a shift_count column with the number of times each user went on call, back to back periods counting as one shift")
+}
+
+// setSummaryShiftCounts sets the shifts of the users in the summary, adding up the ones of each schedule.
+func setSummaryShiftCounts(data *report.PrintableData) error {
+	countsByUser := make(map[string]int)
+	for _, scheduleData := range data.SchedulesData {
+		for _, user := range scheduleData.RotaUsers {
+			count, err := strconv.Atoi(user.ExtraValues[shiftCountColumn])
+			if err != nil {
+				return fmt.Errorf("invalid shift count for %s in schedule %s: %w", user.Name, scheduleData.ID, err)
+			}
+			countsByUser[user.Name] += count
+		}
+	}
+	for _, user := range data.UsersSchedulesSummary {
+		setExtraValue(user, shiftCountColumn, strconv.Itoa(countsByUser[user.Name]))
+	}
+	return nil
+}
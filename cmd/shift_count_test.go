@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_setSummaryShiftCounts(t *testing.T) {
+	user := func(name, count string) *report.ScheduleUser {
+		return &report.ScheduleUser{Name: name, ExtraValues: map[string]string{shiftCountColumn: count}}
+	}
+	summary := []*report.ScheduleUser{{Name: "John Doe"}, {Name: "Mary Jane"}}
+	data := &report.PrintableData{
+		SchedulesData: []*report.ScheduleData{
+			{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{user("John Doe", "3"), user("Mary Jane", "1")}},
+			{ID: "SCHED_2", RotaUsers: []*report.ScheduleUser{user("John Doe", "2")}},
+		},
+		UsersSchedulesSummary: summary,
+	}
+
+	require.NoError(t, setSummaryShiftCounts(data))
+	assert.Equal(t, "5", summary[0].ExtraValues[shiftCountColumn])
+	assert.Equal(t, "1", summary[1].ExtraValues[shiftCountColumn])
+
+	data.SchedulesData[0].RotaUsers[0].ExtraValues[shiftCountColumn] = ""
+	require.Error(t, setSummaryShiftCounts(data))
+}
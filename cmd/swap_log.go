@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+)
+
+var includeSwapLog bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includeSwapLog, "include-swap-log", false, "annotate the schedules with the on-call swaps made through overrides")
+}
+
+type scheduleOverride struct {
+	start   time.Time
+	end     time.Time
+	user    api.User // user on call through the override
+	covered api.User // user on call in the schedule layers
+}
+
+// swapLogNotes returns a SWAP annotation for each pair of overrides where two users covered each
+// other's time in the schedule period. Swaps may not change the amounts, but they are reported
+// for transparency.
+func swapLogNotes(scheduleInfo *api.ScheduleInfo) ([]string, error) {
+	overrides, err := scheduleOverrides(scheduleInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the overrides of schedule %s: %w", scheduleInfo.ID, err)
+	}
+
+	notes := make([]string, 0)
+	paired := make([]bool, len(overrides))
+	for i := range overrides {
+		for j := i + 1; j < len(overrides) && !paired[i]; j++ {
+			if paired[j] || overrides[i].user.ID != overrides[j].covered.ID || overrides[i].covered.ID != overrides[j].user.ID {
+				continue
+			}
+			paired[i], paired[j] = true, true
+			notes = append(notes, fmt.Sprintf("SWAP: %s, %s", overrides[i], overrides[j]))
+		}
+	}
+	return notes, nil
+}
+
+func (o scheduleOverride) String() string {
+	return fmt.Sprintf("%s covered %s from %s to %s", o.user.Summary, o.covered.Summary,
+		o.start.Format("2006-01-02 15:04"), o.end.Format("2006-01-02 15:04"))
+}
+
+// scheduleOverrides returns the overrides of the schedule along with the user they replaced.
+// Overrides of the user already on call are left out.
+func scheduleOverrides(scheduleInfo *api.ScheduleInfo) ([]scheduleOverride, error) {
+	overrides := make([]scheduleOverride, 0)
+	for _, entry := range scheduleInfo.OverrideSubschedule.RenderedScheduleEntries {
+		start, err := time.ParseInLocation(time.RFC3339, entry.Start, scheduleInfo.Location)
+		if err != nil {
+			return nil, err
+		}
+		end, err := time.ParseInLocation(time.RFC3339, entry.End, scheduleInfo.Location)
+		if err != nil {
+			return nil, err
+		}
+
+		covered, err := layeredUserAt(scheduleInfo.ScheduleLayers, start, scheduleInfo.Location)
+		if err != nil {
+			return nil, err
+		}
+		if covered == nil || covered.ID == entry.User.ID {
+			continue
+		}
+
+		overrides = append(overrides, scheduleOverride{start: start, end: end, user: entry.User, covered: *covered})
+	}
+	return overrides, nil
+}
+
+// layeredUserAt returns the user on call at the given time according to the schedule layers,
+// ignoring the overrides, or nil when nobody is.
+func layeredUserAt(layers []api.ScheduleLayer, at time.Time, location *time.Location) (*api.User, error) {
+	for _, layer := range layers {
+		for _, entry := range layer.RenderedScheduleEntries {
+			start, err := time.ParseInLocation(time.RFC3339, entry.Start, location)
+			if err != nil {
+				return nil, err
+			}
+			end, err := time.ParseInLocation(time.RFC3339, entry.End, location)
+			if err != nil {
+				return nil, err
+			}
+			if !at.Before(start) && at.Before(end) {
+				return &entry.User, nil
+			}
+		}
+	}
+	return nil, nil
+}
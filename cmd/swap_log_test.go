@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_swapLogNotes(t *testing.T) {
+	john := api.User{ID: "USER_1", Summary: "John Doe"}
+	mary := api.User{ID: "USER_2", Summary: "Mary Jane"}
+	roger := api.User{ID: "USER_3", Summary: "Roger Solé"}
+	layers := []api.ScheduleLayer{
+		{
+			RenderedScheduleEntries: []api.RenderedScheduleEntry{
+				{Start: "2022-08-01T00:00:00Z", End: "2022-08-08T00:00:00Z", User: john},
+				{Start: "2022-08-08T00:00:00Z", End: "2022-08-15T00:00:00Z", User: mary},
+				{Start: "2022-08-15T00:00:00Z", End: "2022-08-22T00:00:00Z", User: roger},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		overrides []api.RenderedScheduleEntry
+		want      []string
+	}{
+		{
+			name: "No overrides",
+			want: []string{},
+		},
+		{
+			name: "Two users cover each other",
+			overrides: []api.RenderedScheduleEntry{
+				{Start: "2022-08-02T00:00:00Z", End: "2022-08-03T00:00:00Z", User: mary},
+				{Start: "2022-08-09T00:00:00Z", End: "2022-08-10T00:00:00Z", User: john},
+			},
+			want: []string{
+				"SWAP: Mary Jane covered John Doe from 2022-08-02 00:00 to 2022-08-03 00:00, " +
+					"John Doe covered Mary Jane from 2022-08-09 00:00 to 2022-08-10 00:00",
+			},
+		},
+		{
+			name: "One-way cover is not a swap",
+			overrides: []api.RenderedScheduleEntry{
+				{Start: "2022-08-02T00:00:00Z", End: "2022-08-03T00:00:00Z", User: mary},
+				{Start: "2022-08-16T00:00:00Z", End: "2022-08-17T00:00:00Z", User: john},
+			},
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduleInfo := &api.ScheduleInfo{
+				ID:                  "SCHED_1",
+				Location:            time.UTC,
+				ScheduleLayers:      layers,
+				OverrideSubschedule: api.ScheduleLayer{RenderedScheduleEntries: tt.overrides},
+			}
+
+			notes, err := swapLogNotes(scheduleInfo)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, notes)
+		})
+	}
+}
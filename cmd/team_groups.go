@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+// unassignedTeam groups the users that don't belong to any team.
+const unassignedTeam = "Unassigned"
+
+var groupUsersByTeam bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&groupUsersByTeam, "group-users-by-team", false, "group the users of each schedule by PagerDuty team, with a subtotal per team")
+}
+
+// setTeamGroups groups the users of each schedule by their PagerDuty team, keeping their order
+// within each team. Teams are sorted by name, with the users without a team last. Users in
+// several teams are grouped in the first one PagerDuty lists.
+func (pd *pagerDutyClient) setTeamGroups(data *report.PrintableData) error {
+	if len(pd.cachedUsers) == 0 {
+		err := pd.loadUsersInMemoryCache()
+		if err != nil {
+			return fmt.Errorf("failed to get the users' teams: %w", err)
+		}
+	}
+
+	userTeams := make(map[string]string)
+	for _, user := range pd.cachedUsers {
+		if len(user.Teams) == 0 {
+			continue
+		}
+		team := user.Teams[0].Name
+		if team == "" {
+			team = user.Teams[0].ID
+		}
+		userTeams[strings.ToLower(user.Email)] = team
+	}
+
+	for _, scheduleData := range data.SchedulesData {
+		groups := make(map[string]*report.TeamGroup)
+		for _, user := range scheduleData.RotaUsers {
+			team, ok := userTeams[strings.ToLower(user.EmailAddress)]
+			if !ok {
+				team = unassignedTeam
+			}
+			if _, ok := groups[team]; !ok {
+				groups[team] = &report.TeamGroup{Name: team}
+			}
+			groups[team].Users = append(groups[team].Users, user)
+		}
+
+		scheduleData.TeamGroups = make([]report.TeamGroup, 0, len(groups))
+		for _, group := range groups {
+			scheduleData.TeamGroups = append(scheduleData.TeamGroups, *group)
+		}
+		sort.Slice(scheduleData.TeamGroups, func(i, j int) bool {
+			a, b := scheduleData.TeamGroups[i].Name, scheduleData.TeamGroups[j].Name
+			if (a == unassignedTeam) != (b == unassignedTeam) {
+				return b == unassignedTeam
+			}
+			return a < b
+		})
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_pagerDutyClient_setTeamGroups(t *testing.T) {
+	tests := []struct {
+		name      string
+		mockSetup func(*clientMock)
+		want      map[string][]string
+		wantOrder []string
+		wantErr   bool
+	}{
+		{
+			name: "Users grouped by team",
+			mockSetup: func(mock *clientMock) {
+				mock.On("ListUsers").Once().Return([]*api.User{
+					{ID: "USER_1", Email: "john@email.com", Teams: []api.Team{{ID: "TEAM_2", Name: "Platform"}, {ID: "TEAM_1", Name: "Database"}}},
+					{ID: "USER_2", Email: "Mary@email.com", Teams: []api.Team{{ID: "TEAM_1", Name: "Database"}}},
+					{ID: "USER_3", Email: "roger@email.com"},
+					{ID: "USER_4", Email: "ann@email.com", Teams: []api.Team{{ID: "TEAM_2", Name: "Platform"}}},
+				}, nil)
+			},
+			wantOrder: []string{"Database", "Platform", "Unassigned"},
+			want: map[string][]string{
+				"Database":   {"Mary Jane"},
+				"Platform":   {"John Doe", "Ann Lee"},
+				"Unassigned": {"Roger Solé", "Will Smith"},
+			},
+		},
+		{
+			name: "Fails to list the users",
+			mockSetup: func(mock *clientMock) {
+				mock.On("ListUsers").Once().Return(nil, errors.New("failed"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedClient := &clientMock{}
+			tt.mockSetup(mockedClient)
+
+			pd := pagerDutyClient{client: mockedClient}
+			scheduleData := &report.ScheduleData{
+				ID: "SCHED_1",
+				RotaUsers: []*report.ScheduleUser{
+					{Name: "John Doe", EmailAddress: "john@email.com", TotalAmount: 10},
+					{Name: "Roger Solé", EmailAddress: "roger@email.com"},
+					{Name: "Mary Jane", EmailAddress: "mary@email.com"},
+					{Name: "Ann Lee", EmailAddress: "ann@email.com", TotalAmount: 5.5},
+					{Name: "Will Smith", EmailAddress: "will@email.com"},
+				},
+			}
+
+			err := pd.setTeamGroups(&report.PrintableData{SchedulesData: []*report.ScheduleData{scheduleData}})
+			mockedClient.AssertExpectations(t)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			order := make([]string, 0)
+			for _, group := range scheduleData.TeamGroups {
+				order = append(order, group.Name)
+				names := make([]string, 0)
+				for _, user := range group.Users {
+					names = append(names, user.Name)
+				}
+				assert.Equal(t, tt.want[group.Name], names)
+			}
+			assert.Equal(t, tt.wantOrder, order)
+			assert.Equal(t, float32(15.5), scheduleData.TeamGroups[1].TotalAmount())
+		})
+	}
+}
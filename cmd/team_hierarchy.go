@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const teamPathColumn = "team_path"
+
+var includeTeamHierarchy bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includeTeamHierarchy, "include-team-hierarchy", false, "add a team_path column with the parent teams of each user's team, e.g. Engineering > SRE > Production")
+}
+
+// teamPath returns the names of the team and its parents, from the top level team down.
+func teamPath(teamID string, teamsByID map[string]*api.Team) string {
+	names := make([]string, 0)
+	visited := make(map[string]bool)
+	for id := teamID; id != "" && !visited[id]; {
+		visited[id] = true
+		team, ok := teamsByID[id]
+		if !ok {
+			break
+		}
+		names = append([]string{team.Name}, names...)
+		id = team.ParentID
+	}
+	return strings.Join(names, " > ")
+}
+
+// setTeamPaths sets the team path of each user, for cost allocation across the organisation.
+// Users in several teams get the path of the first one PagerDuty lists.
+func (pd *pagerDutyClient) setTeamPaths(data *report.PrintableData) error {
+	teams, err := pd.client.ListTeams()
+	if err != nil {
+		return fmt.Errorf("failed to get the team hierarchy: %w", err)
+	}
+	teamsByID := make(map[string]*api.Team, len(teams))
+	for _, team := range teams {
+		teamsByID[team.ID] = team
+	}
+
+	if len(pd.cachedUsers) == 0 {
+		err := pd.loadUsersInMemoryCache()
+		if err != nil {
+			return fmt.Errorf("failed to get the users' teams: %w", err)
+		}
+	}
+	pathsByEmail := make(map[string]string)
+	for _, user := range pd.cachedUsers {
+		if len(user.Teams) > 0 {
+			pathsByEmail[strings.ToLower(user.Email)] = teamPath(user.Teams[0].ID, teamsByID)
+		}
+	}
+
+	users := data.UsersSchedulesSummary
+	for _, scheduleData := range data.SchedulesData {
+		users = append(users[:len(users):len(users)], scheduleData.RotaUsers...)
+	}
+	for _, user := range users {
+		setExtraValue(user, teamPathColumn, pathsByEmail[strings.ToLower(user.EmailAddress)])
+	}
+	return nil
+}
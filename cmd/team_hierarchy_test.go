@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_teamPath(t *testing.T) {
+	teamsByID := map[string]*api.Team{
+		"ENG":  {ID: "ENG", Name: "Engineering"},
+		"SRE":  {ID: "SRE", Name: "SRE", ParentID: "ENG"},
+		"PROD": {ID: "PROD", Name: "Production", ParentID: "SRE"},
+		"LOOP": {ID: "LOOP", Name: "Loop", ParentID: "LOOP"},
+	}
+
+	assert.Equal(t, "Engineering > SRE > Production", teamPath("PROD", teamsByID))
+	assert.Equal(t, "Engineering", teamPath("ENG", teamsByID))
+	assert.Equal(t, "Loop", teamPath("LOOP", teamsByID))
+	assert.Equal(t, "", teamPath("UNKNOWN", teamsByID))
+}
+
+func Test_pagerDutyClient_setTeamPaths(t *testing.T) {
+	tests := []struct {
+		name      string
+		mockSetup func(*clientMock)
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name: "Users with and without team",
+			mockSetup: func(mock *clientMock) {
+				mock.On("ListTeams").Once().Return([]*api.Team{
+					{ID: "ENG", Name: "Engineering"},
+					{ID: "SRE", Name: "SRE", ParentID: "ENG"},
+				}, nil)
+				mock.On("ListUsers").Once().Return([]*api.User{
+					{ID: "USER_1", Email: "john@email.com", Teams: []api.Team{{ID: "SRE"}}},
+					{ID: "USER_2", Email: "mary@email.com"},
+				}, nil)
+			},
+			want: []string{"Engineering > SRE", "Engineering > SRE", ""},
+		},
+		{
+			name: "Fails to list the teams",
+			mockSetup: func(mock *clientMock) {
+				mock.On("ListTeams").Once().Return(nil, errors.New("failed"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedClient := &clientMock{}
+			tt.mockSetup(mockedClient)
+
+			pd := pagerDutyClient{client: mockedClient}
+			john := &report.ScheduleUser{Name: "John Doe", EmailAddress: "John@email.com"}
+			mary := &report.ScheduleUser{Name: "Mary Jane", EmailAddress: "mary@email.com"}
+			summary := &report.ScheduleUser{Name: "John Doe", EmailAddress: "John@email.com"}
+			data := &report.PrintableData{
+				SchedulesData:         []*report.ScheduleData{{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{john, mary}}},
+				UsersSchedulesSummary: []*report.ScheduleUser{summary},
+			}
+
+			err := pd.setTeamPaths(data)
+			mockedClient.AssertExpectations(t)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, []string{
+				summary.ExtraValues[teamPathColumn], john.ExtraValues[teamPathColumn], mary.ExtraValues[teamPathColumn],
+			})
+		})
+	}
+}
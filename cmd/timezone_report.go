@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+)
+
+var timezoneReportCmd = &cobra.Command{
+	Use:   "timezone-report",
+	Short: "list schedules on PagerDuty grouped by time zone",
+	Long:  "Get the schedules configured in PagerDuty grouped by their time zone, with the number of users of each one",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, closeClient, err := newPagerDutyAPIClient()
+		if err != nil {
+			return err
+		}
+		defer closeClient()
+		pd := &pagerDutyClient{client: client}
+		return pd.timezoneReport(os.Stdout)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(timezoneReportCmd)
+}
+
+func (pd *pagerDutyClient) timezoneReport(w io.Writer) error {
+	schedules, err := pd.client.ListSchedules()
+	if err != nil {
+		return err
+	}
+
+	schedulesByTimezone := make(map[string][]*api.Schedule)
+	for _, schedule := range schedules {
+		schedulesByTimezone[schedule.TimeZone] = append(schedulesByTimezone[schedule.TimeZone], schedule)
+	}
+
+	timezones := make([]string, 0, len(schedulesByTimezone))
+	for timezone := range schedulesByTimezone {
+		timezones = append(timezones, timezone)
+	}
+	sort.Strings(timezones)
+
+	fmt.Fprintln(w, fmt.Sprintf("==== Found %d schedule(s) in %d time zone(s) ====", len(schedules), len(timezones)))
+	for _, timezone := range timezones {
+		timezoneSchedules := schedulesByTimezone[timezone]
+		sort.Slice(timezoneSchedules, func(i, j int) bool {
+			return timezoneSchedules[i].Name < timezoneSchedules[j].Name
+		})
+
+		// users in several schedules of the time zone are counted once
+		users := make(map[string]bool)
+		for _, schedule := range timezoneSchedules {
+			for _, userID := range schedule.UserIDs {
+				users[userID] = true
+			}
+		}
+
+		fmt.Fprintln(w, fmt.Sprintf("%s (%d schedule(s), %d user(s))", timezone, len(timezoneSchedules), len(users)))
+		for _, schedule := range timezoneSchedules {
+			fmt.Fprintln(w, fmt.Sprintf("  [%s] %-20s, Users: %d", schedule.ID, schedule.Name, len(schedule.UserIDs)))
+		}
+	}
+
+	return nil
+}
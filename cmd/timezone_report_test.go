@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_timezoneReport(t *testing.T) {
+	tests := []struct {
+		name      string
+		mockSetup func(*clientMock)
+		want      string
+		wantErr   bool
+	}{
+		{
+			name: "Successfully group pagerduty schedules by time zone",
+			mockSetup: func(clientMock *clientMock) {
+				clientMock.On("ListSchedules", mock.Anything).Once().Return([]*api.Schedule{
+					{ID: "SCHED_1", Name: "Platform", TimeZone: "Europe/London", UserIDs: []string{"USER_1", "USER_2"}},
+					{ID: "SCHED_2", Name: "Database", TimeZone: "America/New_York", UserIDs: []string{"USER_3"}},
+					{ID: "SCHED_3", Name: "Networking", TimeZone: "Europe/London", UserIDs: []string{"USER_2", "USER_4"}},
+				}, nil)
+			},
+			want: "==== Found 3 schedule(s) in 2 time zone(s) ====\n" +
+				"America/New_York (1 schedule(s), 1 user(s))\n" +
+				"  [SCHED_2] Database            , Users: 1\n" +
+				"Europe/London (2 schedule(s), 3 user(s))\n" +
+				"  [SCHED_3] Networking          , Users: 2\n" +
+				"  [SCHED_1] Platform            , Users: 2\n",
+		},
+		{
+			name: "Failed to list pagerduty schedules",
+			mockSetup: func(clientMock *clientMock) {
+				clientMock.On("ListSchedules", mock.Anything).Once().Return(nil, errors.New("failed to list"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedClient := &clientMock{}
+			tt.mockSetup(mockedClient)
+
+			pd := pagerDutyClient{client: mockedClient}
+			var out bytes.Buffer
+			err := pd.timezoneReport(&out)
+			mockedClient.AssertExpectations(t)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const latestReleaseURL = "https://api.github.com/repos/b3ngriffiths/go-pagerduty-oncall-report/releases/latest"
+
+var checkForUpdates bool
+
+// describeSuffix matches the commit count and hash git describe appends to versions built after a tag.
+var describeSuffix = regexp.MustCompile(`-\d+-g[0-9a-f]+$`)
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&checkForUpdates, "check-for-updates", false, "check GitHub for a newer release, then exit without generating the report")
+}
+
+type release struct {
+	TagName string `json:"tag_name"`
+	HTMLURL string `json:"html_url"`
+}
+
+// checkLatestRelease prints whether a release newer than the running version is available.
+func checkLatestRelease(w io.Writer, url string) error {
+	client := &http.Client{Timeout: 10 * time.Second}
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to check for updates: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to check for updates: %s", resp.Status)
+	}
+
+	var latest release
+	if err := json.NewDecoder(resp.Body).Decode(&latest); err != nil {
+		return fmt.Errorf("failed to read the latest release: %w", err)
+	}
+
+	if _, ok := parseVersion(version); !ok {
+		fmt.Fprintf(w, "development build %s, the latest release is %s at %s\n", version, latest.TagName, latest.HTMLURL)
+	} else if isNewerVersion(latest.TagName, version) {
+		fmt.Fprintf(w, "%s is available at %s\n", latest.TagName, latest.HTMLURL)
+	} else {
+		fmt.Fprintln(w, "up to date")
+	}
+	return nil
+}
+
+// isNewerVersion compares two vMAJOR.MINOR.PATCH versions. A current version that isn't one,
+// like a dev build, is considered older than any release.
+func isNewerVersion(latest, current string) bool {
+	latestParts, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	currentParts, ok := parseVersion(current)
+	if !ok {
+		return true
+	}
+
+	for i := range latestParts {
+		if latestParts[i] != currentParts[i] {
+			return latestParts[i] > currentParts[i]
+		}
+	}
+	return false
+}
+
+// parseVersion parses a vMAJOR.MINOR.PATCH version, ignoring the suffix git describe adds to builds
+// after a tag or with local changes, like v1.2.3-4-gabcdef0-dirty.
+func parseVersion(version string) ([3]int, bool) {
+	var parts [3]int
+	version = strings.TrimSuffix(version, "-dirty")
+	if match := describeSuffix.FindStringIndex(version); match != nil {
+		version = version[:match[0]]
+	}
+	fields := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(fields) != len(parts) {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
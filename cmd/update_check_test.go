@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkLatestRelease(t *testing.T) {
+	defer func(current string) { version = current }(version)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"tag_name":"v1.2.3","html_url":"https://github.com/b3ngriffiths/go-pagerduty-oncall-report/releases/tag/v1.2.3"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		version string
+		want    string
+	}{
+		{name: "Older version", version: "v1.2.0", want: "v1.2.3 is available at https://github.com/b3ngriffiths/go-pagerduty-oncall-report/releases/tag/v1.2.3\n"},
+		{name: "Same version", version: "v1.2.3", want: "up to date\n"},
+		{name: "Newer version", version: "v1.10.0", want: "up to date\n"},
+		{name: "Build after the latest release", version: "v1.2.3-4-gabcdef0", want: "up to date\n"},
+		{name: "Build with local changes", version: "v1.2.3-4-gabcdef0-dirty", want: "up to date\n"},
+		{name: "Build after an older release", version: "v1.2.0-4-gabcdef0-dirty", want: "v1.2.3 is available at https://github.com/b3ngriffiths/go-pagerduty-oncall-report/releases/tag/v1.2.3\n"},
+		{name: "Dev build", version: "dev", want: "development build dev, the latest release is v1.2.3 at https://github.com/b3ngriffiths/go-pagerduty-oncall-report/releases/tag/v1.2.3\n"},
+		{name: "Untagged build", version: "abcdef0-dirty", want: "development build abcdef0-dirty, the latest release is v1.2.3 at https://github.com/b3ngriffiths/go-pagerduty-oncall-report/releases/tag/v1.2.3\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version = tt.version
+			var out bytes.Buffer
+
+			err := checkLatestRelease(&out, server.URL)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
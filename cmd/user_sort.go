@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const defaultUserSortKey = "alphabetical"
+
+var userOrder string
+
+func init() {
+	scheduleReportCmd.Flags().StringVar(&userOrder, "user-order", "", "order of the users within each schedule, unless configured for the schedule: alphabetical (default), hours_desc, hours_asc, amount_desc, amount_asc, api")
+}
+
+var userSortKeys = map[string]func(a, b *report.ScheduleUser) bool{
+	"alphabetical": func(a, b *report.ScheduleUser) bool { return a.Name < b.Name },
+	"hours_desc":   func(a, b *report.ScheduleUser) bool { return a.NumTotalHours() > b.NumTotalHours() },
+	"hours_asc":    func(a, b *report.ScheduleUser) bool { return a.NumTotalHours() < b.NumTotalHours() },
+	"amount_desc":  func(a, b *report.ScheduleUser) bool { return a.TotalAmount > b.TotalAmount },
+	"amount_asc":   func(a, b *report.ScheduleUser) bool { return a.TotalAmount < b.TotalAmount },
+	"api":          nil, // keeps the order the users first appear in the PagerDuty entries
+}
+
+// scheduleUserSortKey returns the user sort key configured for the schedule, falling back to
+// --user-order and then to the default one.
+func scheduleUserSortKey(scheduleID string) string {
+	settings := Config.FindScheduleSettingsByID(scheduleID)
+	if settings != nil && settings.UserSortKey != "" {
+		return settings.UserSortKey
+	}
+	if userOrder != "" {
+		return userOrder
+	}
+	return defaultUserSortKey
+}
+
+// scheduleUserIDs returns the IDs of the users on call in the schedule, in the order they
+// first appear in the PagerDuty entries.
+func scheduleUserIDs(scheduleInfo *api.ScheduleInfo) []string {
+	userIDs := make([]string, 0)
+	for _, entry := range scheduleInfo.FinalSchedule.RenderedScheduleEntries {
+		if !contains(userIDs, entry.User.ID) {
+			userIDs = append(userIDs, entry.User.ID)
+		}
+	}
+	return userIDs
+}
+
+// sortScheduleUsers orders the users of a schedule by the given key. Users are first sorted
+// by name so ties are always resolved the same way, whatever the API response order was.
+func sortScheduleUsers(users []*report.ScheduleUser, sortKey string) error {
+	less, ok := userSortKeys[sortKey]
+	if !ok {
+		return fmt.Errorf("user sort key %s not supported", sortKey)
+	}
+	if less == nil {
+		return nil
+	}
+
+	sort.SliceStable(users, func(i, j int) bool {
+		return userSortKeys[defaultUserSortKey](users[i], users[j])
+	})
+	sort.SliceStable(users, func(i, j int) bool {
+		return less(users[i], users[j])
+	})
+	return nil
+}
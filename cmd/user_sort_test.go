@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sortScheduleUsers(t *testing.T) {
+	newUsers := func() []*report.ScheduleUser {
+		return []*report.ScheduleUser{
+			{Name: "Charlie", NumWorkHours: 10, NumWeekendHours: 2, TotalAmount: 40},
+			{Name: "Alice", NumWorkHours: 4, TotalAmount: 40},
+			{Name: "Bob", NumWorkHours: 20, NumBankHolidaysHours: 1, TotalAmount: 15},
+			{Name: "Dave", NumWorkHours: 4, TotalAmount: 10},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		sortKey string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "Alphabetical order",
+			sortKey: "alphabetical",
+			want:    []string{"Alice", "Bob", "Charlie", "Dave"},
+		},
+		{
+			name:    "Hours descending",
+			sortKey: "hours_desc",
+			want:    []string{"Bob", "Charlie", "Alice", "Dave"},
+		},
+		{
+			name:    "Hours ascending keeps ties in alphabetical order",
+			sortKey: "hours_asc",
+			want:    []string{"Alice", "Dave", "Charlie", "Bob"},
+		},
+		{
+			name:    "Amount descending keeps ties in alphabetical order",
+			sortKey: "amount_desc",
+			want:    []string{"Alice", "Charlie", "Bob", "Dave"},
+		},
+		{
+			name:    "Amount ascending",
+			sortKey: "amount_asc",
+			want:    []string{"Dave", "Bob", "Alice", "Charlie"},
+		},
+		{
+			name:    "API order keeps the users as they are",
+			sortKey: "api",
+			want:    []string{"Charlie", "Alice", "Bob", "Dave"},
+		},
+		{
+			name:    "Unknown sort key",
+			sortKey: "random",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := newUsers()
+			err := sortScheduleUsers(users, tt.sortKey)
+
+			if tt.wantErr == true {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(users))
+			for _, user := range users {
+				names = append(names, user.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func Test_scheduleUserSortKey(t *testing.T) {
+	defer func() { userOrder = "" }()
+	setConfig(t, configuration.New())
+	Config.ScheduleSettings = []configuration.ScheduleSettings{{Id: "SCHED_1", UserSortKey: "hours_desc"}}
+
+	assert.Equal(t, "hours_desc", scheduleUserSortKey("SCHED_1"))
+	assert.Equal(t, "alphabetical", scheduleUserSortKey("SCHED_2"))
+
+	userOrder = "amount_desc"
+	assert.Equal(t, "hours_desc", scheduleUserSortKey("SCHED_1"))
+	assert.Equal(t, "amount_desc", scheduleUserSortKey("SCHED_2"))
+}
+
+func Test_scheduleUserIDs(t *testing.T) {
+	scheduleInfo := &api.ScheduleInfo{
+		FinalSchedule: api.ScheduleLayer{
+			RenderedScheduleEntries: []api.RenderedScheduleEntry{
+				{User: api.User{ID: "USER_2"}},
+				{User: api.User{ID: "USER_1"}},
+				{User: api.User{ID: "USER_2"}},
+				{User: api.User{ID: "USER_3"}},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"USER_2", "USER_1", "USER_3"}, scheduleUserIDs(scheduleInfo))
+}
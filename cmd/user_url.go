@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const userURLColumn = "user_url"
+
+var includeUserURL bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includeUserURL, "include-user-url", false, "add a user_url column with the link to each user's PagerDuty profile")
+}
+
+// userURL returns the profile of the user in the PagerDuty web app.
+func userURL(userID string) string {
+	return fmt.Sprintf("%s/users/%s", pagerDutyAppURL, userID)
+}
+
+// setSummaryUserURLs sets the profile of the users in the summary, which only know the users by name.
+func setSummaryUserURLs(data *report.PrintableData) {
+	urlsByUser := make(map[string]string)
+	for _, scheduleData := range data.SchedulesData {
+		for _, user := range scheduleData.RotaUsers {
+			urlsByUser[user.Name] = user.ExtraValues[userURLColumn]
+		}
+	}
+	for _, user := range data.UsersSchedulesSummary {
+		setExtraValue(user, userURLColumn, urlsByUser[user.Name])
+	}
+}
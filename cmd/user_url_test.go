@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_setSummaryUserURLs(t *testing.T) {
+	john := &report.ScheduleUser{Name: "John Doe"}
+	setExtraValue(john, userURLColumn, userURL("PJOHN01"))
+
+	data := &report.PrintableData{
+		SchedulesData:         []*report.ScheduleData{{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{john}}},
+		UsersSchedulesSummary: []*report.ScheduleUser{{Name: "John Doe"}, {Name: "Mary Jane"}},
+	}
+	setSummaryUserURLs(data)
+
+	assert.Equal(t, "https://app.pagerduty.com/users/PJOHN01", data.UsersSchedulesSummary[0].ExtraValues[userURLColumn])
+	assert.Equal(t, "", data.UsersSchedulesSummary[1].ExtraValues[userURLColumn])
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const utcOffsetColumn = "utc_offset"
+
+var includeTimezoneOffset bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&includeTimezoneOffset, "include-timezone-offset", false, "add a utc_offset column with the UTC offset of the schedule time zone while each user was on call, e.g. +01:00, or several separated by / when it changed")
+}
+
+// utcOffsets returns the UTC offsets of the location in effect during the periods, separated by / in the order
+// they apply.
+func utcOffsets(periods []*api.UserRotaPeriod, location *time.Location) string {
+	if location == nil {
+		location = time.UTC
+	}
+	offsets := make([]string, 0, 1)
+	add := func(t time.Time) {
+		offset := t.In(location).Format("-07:00")
+		if !contains(offsets, offset) {
+			offsets = append(offsets, offset)
+		}
+	}
+	for _, period := range periods {
+		if !period.End.After(period.Start) {
+			continue
+		}
+		// time zones change their offset on the hour or half hour, so checking every half hour finds the changes
+		for t := period.Start; t.Before(period.End); t = t.Add(30 * time.Minute) {
+			add(t)
+		}
+		add(period.End.Add(-time.Nanosecond))
+	}
+	return strings.Join(offsets, "/")
+}
+
+// setSummaryUTCOffsets sets the UTC offsets of the users in the summary, those of every schedule they were on call in.
+func setSummaryUTCOffsets(data *report.PrintableData) {
+	offsetsByUser := make(map[string][]string)
+	for _, scheduleData := range data.SchedulesData {
+		for _, user := range scheduleData.RotaUsers {
+			value := user.ExtraValues[utcOffsetColumn]
+			if value == "" {
+				continue
+			}
+			for _, offset := range strings.Split(value, "/") {
+				if !contains(offsetsByUser[user.Name], offset) {
+					offsetsByUser[user.Name] = append(offsetsByUser[user.Name], offset)
+				}
+			}
+		}
+	}
+	for _, user := range data.UsersSchedulesSummary {
+		setExtraValue(user, utcOffsetColumn, strings.Join(offsetsByUser[user.Name], "/"))
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_utcOffsets(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+	utc := func(m time.Month, d, h int) time.Time { return time.Date(2023, m, d, h, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name     string
+		periods  []*api.UserRotaPeriod
+		location *time.Location
+		want     string
+	}{
+		{name: "no periods", location: london, want: ""},
+		{
+			name:     "summer time",
+			periods:  []*api.UserRotaPeriod{{Start: utc(7, 3, 8), End: utc(7, 10, 8)}},
+			location: london,
+			want:     "+01:00",
+		},
+		{
+			name:     "across the end of summer time",
+			periods:  []*api.UserRotaPeriod{{Start: utc(10, 23, 8), End: utc(10, 30, 8)}},
+			location: london,
+			want:     "+01:00/+00:00",
+		},
+		{
+			name:     "half hour offset",
+			periods:  []*api.UserRotaPeriod{{Start: utc(7, 3, 8), End: utc(7, 3, 20)}},
+			location: kolkata,
+			want:     "+05:30",
+		},
+		{
+			name:    "no time zone",
+			periods: []*api.UserRotaPeriod{{Start: utc(7, 3, 8), End: utc(7, 3, 20)}},
+			want:    "+00:00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, utcOffsets(tt.periods, tt.location))
+		})
+	}
+}
+
+func Test_setSummaryUTCOffsets(t *testing.T) {
+	user := func(offsets string) *report.ScheduleUser {
+		return &report.ScheduleUser{Name: "John Doe", ExtraValues: map[string]string{utcOffsetColumn: offsets}}
+	}
+	summary := &report.ScheduleUser{Name: "John Doe"}
+	data := &report.PrintableData{
+		SchedulesData: []*report.ScheduleData{
+			{RotaUsers: []*report.ScheduleUser{user("+01:00/+00:00")}},
+			{RotaUsers: []*report.ScheduleUser{user("-05:00/+00:00")}},
+		},
+		UsersSchedulesSummary: []*report.ScheduleUser{summary},
+	}
+
+	setSummaryUTCOffsets(data)
+	assert.Equal(t, "+01:00/+00:00/-05:00", summary.ExtraValues[utcOffsetColumn])
+}
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+)
+
+var (
+	validateConfigOnly bool
+	watchConfig        bool
+)
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&validateConfigOnly, "validate-config", false, "validate the configuration file, then exit without generating the report")
+	scheduleReportCmd.Flags().BoolVar(&watchConfig, "watch-config", false, "validate the configuration file every time it changes, without generating the report")
+}
+
+// validateConfig returns the problems in the configuration that would make the report fail
+// or be wrong.
+func validateConfig(config *configuration.Configuration) []error {
+	var errs []error
+
+	for _, dayType := range dayTypes {
+		if _, err := config.FindPriceByDay(dayType); err != nil {
+			errs = append(errs, fmt.Errorf("rotationPrices: %w", err))
+		}
+	}
+	if config.RotationInfo.CheckRotationChangeEvery <= 0 {
+		errs = append(errs, fmt.Errorf("rotationInfo: checkRotationChangeEvery must be greater than 0"))
+	}
+	if config.RotationInfo.DailyRotationStartsAt < 0 || config.RotationInfo.DailyRotationStartsAt > 23 {
+		errs = append(errs, fmt.Errorf("rotationInfo: dailyRotationStartsAt must be an hour between 0 and 23"))
+	}
+	if _, ok := roundingModes[config.RoundingMode]; config.RoundingMode != "" && !ok {
+		errs = append(errs, fmt.Errorf("roundingMode: %s not supported", config.RoundingMode))
+	}
+	if hours := config.BusinessHours; hours.StartsAt < 0 || hours.EndsAt > 24 || hours.StartsAt > hours.EndsAt {
+		errs = append(errs, fmt.Errorf("businessHours: startsAt and endsAt must be hours between 0 and 24, startsAt first"))
+	}
+	errs = append(errs, validateTimeRange("reportTimeRange", config.ReportTimeRange.Start, config.ReportTimeRange.End)...)
+
+	for i, user := range config.RotationUsers {
+		if user.UserID == "" {
+			errs = append(errs, fmt.Errorf("rotationUsers[%d]: userId is required", i))
+		}
+		errs = append(errs, validatePrices(fmt.Sprintf("rotationUsers[%d]", i), user.Prices)...)
+	}
+
+	for i, override := range config.ScheduleTimeRangeOverrides {
+		errs = append(errs, validateTimeRange(fmt.Sprintf("scheduleTimeRangeOverrides[%d]", i), override.Start, override.End)...)
+	}
+
+	for i, settings := range config.ScheduleSettings {
+		field := fmt.Sprintf("scheduleSettings[%d]", i)
+		if _, ok := roundingModes[settings.RoundingMode]; settings.RoundingMode != "" && !ok {
+			errs = append(errs, fmt.Errorf("%s: rounding mode %s not supported", field, settings.RoundingMode))
+		}
+		if _, ok := userSortKeys[settings.UserSortKey]; settings.UserSortKey != "" && !ok {
+			errs = append(errs, fmt.Errorf("%s: user sort key %s not supported", field, settings.UserSortKey))
+		}
+		errs = append(errs, validatePrices(field, settings.Prices)...)
+		if _, err := parseWeekdays(settings.WeekendDefinition); err != nil {
+			errs = append(errs, fmt.Errorf("%s: weekendDefinition: %w", field, err))
+		}
+		if settings.TagHighAbove > 0 && settings.TagLowBelow > settings.TagHighAbove {
+			errs = append(errs, fmt.Errorf("%s: tagLowBelow must not be greater than tagHighAbove", field))
+		}
+		if settings.PaymentFrequency != "" && !contains(paymentFrequencies, settings.PaymentFrequency) {
+			errs = append(errs, fmt.Errorf("%s: payment frequency %s not supported, use %v", field, settings.PaymentFrequency, paymentFrequencies))
+		}
+		for _, layer := range settings.ExcludedLayers {
+			if layer < 1 {
+				errs = append(errs, fmt.Errorf("%s: excludedLayers: %d is not a layer index, they start at 1", field, layer))
+			}
+		}
+		if settings.RotationLength != "" {
+			if _, err := parseRotationLength(settings.RotationLength); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", field, err))
+			}
+		}
+	}
+
+	for i, entry := range config.ManualEntries {
+		field := fmt.Sprintf("manualEntries[%d]", i)
+		if entry.ScheduleId == "" {
+			errs = append(errs, fmt.Errorf("%s: scheduleId is required", field))
+		}
+		if entry.UserId == "" && entry.UserEmail == "" {
+			errs = append(errs, fmt.Errorf("%s: userId or userEmail is required", field))
+		}
+		if entry.Start == "" || entry.End == "" {
+			errs = append(errs, fmt.Errorf("%s: start and end are required", field))
+			continue
+		}
+		errs = append(errs, validateTimeRange(field, entry.Start, entry.End)...)
+	}
+
+	return errs
+}
+
+func validateTimeRange(field, start, end string) []error {
+	var errs []error
+	var startTime, endTime time.Time
+	var err error
+	if start != "" {
+		if startTime, err = time.Parse(time.RFC822, start); err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid start: %w", field, err))
+		}
+	}
+	if end != "" {
+		if endTime, err = time.Parse(time.RFC822, end); err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid end: %w", field, err))
+		}
+	}
+	if len(errs) == 0 && !startTime.IsZero() && !endTime.IsZero() && !endTime.After(startTime) {
+		errs = append(errs, fmt.Errorf("%s: end must be after start", field))
+	}
+	return errs
+}
+
+func validatePrices(field string, prices []configuration.RotationPriceDay) []error {
+	var errs []error
+	for _, price := range prices {
+		if !contains(dayTypes, price.Day) {
+			errs = append(errs, fmt.Errorf("%s: price day %s not supported, use %v", field, price.Day, dayTypes))
+		}
+	}
+	return errs
+}
+
+// loadConfigFile reads the configuration file again, independently of the loaded Config.
+func loadConfigFile(path string) (*configuration.Configuration, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	config := configuration.New()
+	if err := v.Unmarshal(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// printConfigValidation validates the configuration file, printing "config valid" or the
+// problems found, and reports whether it is valid.
+func printConfigValidation(w io.Writer, path string) bool {
+	config, err := loadConfigFile(path)
+	if err != nil {
+		fmt.Fprintf(w, "config invalid: %v\n", err)
+		return false
+	}
+
+	errs := validateConfig(config)
+	if len(errs) == 0 {
+		fmt.Fprintln(w, "config valid")
+		return true
+	}
+
+	fmt.Fprintf(w, "config invalid: %d error(s)\n", len(errs))
+	for _, err := range errs {
+		fmt.Fprintf(w, "  - %v\n", err)
+	}
+	return false
+}
+
+// runValidateConfig validates the configuration file once.
+func runValidateConfig(w io.Writer) error {
+	if !printConfigValidation(w, viper.ConfigFileUsed()) {
+		return fmt.Errorf("invalid configuration file %s", viper.ConfigFileUsed())
+	}
+	return nil
+}
+
+// runWatchConfig validates the configuration file every time it changes, until interrupted.
+func runWatchConfig(w io.Writer) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return watchConfigFile(ctx, w, viper.ConfigFileUsed())
+}
+
+func watchConfigFile(ctx context.Context, w io.Writer, path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch the configuration file: %w", err)
+	}
+	defer watcher.Close()
+
+	// Editors often replace the file instead of writing it, so the directory is watched.
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("failed to watch the configuration file: %w", err)
+	}
+
+	fmt.Fprintf(w, "Watching %s\n", path)
+	printConfigValidation(w, path)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) == path && event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+				printConfigValidation(w, path)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			return fmt.Errorf("failed to watch the configuration file: %w", err)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validConfig = `
+rotationInfo:
+  dailyRotationStartsAt: 8
+  checkRotationChangeEvery: 30
+rotationPrices:
+  currency: £
+  daysInfo:
+    - day: weekday
+      price: 1
+    - day: weekend
+      price: 2
+    - day: bankholiday
+      price: 3
+`
+
+func Test_printConfigValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   []string
+		valid  bool
+	}{
+		{
+			name:   "Valid configuration",
+			config: validConfig,
+			want:   []string{"config valid"},
+			valid:  true,
+		},
+		{
+			name:   "Not YAML",
+			config: "rotationInfo: [",
+			want:   []string{"config invalid"},
+		},
+		{
+			name: "Invalid settings",
+			config: validConfig + `
+roundingMode: half_odd
+rotationUsers:
+  - name: John Doe
+    prices:
+      - day: holiday
+        price: 5
+scheduleSettings:
+  - id: SCHED_1
+    userSortKey: random
+manualEntries:
+  - scheduleId: SCHED_1
+    userId: USER_1
+    start: 12 Jan 20 08:00 UTC
+    end: 10 Jan 20 08:00 UTC
+`,
+			want: []string{
+				"config invalid: 5 error(s)",
+				"roundingMode: half_odd not supported",
+				"rotationUsers[0]: userId is required",
+				"rotationUsers[0]: price day holiday not supported",
+				"scheduleSettings[0]: user sort key random not supported",
+				"manualEntries[0]: end must be after start",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.config), 0600))
+
+			var out bytes.Buffer
+			valid := printConfigValidation(&out, path)
+
+			assert.Equal(t, tt.valid, valid)
+			for _, want := range tt.want {
+				assert.Contains(t, out.String(), want)
+			}
+		})
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe to read while the watcher writes to it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func Test_watchConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(validConfig), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var out syncBuffer
+	done := make(chan error)
+	go func() { done <- watchConfigFile(ctx, &out, path) }()
+
+	require.Eventually(t, func() bool { return strings.Contains(out.String(), "config valid") }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, os.WriteFile(path, []byte(validConfig+"roundingMode: half_odd\n"), 0600))
+	require.Eventually(t, func() bool { return strings.Contains(out.String(), "roundingMode: half_odd not supported") }, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func Test_runValidateConfig_unloadableFile(t *testing.T) {
+	defer func(file string) { cfgFile = file }(cfgFile)
+	setConfig(t, nil)
+	cfgFile = filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte("rotationInfo: ["), 0o644))
+
+	require.Error(t, loadConfig())
+
+	var out bytes.Buffer
+	err := runValidateConfig(&out)
+	assert.ErrorContains(t, err, "invalid configuration file "+cfgFile)
+	assert.True(t, strings.HasPrefix(out.String(), "config invalid"))
+}
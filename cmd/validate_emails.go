@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"net/mail"
+)
+
+var (
+	validateEmailFormat bool
+	errorOnInvalidEmail bool
+)
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&validateEmailFormat, "validate-email-format", false, "warn about PagerDuty users whose email address is not RFC 5322 compliant")
+	scheduleReportCmd.Flags().BoolVar(&errorOnInvalidEmail, "error-on-invalid-email", false, "abort the report when a PagerDuty user has a malformed email address")
+}
+
+// validateUserEmails checks that every PagerDuty user has a well-formed email address, as
+// malformed ones make the payroll imports of the report fail. It only returns an error for
+// malformed addresses when failOnInvalid is set.
+func (pd *pagerDutyClient) validateUserEmails(failOnInvalid bool) error {
+	if len(pd.cachedUsers) == 0 {
+		err := pd.loadUsersInMemoryCache()
+		if err != nil {
+			return fmt.Errorf("failed to validate user emails: %w", err)
+		}
+	}
+
+	invalid := make([]string, 0)
+	for _, user := range pd.cachedUsers {
+		address, err := mail.ParseAddress(user.Email)
+		if err != nil || address.Address != user.Email {
+			log.Printf("WARNING: user '%s' (%s) has a malformed email address: '%s'", user.Name, user.ID, user.Email)
+			invalid = append(invalid, user.ID)
+		}
+	}
+
+	if failOnInvalid && len(invalid) > 0 {
+		return fmt.Errorf("found %d user(s) with a malformed email address: %v", len(invalid), invalid)
+	}
+	return nil
+}
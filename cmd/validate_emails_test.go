@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/api"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_pagerDutyClient_validateUserEmails(t *testing.T) {
+	validUsers := []*api.User{
+		{ID: "1", Name: "John Doe", Email: "john.doe@email.com"},
+		{ID: "2", Name: "Mary Jane", Email: "mary+oncall@sub.email.co.uk"},
+	}
+	invalidUsers := []*api.User{
+		{ID: "1", Name: "John Doe", Email: "john.doe@email.com"},
+		{ID: "2", Name: "Mary Jane", Email: "mary jane@email.com"},
+		{ID: "3", Name: "No Domain", Email: "nodomain"},
+		{ID: "4", Name: "Display Name", Email: "Display Name <display@email.com>"},
+	}
+
+	tests := []struct {
+		name          string
+		mockSetup     func(*clientMock)
+		failOnInvalid bool
+		wantErr       bool
+	}{
+		{
+			name: "All emails are valid",
+			mockSetup: func(mock *clientMock) {
+				mock.On("ListUsers").Once().Return(validUsers, nil)
+			},
+			failOnInvalid: true,
+		},
+		{
+			name: "Malformed emails only log a warning by default",
+			mockSetup: func(mock *clientMock) {
+				mock.On("ListUsers").Once().Return(invalidUsers, nil)
+			},
+		},
+		{
+			name: "Malformed emails fail when configured to",
+			mockSetup: func(mock *clientMock) {
+				mock.On("ListUsers").Once().Return(invalidUsers, nil)
+			},
+			failOnInvalid: true,
+			wantErr:       true,
+		},
+		{
+			name: "Fails to load users",
+			mockSetup: func(mock *clientMock) {
+				mock.On("ListUsers").Once().Return(nil, errors.New("failed"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedClient := &clientMock{}
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockedClient)
+			}
+
+			pd := pagerDutyClient{client: mockedClient}
+			err := pd.validateUserEmails(tt.failOnInvalid)
+			mockedClient.AssertExpectations(t)
+
+			if tt.wantErr == true {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
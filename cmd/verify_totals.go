@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+var verifyReportTotals bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&verifyReportTotals, "verify-totals", false, "re-sum the amounts of each user, schedule and the grand total independently, warning about any difference with the displayed totals")
+}
+
+// minorUnits returns the amount in the smallest unit of the currency, e.g. pennies, so amounts are compared
+// to the decimal places of the currency regardless of float errors.
+func minorUnits(amount float64, decimals int) int64 {
+	return int64(math.Round(amount * math.Pow10(decimals)))
+}
+
+// verifyTotals re-sums the amounts of the report, and the service amounts paid in each schedule, in float64
+// and returns a description of each total that doesn't match: the total of each user in a schedule, of each
+// user in the summary and the grand total.
+func verifyTotals(data *report.PrintableData, amounts scheduleServiceAmounts) []string {
+	decimals := amountDecimals()
+	discrepancies := make([]string, 0)
+	check := func(description string, displayed float32, expected float64) {
+		if difference := minorUnits(float64(displayed), decimals) - minorUnits(expected, decimals); difference != 0 {
+			discrepancies = append(discrepancies, fmt.Sprintf("%s is %.*f, the amounts add up to %.*f (difference %.*f)",
+				description, decimals, displayed, decimals, expected, decimals, float64(difference)/math.Pow10(decimals)))
+		}
+	}
+
+	totalsByUser := make(map[string]float64)
+	var grandTotal float64
+	for _, scheduleData := range data.SchedulesData {
+		for _, user := range scheduleData.RotaUsers {
+			expected := float64(user.TotalAmountWorkHours) + float64(user.TotalAmountWeekendHours) + float64(user.TotalAmountBankHolidaysHours)
+			expected += float64(amounts[scheduleData.ID][user.Name])
+			check(fmt.Sprintf("total of %s in schedule '%s'", user.Name, scheduleData.ID), user.TotalAmount, expected)
+			totalsByUser[user.Name] += float64(user.TotalAmount)
+			grandTotal += float64(user.TotalAmount)
+		}
+	}
+	for _, user := range data.UsersSchedulesSummary {
+		check(fmt.Sprintf("summary total of %s", user.Name), user.TotalAmount, totalsByUser[user.Name])
+	}
+	check("grand total", grandTotalAmount(data), grandTotal)
+	return discrepancies
+}
+
+func logTotalDiscrepancies(data *report.PrintableData, amounts scheduleServiceAmounts) {
+	for _, discrepancy := range verifyTotals(data, amounts) {
+		log.Printf("WARNING: %s", discrepancy)
+	}
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_verifyTotals(t *testing.T) {
+	setConfig(t, configuration.New())
+	Config.RotationPrices.Currency = "£"
+
+	tests := []struct {
+		name              string
+		scheduleUserTotal float32
+		summaryTotal      float32
+		want              []string
+	}{
+		{name: "totals match", scheduleUserTotal: 30.02, summaryTotal: 40.02, want: []string{}},
+		{
+			name:              "schedule total off by a penny",
+			scheduleUserTotal: 30.03,
+			summaryTotal:      40.03,
+			want:              []string{"total of John Doe in schedule 'SCHED_1' is 30.03, the amounts add up to 30.02 (difference 0.01)"},
+		},
+		{
+			name:              "summary total doesn't add up",
+			scheduleUserTotal: 30.02,
+			summaryTotal:      40,
+			want: []string{
+				"summary total of John Doe is 40.00, the amounts add up to 40.02 (difference -0.02)",
+				"grand total is 40.00, the amounts add up to 40.02 (difference -0.02)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &report.PrintableData{
+				SchedulesData: []*report.ScheduleData{
+					{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{{
+						Name: "John Doe", TotalAmountWorkHours: 10.01, TotalAmountWeekendHours: 20.01, TotalAmount: tt.scheduleUserTotal,
+					}}},
+					{ID: "SCHED_2", RotaUsers: []*report.ScheduleUser{{
+						Name: "John Doe", TotalAmountWorkHours: 5, TotalAmount: 10,
+						ExtraValues: map[string]string{serviceAmountColumn: "£5.00 GBP"},
+					}}},
+				},
+				UsersSchedulesSummary: []*report.ScheduleUser{{Name: "John Doe", TotalAmount: tt.summaryTotal}},
+			}
+
+			amounts := scheduleServiceAmounts{"SCHED_2": {"John Doe": 5}}
+
+			assert.Equal(t, tt.want, verifyTotals(data, amounts))
+		})
+	}
+}
+
+func Test_verifyTotals_currencyDecimals(t *testing.T) {
+	tests := []struct {
+		name       string
+		currency   string
+		workAmount float32
+		total      float32
+		want       []string
+	}{
+		{name: "Zero decimals rounded to the unit", currency: "JPY", workAmount: 994, total: 1000.4, want: []string{}},
+		{
+			name:       "Zero decimals off by a unit",
+			currency:   "JPY",
+			workAmount: 994,
+			total:      1001,
+			want:       []string{"total of John Doe in schedule 'SCHED_1' is 1001, the amounts add up to 1000 (difference 1)"},
+		},
+		{
+			name:       "Three decimals off by a fils",
+			currency:   "KWD",
+			workAmount: 4,
+			total:      10.001,
+			want:       []string{"total of John Doe in schedule 'SCHED_1' is 10.001, the amounts add up to 10.000 (difference 0.001)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConfig(t, configuration.New())
+			Config.RotationPrices.Currency = tt.currency
+			data := &report.PrintableData{
+				SchedulesData: []*report.ScheduleData{
+					{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{{
+						Name: "John Doe", TotalAmountWorkHours: tt.workAmount, TotalAmount: tt.total,
+					}}},
+				},
+				UsersSchedulesSummary: []*report.ScheduleUser{{Name: "John Doe", TotalAmount: tt.total}},
+			}
+			amounts := scheduleServiceAmounts{"SCHED_1": {"John Doe": 6}}
+
+			assert.Equal(t, tt.want, verifyTotals(data, amounts))
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// scheduleWeekendDays returns the weekend days configured for the schedule, or nil for Saturday and Sunday.
+func scheduleWeekendDays(scheduleID string) ([]time.Weekday, error) {
+	settings := Config.FindScheduleSettingsByID(scheduleID)
+	if settings == nil {
+		return nil, nil
+	}
+	return parseWeekdays(settings.WeekendDefinition)
+}
+
+// parseWeekdays converts day names, e.g. "Friday", into weekdays, ignoring the case.
+func parseWeekdays(names []string) ([]time.Weekday, error) {
+	weekdays := make([]time.Weekday, 0, len(names))
+	for _, name := range names {
+		weekday, err := parseWeekday(name)
+		if err != nil {
+			return nil, err
+		}
+		weekdays = append(weekdays, weekday)
+	}
+	if len(weekdays) == 0 {
+		return nil, nil
+	}
+	return weekdays, nil
+}
+
+func parseWeekday(name string) (time.Weekday, error) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(weekday.String(), strings.TrimSpace(name)) {
+			return weekday, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown day %q", name)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseWeekdays(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		want    []time.Weekday
+		wantErr bool
+	}{
+		{name: "no days", names: nil, want: nil},
+		{name: "day names", names: []string{"Friday", "saturday"}, want: []time.Weekday{time.Friday, time.Saturday}},
+		{name: "unknown day", names: []string{"Friday", "Fri"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWeekdays(tt.names)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_updateDataForDate_weekendDefinition(t *testing.T) {
+	setConfig(t, configuration.New())
+	Config.ScheduleSettings = []configuration.ScheduleSettings{{Id: "SCHED_1", WeekendDefinition: []string{"Friday", "Saturday"}}}
+
+	weekendDays, err := scheduleWeekendDays("SCHED_1")
+	require.NoError(t, err)
+	calendar := &configuration.BHCalendar{WeekendDays: weekendDays}
+
+	data := &report.ScheduleUser{}
+	for _, date := range []time.Time{
+		time.Date(2024, time.January, 5, 12, 0, 0, 0, time.UTC), // Friday
+		time.Date(2024, time.January, 6, 12, 0, 0, 0, time.UTC), // Saturday
+		time.Date(2024, time.January, 7, 12, 0, 0, 0, time.UTC), // Sunday
+	} {
+		updateDataForDate(calendar, data, time.January, date, 1)
+	}
+
+	assert.Equal(t, float32(2), data.NumWeekendHours)
+	assert.Equal(t, float32(1), data.NumWorkHours)
+
+	weekendDays, err = scheduleWeekendDays("SCHED_2")
+	require.NoError(t, err)
+	assert.Nil(t, weekendDays)
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+)
+
+const (
+	workweekFractionColumn = "frac_of_workweek"
+	workweekHours          = 40.0
+)
+
+var normalizeToWorkweek bool
+
+func init() {
+	scheduleReportCmd.Flags().BoolVar(&normalizeToWorkweek, "normalize-to-40h-week", false, "add a frac_of_workweek column with the hours on call of each user divided by a 40 hour work week")
+}
+
+// workweekFraction returns the hours on call of the user as a fraction of a 40 hour work week, which over
+// a month is the number of full work weeks the on-call time is equivalent to.
+func workweekFraction(user *report.ScheduleUser) float32 {
+	return (user.NumWorkHours + user.NumWeekendHours + user.NumBankHolidaysHours) / workweekHours
+}
+
+// setWorkweekFractions sets the work week fraction of the users of each schedule and of the summary.
+func setWorkweekFractions(data *report.PrintableData) {
+	users := data.UsersSchedulesSummary
+	for _, scheduleData := range data.SchedulesData {
+		users = append(users[:len(users):len(users)], scheduleData.RotaUsers...)
+	}
+	for _, user := range users {
+		setExtraValue(user, workweekFractionColumn, fmt.Sprintf("%.2f", workweekFraction(user)))
+	}
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/report"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_setWorkweekFractions(t *testing.T) {
+	john := &report.ScheduleUser{Name: "John Doe", NumWorkHours: 48, NumWeekendHours: 24, NumBankHolidaysHours: 8}
+	mary := &report.ScheduleUser{Name: "Mary Jane", NumWorkHours: 10}
+	summary := &report.ScheduleUser{Name: "John Doe", NumWorkHours: 96, NumWeekendHours: 48, NumBankHolidaysHours: 8}
+	data := &report.PrintableData{
+		SchedulesData:         []*report.ScheduleData{{ID: "SCHED_1", RotaUsers: []*report.ScheduleUser{john, mary}}},
+		UsersSchedulesSummary: []*report.ScheduleUser{summary},
+	}
+
+	setWorkweekFractions(data)
+
+	assert.Equal(t, "2.00", john.ExtraValues[workweekFractionColumn])
+	assert.Equal(t, "0.25", mary.ExtraValues[workweekFractionColumn])
+	assert.Equal(t, "3.80", summary.ExtraValues[workweekFractionColumn])
+}
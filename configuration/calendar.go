@@ -1,6 +1,7 @@
 package configuration
 
 import (
+	"fmt"
 	"log"
 	"time"
 
@@ -44,7 +45,8 @@ type BankHoliday struct {
 }
 
 type BHCalendar struct {
-	DaysMaps map[string]BankHoliday // map[date 02-01-2006 format]
+	DaysMaps    map[string]BankHoliday // map[date 02-01-2006 format]
+	WeekendDays []time.Weekday         // overrides Saturday and Sunday when set
 }
 
 func (b *BHCalendar) IsDateBankHoliday(date time.Time) bool {
@@ -52,16 +54,64 @@ func (b *BHCalendar) IsDateBankHoliday(date time.Time) bool {
 	return present
 }
 
+// WithBankHolidays returns a copy of the calendar with the given bank holidays too.
+func (b BHCalendar) WithBankHolidays(bankHolidays []BankHoliday) BHCalendar {
+	daysMaps := make(map[string]BankHoliday, len(b.DaysMaps)+len(bankHolidays))
+	for key, bankHoliday := range b.DaysMaps {
+		daysMaps[key] = bankHoliday
+	}
+	for _, bankHoliday := range bankHolidays {
+		if _, present := daysMaps[bankHoliday.Date.ToHashKey()]; !present {
+			daysMaps[bankHoliday.Date.ToHashKey()] = bankHoliday
+		}
+	}
+	return BHCalendar{DaysMaps: daysMaps, WeekendDays: b.WeekendDays}
+}
+
 func (b *BHCalendar) IsWeekend(date time.Time) bool {
+	if len(b.WeekendDays) > 0 {
+		for _, weekday := range b.WeekendDays {
+			if date.Weekday() == weekday {
+				return true
+			}
+		}
+		return false
+	}
 	return date.Weekday() == 6 || date.Weekday() == 0
 }
 
 type BHCalendars map[string]BHCalendar // map[calendar_name-year]
 
+// Find returns the calendar with the bank holidays of every year from fromYear to toYear. The calendar
+// of fromYear is required, the ones of the following years are added when loaded.
+func (c BHCalendars) Find(name string, fromYear, toYear int) (BHCalendar, bool) {
+	calendar, present := c[fmt.Sprintf("%s-%d", name, fromYear)]
+	if !present || toYear <= fromYear {
+		return calendar, present
+	}
+
+	daysMaps := make(map[string]BankHoliday, len(calendar.DaysMaps))
+	for year := fromYear; year <= toYear; year++ {
+		yearCalendar, ok := c[fmt.Sprintf("%s-%d", name, year)]
+		if !ok {
+			log.Printf("Warning: calendar '%s-%d' not found, its bank holidays are paid as regular days", name, year)
+			continue
+		}
+		for key, bankHoliday := range yearCalendar.DaysMaps {
+			daysMaps[key] = bankHoliday
+		}
+	}
+	return BHCalendar{DaysMaps: daysMaps, WeekendDays: calendar.WeekendDays}, true
+}
+
 var BankHolidaysCalendars BHCalendars
 
-func LoadCalendars(year int) {
-	log.Printf("Loading calendars for year: %d", year)
+// LoadCalendars loads the bank holidays calendars of every year from fromYear to toYear.
+func LoadCalendars(fromYear, toYear int) error {
+	if toYear < fromYear {
+		toYear = fromYear
+	}
+	log.Printf("Loading calendars for years: %d-%d", fromYear, toYear)
 
 	riceConf := rice.Config{
 		LocateOrder: []rice.LocateMethod{
@@ -73,7 +123,7 @@ func LoadCalendars(year int) {
 	}
 	calendarsLocation, err := riceConf.FindBox("./../_assets")
 	if err != nil {
-		log.Fatalf("cannot find box '_assets': %s", err.Error())
+		return fmt.Errorf("cannot find box '_assets': %w", err)
 	}
 
 	BankHolidaysCalendars = BHCalendars{}
@@ -87,20 +137,20 @@ func LoadCalendars(year int) {
 		}
 
 		split := strings.Split(f.Name(), ".")
-		if parsedYear, _ := strconv.Atoi(split[2]); parsedYear != year {
+		if parsedYear, _ := strconv.Atoi(split[2]); parsedYear < fromYear || parsedYear > toYear {
 			return nil
 		}
 
 		key := strings.Join(split[1:len(split)-1], "-")
 		fileBytes, e := calendarsLocation.Bytes(path)
 		if e != nil {
-			panic(e)
+			return e
 		}
 
 		var bankHolidays []BankHoliday
 		err = yaml.Unmarshal(fileBytes, &bankHolidays)
 		if err != nil {
-			log.Fatalf("error: %v", err)
+			return fmt.Errorf("error reading calendar %s: %w", path, err)
 		}
 
 		calendar := map[string]BankHoliday{}
@@ -117,6 +167,7 @@ func LoadCalendars(year int) {
 	})
 
 	if err != nil {
-		log.Fatalf("error going through calendars directory: %s", err.Error())
+		return fmt.Errorf("error going through calendars directory: %w", err)
 	}
+	return nil
 }
@@ -3,12 +3,14 @@ package configuration
 import (
 	"fmt"
 	"log"
+	"strings"
 )
 
 type RotationUser struct {
 	UserID           string
 	Name             string
 	HolidaysCalendar string
+	Prices           []RotationPriceDay // overrides the schedule and global prices for this user
 }
 
 type RotationPriceDay struct {
@@ -17,8 +19,9 @@ type RotationPriceDay struct {
 }
 
 type RotationPrices struct {
-	Currency string
-	DaysInfo []RotationPriceDay
+	Currency     string
+	CurrencyCode string // ISO 4217 code for --include-currency-code-column, derived from Currency if empty
+	DaysInfo     []RotationPriceDay
 }
 
 type RotationExcludedHoursDay struct {
@@ -27,6 +30,12 @@ type RotationExcludedHoursDay struct {
 	ExcludedEndsAt   int
 }
 
+// BusinessHours are the working hours of the weekdays, 09:00 to 17:00 when not configured.
+type BusinessHours struct {
+	StartsAt int
+	EndsAt   int
+}
+
 type RotationInfo struct {
 	DailyRotationStartsAt    int
 	CheckRotationChangeEvery int
@@ -43,29 +52,72 @@ type ScheduleTimeRange struct {
 	End   string
 }
 
+// ManualEntry is an on-call period configured by hand, for when the PagerDuty data is wrong
+// and can't be fixed retroactively.
+type ManualEntry struct {
+	ScheduleId string
+	UserId     string
+	UserEmail  string // alternative to UserId
+	Start      string // RFC822
+	End        string // RFC822
+}
+
+type ScheduleSettings struct {
+	Id                string
+	UserSortKey       string
+	MaxAmountWarn     float32
+	MaxAmountError    float32
+	RoundingMode      string
+	RatePerService    float32            // additional pay per hour on call in the primary layer and service escalating to the schedule
+	Prices            []RotationPriceDay // overrides the global prices for this schedule
+	WeekendDefinition []string           // names of the weekend days, Saturday and Sunday if empty
+	RotationLength    string             // how often the users are on call again, e.g. 1w, checked with --schedule-rotation-length
+	TagHighAbove      float32            // overrides --tag-high-above for this schedule
+	TagLowBelow       float32            // overrides --tag-low-below for this schedule
+	Tier              string             // classification of the schedule, e.g. tier-1 for production
+	ExcludedLayers    []int              // 1-based indexes of the layers left out of the report, added to --exclude-layer
+	PaymentFrequency  string             // pay run of the schedule: weekly, bi-weekly or monthly
+}
+
+// Output holds the settings of the report outputs, overridden by the flags of the same name.
+type Output struct {
+	AmountColumnName string // header of the total amount column of the csv output
+	HoursColumnName  string // name of the hours columns of the csv output, after the day type
+}
+
 type Configuration struct {
-	PdAuthToken string `mapstructure:"PD_AUTH_TOKEN"` // loads from env variable
+	PdAuthToken       string `mapstructure:"PD_AUTH_TOKEN"`        // loads from env variable
+	PdAlertRoutingKey string `mapstructure:"PD_ALERT_ROUTING_KEY"` // loads from env variable
 
+	AccountName                string        // the PagerDuty API doesn't return the account name, see the README
+	BusinessHours              BusinessHours // hours left out by --include-non-business-hours-only
 	DefaultHolidayCalendar     string
 	DefaultUserTimezone        string
+	ManagerEmailMap            map[string]string // user email to manager email
+	ManualEntries              []ManualEntry
+	Output                     Output
 	ReportTimeRange            ReportTimeRange
+	RoundingMode               string
 	RotationInfo               RotationInfo
 	RotationExcludedHours      []RotationExcludedHoursDay
 	RotationPrices             RotationPrices
 	RotationUsers              []RotationUser
 	ScheduleTimeRangeOverrides []ScheduleTimeRange
+	ScheduleSettings           []ScheduleSettings
 	SchedulesToIgnore          []string
 
-	cacheRotationUsers  map[string]*RotationUser
-	cacheRotationPrices map[string]int
-	cacheExcludedByDay  map[string]*RotationExcludedHoursDay
+	cacheRotationUsers    map[string]*RotationUser
+	cacheRotationPrices   map[string]int
+	cacheExcludedByDay    map[string]*RotationExcludedHoursDay
+	cacheScheduleSettings map[string]*ScheduleSettings
 }
 
 func New() *Configuration {
 	return &Configuration{
-		cacheRotationUsers:  make(map[string]*RotationUser),
-		cacheRotationPrices: make(map[string]int),
-		cacheExcludedByDay:  make(map[string]*RotationExcludedHoursDay),
+		cacheRotationUsers:    make(map[string]*RotationUser),
+		cacheRotationPrices:   make(map[string]int),
+		cacheExcludedByDay:    make(map[string]*RotationExcludedHoursDay),
+		cacheScheduleSettings: make(map[string]*ScheduleSettings),
 	}
 }
 
@@ -125,6 +177,43 @@ func (c *Configuration) FindRotationUserInfoByID(userID string) (*RotationUser,
 	return rotationUser, nil
 }
 
+// FindScheduleSettingsByID returns the settings configured for the given schedule, or nil when there are none.
+func (c *Configuration) FindScheduleSettingsByID(scheduleID string) *ScheduleSettings {
+	if settings, ok := c.cacheScheduleSettings[scheduleID]; ok {
+		return settings
+	}
+
+	for i := range c.ScheduleSettings {
+		if c.ScheduleSettings[i].Id == scheduleID {
+			c.cacheScheduleSettings[scheduleID] = &c.ScheduleSettings[i]
+			return &c.ScheduleSettings[i]
+		}
+	}
+
+	return nil
+}
+
+// FindManualEntriesByScheduleID returns the manual entries configured for the given schedule.
+func (c *Configuration) FindManualEntriesByScheduleID(scheduleID string) []ManualEntry {
+	entries := make([]ManualEntry, 0)
+	for _, entry := range c.ManualEntries {
+		if entry.ScheduleId == scheduleID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// FindManagerEmail returns the email address of the user's manager, or an empty string if not configured.
+func (c *Configuration) FindManagerEmail(userEmail string) string {
+	for email, managerEmail := range c.ManagerEmailMap {
+		if strings.EqualFold(email, userEmail) {
+			return managerEmail
+		}
+	}
+	return ""
+}
+
 func (c *Configuration) IsScheduleIDToIgnore(scheduleID string) bool {
 	for _, scheduleIDToIgnore := range c.SchedulesToIgnore {
 		if scheduleIDToIgnore == scheduleID {
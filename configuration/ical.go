@@ -0,0 +1,95 @@
+package configuration
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const icalDateFormat = "20060102"
+
+// ParseICal returns the days of the events in an iCalendar, as published for public holidays. Events
+// lasting several days add a bank holiday for each of them.
+func ParseICal(r io.Reader) ([]BankHoliday, error) {
+	lines, err := unfoldICalLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var holidays []BankHoliday
+	var inEvent bool
+	var summary string
+	var start, end time.Time
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		// parameters, e.g. DTSTART;VALUE=DATE, don't matter for whole days
+		name, _, _ = strings.Cut(strings.ToUpper(name), ";")
+
+		switch name {
+		case "BEGIN":
+			if value == "VEVENT" {
+				inEvent, summary, start, end = true, "", time.Time{}, time.Time{}
+			}
+		case "SUMMARY":
+			summary = strings.ReplaceAll(value, `\,`, ",")
+		case "DTSTART", "DTEND":
+			if !inEvent {
+				continue
+			}
+			day, err := parseICalDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %q: %w", name, value, err)
+			}
+			if name == "DTSTART" {
+				start = day
+			} else {
+				end = day
+			}
+		case "END":
+			if value != "VEVENT" || !inEvent {
+				continue
+			}
+			inEvent = false
+			if start.IsZero() {
+				return nil, fmt.Errorf("event %q has no start date", summary)
+			}
+			// the end date is exclusive, and optional for single days
+			if !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+				date := day
+				holidays = append(holidays, BankHoliday{Name: summary, Date: Day{Time: &date}})
+			}
+		}
+	}
+	return holidays, nil
+}
+
+// parseICalDate parses the date of a DATE or DATE-TIME value, ignoring the time.
+func parseICalDate(value string) (time.Time, error) {
+	if len(value) < len(icalDateFormat) {
+		return time.Time{}, fmt.Errorf("too short")
+	}
+	return time.Parse(icalDateFormat, value[:len(icalDateFormat)])
+}
+
+// unfoldICalLines returns the content lines of the iCalendar, joining the ones folded over several lines.
+func unfoldICalLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
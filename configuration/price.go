@@ -9,39 +9,86 @@ type PricesInfo struct {
 	HoursBhDay            int
 }
 
-func (c *Configuration) GetPricesInfo() (*PricesInfo, error) {
-	weekDayPrice, err := c.FindPriceByDay("weekday")
+const (
+	PriceSourceUser     = "user-override"
+	PriceSourceSchedule = "schedule-config"
+	PriceSourceGlobal   = "global-default"
+)
+
+func findPriceInDays(days []RotationPriceDay, dayType string) (int, bool) {
+	for _, day := range days {
+		if day.Day == dayType {
+			return day.Price, true
+		}
+	}
+	return 0, false
+}
+
+// FindEffectivePriceByDay returns the daily price that applies to the user on the schedule and
+// where it comes from: the user prices take precedence over the schedule ones, which take
+// precedence over the global ones.
+func (c *Configuration) FindEffectivePriceByDay(dayType, scheduleID, userID string) (int, string, error) {
+	for _, rotationUser := range c.RotationUsers {
+		if rotationUser.UserID == userID {
+			if price, ok := findPriceInDays(rotationUser.Prices, dayType); ok {
+				return price, PriceSourceUser, nil
+			}
+		}
+	}
+
+	if settings := c.FindScheduleSettingsByID(scheduleID); settings != nil {
+		if price, ok := findPriceInDays(settings.Prices, dayType); ok {
+			return price, PriceSourceSchedule, nil
+		}
+	}
+
+	price, err := c.FindPriceByDay(dayType)
 	if err != nil {
-		return nil, err
+		return 0, "", err
 	}
-	excludedWeekDayHoursAmount := 0
-	excludedHours := c.FindRotationExcludedHoursByDay("weekday")
-	if excludedHours != nil {
-		excludedWeekDayHoursAmount = excludedHours.ExcludedEndsAt - excludedHours.ExcludedStartsAt
+	return *price, PriceSourceGlobal, nil
+}
+
+// DailyWorkingHours returns the number of on-call hours paid for a day of the given type.
+func (c *Configuration) DailyWorkingHours(dayType string) int {
+	excludedHours := c.FindRotationExcludedHoursByDay(dayType)
+	if excludedHours == nil {
+		return 24
 	}
-	weekDayWorkingHours := 24 - excludedWeekDayHoursAmount
+	return 24 - (excludedHours.ExcludedEndsAt - excludedHours.ExcludedStartsAt)
+}
+
+// GetPricesInfo returns the global prices.
+func (c *Configuration) GetPricesInfo() (*PricesInfo, error) {
+	return c.getPricesInfo(c.FindPriceByDay)
+}
 
-	weekendDayPrice, err := c.FindPriceByDay("weekend")
+// GetUserPricesInfo returns the prices that apply to the user on the schedule.
+func (c *Configuration) GetUserPricesInfo(scheduleID, userID string) (*PricesInfo, error) {
+	return c.getPricesInfo(func(dayType string) (*int, error) {
+		price, _, err := c.FindEffectivePriceByDay(dayType, scheduleID, userID)
+		return &price, err
+	})
+}
+
+func (c *Configuration) getPricesInfo(findPriceByDay func(dayType string) (*int, error)) (*PricesInfo, error) {
+	weekDayPrice, err := findPriceByDay("weekday")
 	if err != nil {
 		return nil, err
 	}
-	excludedWeekendDayHoursAmount := 0
-	excludedHours = c.FindRotationExcludedHoursByDay("weekend")
-	if excludedHours != nil {
-		excludedWeekendDayHoursAmount = excludedHours.ExcludedEndsAt - excludedHours.ExcludedStartsAt
-	}
-	weekendDayWorkingHours := 24 - excludedWeekendDayHoursAmount
+	weekDayWorkingHours := c.DailyWorkingHours("weekday")
 
-	bhDayPrice, err := c.FindPriceByDay("bankholiday")
+	weekendDayPrice, err := findPriceByDay("weekend")
 	if err != nil {
 		return nil, err
 	}
-	excludedBhDayHoursAmount := 0
-	excludedHours = c.FindRotationExcludedHoursByDay("bankholiday")
-	if excludedHours != nil {
-		excludedBhDayHoursAmount = excludedHours.ExcludedEndsAt - excludedHours.ExcludedStartsAt
+	weekendDayWorkingHours := c.DailyWorkingHours("weekend")
+
+	bhDayPrice, err := findPriceByDay("bankholiday")
+	if err != nil {
+		return nil, err
 	}
-	bhWorkingHours := 24 - excludedBhDayHoursAmount
+	bhWorkingHours := c.DailyWorkingHours("bankholiday")
 
 	return &PricesInfo{
 		WeekDayHourlyPrice:    float32(*weekDayPrice) / float32(weekDayWorkingHours),
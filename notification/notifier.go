@@ -0,0 +1,40 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Notifier reports the outcome of a report run to an external alerting system.
+type Notifier interface {
+	NotifyFailure(failure *Failure) error
+	NotifySuccess() error
+}
+
+// Failure holds the information about a failed report run. Start and End are zero when the run
+// failed before its period was known.
+type Failure struct {
+	Err         error
+	Start       time.Time
+	End         time.Time
+	ScheduleIDs []string
+	PeriodLabel string
+}
+
+// HasPeriod reports whether the period of the failed report run is known.
+func (f *Failure) HasPeriod() bool {
+	return !f.Start.IsZero() && !f.End.IsZero()
+}
+
+func (f *Failure) Description() string {
+	description := fmt.Sprintf("Error: %s", f.Err)
+	if f.HasPeriod() {
+		description += fmt.Sprintf("\nDate range: %s to %s", f.Start.Format(time.RFC822), f.End.Format(time.RFC822))
+	}
+	description += fmt.Sprintf("\nSchedules: %s", strings.Join(f.ScheduleIDs, ", "))
+	if f.PeriodLabel != "" {
+		description += fmt.Sprintf("\nPeriod: %s", f.PeriodLabel)
+	}
+	return description
+}
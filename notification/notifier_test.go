@@ -0,0 +1,42 @@
+package notification
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Failure_Description(t *testing.T) {
+	tests := []struct {
+		name    string
+		failure *Failure
+		want    string
+	}{
+		{
+			name: "With the report period",
+			failure: &Failure{
+				Err:         errors.New("failed to get schedule"),
+				Start:       time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC),
+				End:         time.Date(2022, 9, 1, 8, 0, 0, 0, time.UTC),
+				ScheduleIDs: []string{"SCHED_1", "SCHED_2"},
+				PeriodLabel: "August 2022",
+			},
+			want: "Error: failed to get schedule\nDate range: 01 Aug 22 00:00 UTC to 01 Sep 22 08:00 UTC\nSchedules: SCHED_1, SCHED_2\nPeriod: August 2022",
+		},
+		{
+			name: "Failed before the period was known",
+			failure: &Failure{
+				Err:         errors.New("invalid date"),
+				ScheduleIDs: []string{"SCHED_1"},
+			},
+			want: "Error: invalid date\nSchedules: SCHED_1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.failure.Description())
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	opsGenieAPIURL      = "https://api.opsgenie.com"
+	opsGenieAlertAlias  = "pagerduty-oncall-report-failure"
+	opsGenieAlertSource = "pd-report"
+
+	// OpsGenie rejects alert messages longer than 130 characters
+	opsGenieMaxMessageLength = 130
+)
+
+type opsGenieNotifier struct {
+	apiKey  string
+	team    string
+	baseURL string
+	client  *http.Client
+}
+
+type opsGenieResponder struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type opsGenieAlert struct {
+	Message     string              `json:"message"`
+	Alias       string              `json:"alias"`
+	Description string              `json:"description"`
+	Source      string              `json:"source"`
+	Responders  []opsGenieResponder `json:"responders,omitempty"`
+}
+
+type opsGenieCloseRequest struct {
+	Source string `json:"source"`
+	Note   string `json:"note"`
+}
+
+func NewOpsGenieNotifier(apiKey, team string) Notifier {
+	return &opsGenieNotifier{
+		apiKey:  apiKey,
+		team:    team,
+		baseURL: opsGenieAPIURL,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (n *opsGenieNotifier) NotifyFailure(failure *Failure) error {
+	message := truncate(fmt.Sprintf("PagerDuty on-call report generation failed: %s", failure.Err), opsGenieMaxMessageLength)
+
+	alert := &opsGenieAlert{
+		Message:     message,
+		Alias:       opsGenieAlertAlias,
+		Description: failure.Description(),
+		Source:      opsGenieAlertSource,
+	}
+	if n.team != "" {
+		alert.Responders = []opsGenieResponder{{Name: n.team, Type: "team"}}
+	}
+
+	return n.post("/v2/alerts", alert)
+}
+
+// truncate returns the first max characters of the text, never cutting a multi-byte character in half.
+func truncate(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max])
+}
+
+func (n *opsGenieNotifier) NotifySuccess() error {
+	path := fmt.Sprintf("/v2/alerts/%s/close?identifierType=alias", url.PathEscape(opsGenieAlertAlias))
+	return n.post(path, &opsGenieCloseRequest{
+		Source: opsGenieAlertSource,
+		Note:   "PagerDuty on-call report generated successfully",
+	})
+}
+
+func (n *opsGenieNotifier) post(path string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode opsgenie request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create opsgenie request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "GenieKey "+n.apiKey)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call opsgenie: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("opsgenie responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package notification
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_opsGenieNotifier(t *testing.T) {
+	failure := &Failure{
+		Err:         errors.New("failed to get schedule"),
+		Start:       time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC),
+		End:         time.Date(2022, 9, 1, 8, 0, 0, 0, time.UTC),
+		ScheduleIDs: []string{"SCHED_1", "SCHED_2"},
+		PeriodLabel: "August 2022",
+	}
+
+	tests := []struct {
+		name       string
+		success    bool
+		statusCode int
+		wantPath   string
+		wantErr    bool
+	}{
+		{
+			name:       "Successfully creates an alert on failure",
+			statusCode: http.StatusAccepted,
+			wantPath:   "/v2/alerts",
+		},
+		{
+			name:       "Successfully closes the alert on success",
+			success:    true,
+			statusCode: http.StatusAccepted,
+			wantPath:   "/v2/alerts/pagerduty-oncall-report-failure/close",
+		},
+		{
+			name:       "Fails when opsgenie rejects the alert",
+			statusCode: http.StatusUnauthorized,
+			wantPath:   "/v2/alerts",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var alert opsGenieAlert
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.wantPath, r.URL.Path)
+				assert.Equal(t, "GenieKey api-key", r.Header.Get("Authorization"))
+				if !tt.success {
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+				}
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			notifier := NewOpsGenieNotifier("api-key", "sre").(*opsGenieNotifier)
+			notifier.baseURL = server.URL
+
+			var err error
+			if tt.success {
+				err = notifier.NotifySuccess()
+			} else {
+				err = notifier.NotifyFailure(failure)
+			}
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			if !tt.success {
+				assert.Equal(t, opsGenieAlertAlias, alert.Alias)
+				assert.Contains(t, alert.Message, "failed to get schedule")
+				assert.Contains(t, alert.Description, "SCHED_1, SCHED_2")
+				assert.Contains(t, alert.Description, "01 Aug 22 00:00 UTC")
+				assert.Contains(t, alert.Description, "Period: August 2022")
+				assert.Equal(t, []opsGenieResponder{{Name: "sre", Type: "team"}}, alert.Responders)
+			}
+		})
+	}
+}
+
+func Test_truncate(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		max  int
+		want string
+	}{
+		{name: "Shorter than the maximum", text: "failed", max: 10, want: "failed"},
+		{name: "Cut to the maximum", text: "failed to get schedule", max: 6, want: "failed"},
+		{name: "Counts characters, not bytes", text: "£€£€", max: 3, want: "£€£"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, truncate(tt.text, tt.max))
+		})
+	}
+}
+
+func Test_opsGenieNotifier_longMessage(t *testing.T) {
+	var alert opsGenieAlert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	notifier := NewOpsGenieNotifier("api-key", "").(*opsGenieNotifier)
+	notifier.baseURL = server.URL
+
+	err := notifier.NotifyFailure(&Failure{Err: errors.New(strings.Repeat("£", 200))})
+
+	require.NoError(t, err)
+	assert.True(t, utf8.ValidString(alert.Message))
+	assert.Equal(t, opsGenieMaxMessageLength, utf8.RuneCountInString(alert.Message))
+}
@@ -0,0 +1,72 @@
+package notification
+
+import (
+	"fmt"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+type eventsClient interface {
+	ManageEvent(e *pagerduty.V2Event) (*pagerduty.V2EventResponse, error)
+}
+
+type pagerDutyNotifier struct {
+	routingKey string
+	serviceID  string
+	client     eventsClient
+}
+
+// NewPagerDutyNotifier creates a notifier sending Events API v2 events. The events are routed to
+// the service of the integration the routing key belongs to, whatever the serviceID, which only
+// identifies the alerts in their dedup key and component.
+func NewPagerDutyNotifier(routingKey, serviceID string) Notifier {
+	return &pagerDutyNotifier{
+		routingKey: routingKey,
+		serviceID:  serviceID,
+		client:     pagerduty.NewClient(""),
+	}
+}
+
+func (n *pagerDutyNotifier) dedupKey() string {
+	return fmt.Sprintf("pagerduty-oncall-report-%s", n.serviceID)
+}
+
+func (n *pagerDutyNotifier) NotifyFailure(failure *Failure) error {
+	details := map[string]interface{}{
+		"error":     failure.Err.Error(),
+		"schedules": failure.ScheduleIDs,
+	}
+	if failure.HasPeriod() {
+		details["start"] = failure.Start
+		details["end"] = failure.End
+	}
+	_, err := n.client.ManageEvent(&pagerduty.V2Event{
+		RoutingKey: n.routingKey,
+		Action:     "trigger",
+		DedupKey:   n.dedupKey(),
+		Client:     "pd-report",
+		Payload: &pagerduty.V2Payload{
+			Summary:   fmt.Sprintf("PagerDuty on-call report generation failed: %s", failure.Err),
+			Source:    "pd-report",
+			Severity:  "error",
+			Component: n.serviceID,
+			Details:   details,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to trigger pagerduty incident: %w", err)
+	}
+	return nil
+}
+
+func (n *pagerDutyNotifier) NotifySuccess() error {
+	_, err := n.client.ManageEvent(&pagerduty.V2Event{
+		RoutingKey: n.routingKey,
+		Action:     "resolve",
+		DedupKey:   n.dedupKey(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resolve pagerduty incident: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package notification
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type eventsClientMock struct {
+	mock.Mock
+}
+
+func (_m *eventsClientMock) ManageEvent(e *pagerduty.V2Event) (*pagerduty.V2EventResponse, error) {
+	ret := _m.Called(e)
+
+	var r0 *pagerduty.V2EventResponse
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*pagerduty.V2EventResponse)
+	}
+	return r0, ret.Error(1)
+}
+
+func Test_pagerDutyNotifier(t *testing.T) {
+	failure := &Failure{
+		Err:         errors.New("failed to get schedule"),
+		Start:       time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC),
+		End:         time.Date(2022, 9, 1, 8, 0, 0, 0, time.UTC),
+		ScheduleIDs: []string{"SCHED_1"},
+	}
+
+	tests := []struct {
+		name       string
+		success    bool
+		mockSetup  func(*eventsClientMock)
+		wantAction string
+		wantErr    bool
+	}{
+		{
+			name: "Successfully triggers an incident on failure",
+			mockSetup: func(clientMock *eventsClientMock) {
+				clientMock.On("ManageEvent", mock.Anything).Once().Return(&pagerduty.V2EventResponse{}, nil)
+			},
+			wantAction: "trigger",
+		},
+		{
+			name:    "Successfully resolves the incident on success",
+			success: true,
+			mockSetup: func(clientMock *eventsClientMock) {
+				clientMock.On("ManageEvent", mock.Anything).Once().Return(&pagerduty.V2EventResponse{}, nil)
+			},
+			wantAction: "resolve",
+		},
+		{
+			name: "Fails when the event is rejected",
+			mockSetup: func(clientMock *eventsClientMock) {
+				clientMock.On("ManageEvent", mock.Anything).Once().Return(nil, errors.New("invalid routing key"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientMock := &eventsClientMock{}
+			tt.mockSetup(clientMock)
+
+			notifier := &pagerDutyNotifier{
+				routingKey: "routing-key",
+				serviceID:  "PSERVICE",
+				client:     clientMock,
+			}
+
+			var err error
+			if tt.success {
+				err = notifier.NotifySuccess()
+			} else {
+				err = notifier.NotifyFailure(failure)
+			}
+			clientMock.AssertExpectations(t)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			event := clientMock.Calls[0].Arguments.Get(0).(*pagerduty.V2Event)
+			assert.Equal(t, tt.wantAction, event.Action)
+			assert.Equal(t, "routing-key", event.RoutingKey)
+			assert.Equal(t, "pagerduty-oncall-report-PSERVICE", event.DedupKey)
+		})
+	}
+}
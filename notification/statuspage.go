@@ -0,0 +1,121 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const statusPageAPIURL = "https://api.statuspage.io/v1"
+
+// StatusPageMaintenance opens a Statuspage maintenance window while a report is being
+// generated, as the report API calls may cause an observable PagerDuty latency.
+type StatusPageMaintenance struct {
+	apiKey  string
+	pageID  string
+	baseURL string
+	client  *http.Client
+
+	incidentID string
+}
+
+type statusPageIncident struct {
+	Name             string `json:"name,omitempty"`
+	Status           string `json:"status"`
+	Body             string `json:"body,omitempty"`
+	ScheduledFor     string `json:"scheduled_for,omitempty"`
+	ScheduledUntil   string `json:"scheduled_until,omitempty"`
+	ImpactOverride   string `json:"impact_override,omitempty"`
+	AutoTransitionIn bool   `json:"scheduled_auto_in_progress,omitempty"`
+}
+
+type statusPageIncidentRequest struct {
+	Incident statusPageIncident `json:"incident"`
+}
+
+type statusPageIncidentResponse struct {
+	ID string `json:"id"`
+}
+
+func NewStatusPageMaintenance(apiKey, pageID string) *StatusPageMaintenance {
+	return &StatusPageMaintenance{
+		apiKey:  apiKey,
+		pageID:  pageID,
+		baseURL: statusPageAPIURL,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Start creates a maintenance window starting now and lasting the expected duration.
+func (s *StatusPageMaintenance) Start(expectedDuration time.Duration) error {
+	now := time.Now().UTC()
+	request := &statusPageIncidentRequest{
+		Incident: statusPageIncident{
+			Name:             fmt.Sprintf("PagerDuty on-call report generation (expected duration: %s)", expectedDuration),
+			Status:           "in_progress",
+			Body:             "An on-call report is being generated, PagerDuty API calls may be slower than usual.",
+			ScheduledFor:     now.Format(time.RFC3339),
+			ScheduledUntil:   now.Add(expectedDuration).Format(time.RFC3339),
+			ImpactOverride:   "maintenance",
+			AutoTransitionIn: true,
+		},
+	}
+
+	var response statusPageIncidentResponse
+	err := s.send(http.MethodPost, fmt.Sprintf("/pages/%s/incidents", s.pageID), request, &response)
+	if err != nil {
+		return fmt.Errorf("failed to create statuspage maintenance: %w", err)
+	}
+	s.incidentID = response.ID
+	return nil
+}
+
+// Complete closes the maintenance window opened by Start, if any.
+func (s *StatusPageMaintenance) Complete() error {
+	if s.incidentID == "" {
+		return nil
+	}
+
+	request := &statusPageIncidentRequest{
+		Incident: statusPageIncident{
+			Status: "completed",
+			Body:   "The on-call report generation has finished.",
+		},
+	}
+	err := s.send(http.MethodPatch, fmt.Sprintf("/pages/%s/incidents/%s", s.pageID, s.incidentID), request, nil)
+	if err != nil {
+		return fmt.Errorf("failed to complete statuspage maintenance: %w", err)
+	}
+	s.incidentID = ""
+	return nil
+}
+
+func (s *StatusPageMaintenance) send(method, path string, payload, result interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(method, s.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "OAuth "+s.apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("statuspage responded with status code %d", resp.StatusCode)
+	}
+	if result != nil {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package notification
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StatusPageMaintenance(t *testing.T) {
+	requests := make([]statusPageIncidentRequest, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "OAuth api-key", r.Header.Get("Authorization"))
+
+		var request statusPageIncidentRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		requests = append(requests, request)
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/pages/PAGE_ID/incidents":
+			_, _ = w.Write([]byte(`{"id": "INCIDENT_ID"}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/pages/PAGE_ID/incidents/INCIDENT_ID":
+			_, _ = w.Write([]byte(`{"id": "INCIDENT_ID"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	maintenance := NewStatusPageMaintenance("api-key", "PAGE_ID")
+	maintenance.baseURL = server.URL
+
+	require.NoError(t, maintenance.Start(45*time.Minute))
+	require.NoError(t, maintenance.Complete())
+	// completing twice is a no-op
+	require.NoError(t, maintenance.Complete())
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, "in_progress", requests[0].Incident.Status)
+	assert.Contains(t, requests[0].Incident.Name, "45m0s")
+	assert.Equal(t, "maintenance", requests[0].Incident.ImpactOverride)
+	assert.Equal(t, "completed", requests[1].Incident.Status)
+}
+
+func Test_StatusPageMaintenance_StartFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	maintenance := NewStatusPageMaintenance("api-key", "PAGE_ID")
+	maintenance.baseURL = server.URL
+
+	require.Error(t, maintenance.Start(time.Minute))
+	// nothing to complete when the maintenance was never created
+	require.NoError(t, maintenance.Complete())
+}
@@ -0,0 +1,91 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	victorOpsAPIURL          = "https://alert.victorops.com/integrations/generic/20131114/alert"
+	victorOpsRecoveryMessage = "RECOVERY"
+)
+
+var VictorOpsMessageTypes = []string{"CRITICAL", "WARNING", "INFO"}
+
+type victorOpsNotifier struct {
+	apiKey      string
+	routingKey  string
+	messageType string
+	entityID    string
+	baseURL     string
+	client      *http.Client
+}
+
+type victorOpsAlert struct {
+	MessageType       string `json:"message_type"`
+	EntityID          string `json:"entity_id"`
+	EntityDisplayName string `json:"entity_display_name"`
+	StateMessage      string `json:"state_message,omitempty"`
+	MonitoringTool    string `json:"monitoring_tool"`
+}
+
+// NewVictorOpsNotifier creates a notifier for the VictorOps (Splunk On-Call) REST endpoint.
+// The account name is used to build the entity the incidents are de-duplicated by.
+func NewVictorOpsNotifier(apiKey, routingKey, messageType, account string) Notifier {
+	entityID := "pagerduty-oncall-report"
+	if account != "" {
+		entityID = fmt.Sprintf("%s-%s", entityID, account)
+	}
+
+	return &victorOpsNotifier{
+		apiKey:      apiKey,
+		routingKey:  routingKey,
+		messageType: messageType,
+		entityID:    entityID,
+		baseURL:     victorOpsAPIURL,
+		client:      &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (n *victorOpsNotifier) NotifyFailure(failure *Failure) error {
+	return n.post(&victorOpsAlert{
+		MessageType:       n.messageType,
+		EntityID:          n.entityID,
+		EntityDisplayName: n.entityID,
+		StateMessage:      failure.Description(),
+		MonitoringTool:    "pd-report",
+	})
+}
+
+func (n *victorOpsNotifier) NotifySuccess() error {
+	return n.post(&victorOpsAlert{
+		MessageType:       victorOpsRecoveryMessage,
+		EntityID:          n.entityID,
+		EntityDisplayName: n.entityID,
+		StateMessage:      "PagerDuty on-call report generated successfully",
+		MonitoringTool:    "pd-report",
+	})
+}
+
+func (n *victorOpsNotifier) post(alert *victorOpsAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to encode victorops request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/%s", n.baseURL, url.PathEscape(n.apiKey), url.PathEscape(n.routingKey))
+	resp, err := n.client.Post(endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to call victorops: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("victorops responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package notification
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_victorOpsNotifier(t *testing.T) {
+	failure := &Failure{
+		Err:         errors.New("failed to get schedule"),
+		Start:       time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC),
+		End:         time.Date(2022, 9, 1, 8, 0, 0, 0, time.UTC),
+		ScheduleIDs: []string{"SCHED_1"},
+	}
+
+	tests := []struct {
+		name            string
+		success         bool
+		account         string
+		statusCode      int
+		wantMessageType string
+		wantEntityID    string
+		wantErr         bool
+	}{
+		{
+			name:            "Successfully sends the configured message type on failure",
+			account:         "acme",
+			statusCode:      http.StatusOK,
+			wantMessageType: "WARNING",
+			wantEntityID:    "pagerduty-oncall-report-acme",
+		},
+		{
+			name:            "Successfully sends a recovery message on success",
+			success:         true,
+			account:         "acme",
+			statusCode:      http.StatusOK,
+			wantMessageType: "RECOVERY",
+			wantEntityID:    "pagerduty-oncall-report-acme",
+		},
+		{
+			name:            "Entity has no account suffix when the account is not configured",
+			statusCode:      http.StatusOK,
+			wantMessageType: "WARNING",
+			wantEntityID:    "pagerduty-oncall-report",
+		},
+		{
+			name:       "Fails when victorops rejects the message",
+			statusCode: http.StatusBadRequest,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var alert victorOpsAlert
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api-key/routing-key", r.URL.Path)
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			notifier := NewVictorOpsNotifier("api-key", "routing-key", "WARNING", tt.account).(*victorOpsNotifier)
+			notifier.baseURL = server.URL
+
+			var err error
+			if tt.success {
+				err = notifier.NotifySuccess()
+			} else {
+				err = notifier.NotifyFailure(failure)
+			}
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantMessageType, alert.MessageType)
+			assert.Equal(t, tt.wantEntityID, alert.EntityID)
+			assert.Equal(t, tt.wantEntityID, alert.EntityDisplayName)
+		})
+	}
+}
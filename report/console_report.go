@@ -2,6 +2,7 @@ package report
 
 import (
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"time"
@@ -9,6 +10,8 @@ import (
 
 type consoleReport struct {
 	currency string
+	decimals int
+	out      io.Writer
 }
 
 const (
@@ -17,79 +20,120 @@ const (
 	rowFormat = "| %-35s || %7v | %7v | %12v | %13v | %13v | %18v | %9v |"
 )
 
-func NewConsoleReport(currency string) Writer {
+func NewConsoleReport(currency string, decimals int, out io.Writer) Writer {
 	return &consoleReport{
 		currency: currency,
+		decimals: decimals,
+		out:      out,
 	}
 }
 
 func (r *consoleReport) GenerateReport(data *PrintableData) (string, error) {
 
-	fmt.Println(separator)
-	fmt.Println(fmt.Sprintf("| Generating report(s) from '%s' to '%s'", data.Start.Format("Mon Jan _2 15:04:05 2006"), data.End.Add(time.Second*-1).Format("Mon Jan _2 15:04:05 2006")))
-	fmt.Println(separator)
+	fmt.Fprintln(r.out, separator)
+	fmt.Fprintln(r.out, fmt.Sprintf("| Generating report(s) from '%s' to '%s'", data.Start.Format("Mon Jan _2 15:04:05 2006"), data.End.Add(time.Second*-1).Format("Mon Jan _2 15:04:05 2006")))
+	if data.AccountName != "" {
+		fmt.Fprintln(r.out, fmt.Sprintf("| Account: %s", data.AccountName))
+	}
+	if data.PeriodLabel != "" {
+		fmt.Fprintln(r.out, fmt.Sprintf("| Period: %s", data.PeriodLabel))
+	}
+	fmt.Fprintln(r.out, separator)
 
-	for _, scheduleData := range data.SchedulesData {
-		fmt.Println(blankLine)
-		fmt.Println(separator)
-		fmt.Println(fmt.Sprintf("| Schedule: '%s' (%s)", scheduleData.Name, scheduleData.ID))
-		fmt.Println(fmt.Sprintf("| Time Range: %s to %s", scheduleData.StartDate.Format(time.RFC822), scheduleData.EndDate.Format(time.RFC822)))
-		fmt.Println(separator)
-		fmt.Println(fmt.Sprintf(rowFormat, "USER", "WEEKDAY", "WEEKEND", "BANK HOLIDAY", "TOTAL WEEKDAY", "TOTAL WEEKEND", "TOTAL BANK HOLIDAY", "TOTAL"))
-		fmt.Println(fmt.Sprintf(rowFormat, "EMAIL", "HOURS", "HOURS", "HOURS", "AMOUNT", "AMOUNT", "AMOUNT", "AMOUNT"))
-		fmt.Println(fmt.Sprintf(rowFormat, "", "DAYS", "DAYS", "DAYS", "", "", "", ""))
-		fmt.Println(separator)
+	for i, scheduleData := range data.SchedulesData {
+		if data.startsGroup(i) {
+			fmt.Fprintln(r.out, blankLine)
+			fmt.Fprintln(r.out, separator)
+			fmt.Fprintln(r.out, fmt.Sprintf("| == %s ==", scheduleData.Group))
+		}
+		fmt.Fprintln(r.out, blankLine)
+		fmt.Fprintln(r.out, separator)
+		fmt.Fprintln(r.out, fmt.Sprintf("| Schedule: '%s' (%s)", scheduleData.Name, scheduleData.ID))
+		if scheduleData.URL != "" {
+			fmt.Fprintln(r.out, fmt.Sprintf("| URL: %s", scheduleData.URL))
+		}
+		fmt.Fprintln(r.out, fmt.Sprintf("| Time Range: %s to %s", scheduleData.StartDate.Format(time.RFC822), scheduleData.EndDate.Format(time.RFC822)))
+		for _, note := range scheduleData.Notes {
+			fmt.Fprintln(r.out, fmt.Sprintf("| %s", note))
+		}
+		fmt.Fprintln(r.out, separator)
+		fmt.Fprintln(r.out, fmt.Sprintf(rowFormat, "USER", "WEEKDAY", "WEEKEND", "BANK HOLIDAY", "TOTAL WEEKDAY", "TOTAL WEEKEND", "TOTAL BANK HOLIDAY", "TOTAL"))
+		fmt.Fprintln(r.out, fmt.Sprintf(rowFormat, "EMAIL", "HOURS", "HOURS", "HOURS", "AMOUNT", "AMOUNT", "AMOUNT", "AMOUNT"))
+		fmt.Fprintln(r.out, fmt.Sprintf(rowFormat, "", "DAYS", "DAYS", "DAYS", "", "", "", ""))
+		fmt.Fprintln(r.out, separator)
 
-		sort.Slice(scheduleData.RotaUsers, func(i, j int) bool {
-			return strings.Compare(scheduleData.RotaUsers[i].Name, scheduleData.RotaUsers[j].Name) < 1
-		})
+		for _, group := range scheduleData.userGroups() {
+			if group.Name != "" {
+				fmt.Fprintln(r.out, fmt.Sprintf("| Team: %s", group.Name))
+				fmt.Fprintln(r.out, separator)
+			}
+			for _, userData := range group.Users {
+				fmt.Fprintln(r.out, fmt.Sprintf(rowFormat, userData.Name,
+					fmt.Sprintf("%v h", userData.NumWorkHours),
+					fmt.Sprintf("%v h", userData.NumWeekendHours),
+					fmt.Sprintf("%v h", userData.NumBankHolidaysHours),
+					FormatAmount(r.currency, userData.TotalAmountWorkHours, r.decimals),
+					FormatAmount(r.currency, userData.TotalAmountWeekendHours, r.decimals),
+					FormatAmount(r.currency, userData.TotalAmountBankHolidaysHours, r.decimals),
+					FormatAmount(r.currency, userData.TotalAmount, r.decimals)))
+				fmt.Fprintln(r.out, fmt.Sprintf(rowFormat, userData.EmailAddress,
+					fmt.Sprintf("%.1f d", userData.NumWorkDays),
+					fmt.Sprintf("%.1f d", userData.NumWeekendDays),
+					fmt.Sprintf("%.1f d", userData.NumBankHolidaysDays),
+					"_____________", "_____________", "__________________", "_________"))
+				if len(data.ExtraColumns) > 0 {
+					fmt.Fprintln(r.out, fmt.Sprintf("| %s", userData.extraValuesLine(data.ExtraColumns)))
+				}
+				fmt.Fprintln(r.out, separator)
+			}
+			if group.Name != "" {
+				fmt.Fprintln(r.out, fmt.Sprintf(rowFormat, fmt.Sprintf("%s subtotal", group.Name), "", "", "", "", "", "",
+					FormatAmount(r.currency, group.TotalAmount(), r.decimals)))
+				fmt.Fprintln(r.out, separator)
+			}
+		}
 
-		for _, userData := range scheduleData.RotaUsers {
-			fmt.Println(fmt.Sprintf(rowFormat, userData.Name,
-				fmt.Sprintf("%v h", userData.NumWorkHours),
-				fmt.Sprintf("%v h", userData.NumWeekendHours),
-				fmt.Sprintf("%v h", userData.NumBankHolidaysHours),
-				fmt.Sprintf("%s%.2f", r.currency, userData.TotalAmountWorkHours),
-				fmt.Sprintf("%s%.2f", r.currency, userData.TotalAmountWeekendHours),
-				fmt.Sprintf("%s%.2f", r.currency, userData.TotalAmountBankHolidaysHours),
-				fmt.Sprintf("%s%.2f", r.currency, userData.TotalAmount)))
-			fmt.Println(fmt.Sprintf(rowFormat, userData.EmailAddress,
-				fmt.Sprintf("%.1f d", userData.NumWorkDays),
-				fmt.Sprintf("%.1f d", userData.NumWeekendDays),
-				fmt.Sprintf("%.1f d", userData.NumBankHolidaysDays),
-				"_____________", "_____________", "__________________", "_________"))
-			fmt.Println(separator)
+		if len(scheduleData.HourlyBreakdown) > 0 {
+			fmt.Fprintln(r.out, "| Hourly breakdown")
+			fmt.Fprintln(r.out, separator)
+			for _, hour := range scheduleData.HourlyBreakdown {
+				fmt.Fprintln(r.out, fmt.Sprintf("| %s | %s", hour.Start.Format(hourlyBreakdownFormat), hour.usersLine()))
+			}
+			fmt.Fprintln(r.out, separator)
 		}
 	}
 
-	fmt.Println("")
-	fmt.Println(separator)
-	fmt.Println("| Users summary")
-	fmt.Println(separator)
-	fmt.Println(fmt.Sprintf(rowFormat, "USER", "WEEKDAY", "WEEKEND", "BANK HOLIDAY", "TOTAL WEEKDAY", "TOTAL WEEKEND", "TOTAL BANK HOLIDAY", "TOTAL"))
-	fmt.Println(fmt.Sprintf(rowFormat, "EMAIL", "HOURS", "HOURS", "HOURS", "AMOUNT", "AMOUNT", "AMOUNT", "AMOUNT"))
-	fmt.Println(fmt.Sprintf(rowFormat, "", "DAYS", "DAYS", "DAYS", "", "", "", ""))
-	fmt.Println(separator)
+	fmt.Fprintln(r.out, "")
+	fmt.Fprintln(r.out, separator)
+	fmt.Fprintln(r.out, "| Users summary")
+	fmt.Fprintln(r.out, separator)
+	fmt.Fprintln(r.out, fmt.Sprintf(rowFormat, "USER", "WEEKDAY", "WEEKEND", "BANK HOLIDAY", "TOTAL WEEKDAY", "TOTAL WEEKEND", "TOTAL BANK HOLIDAY", "TOTAL"))
+	fmt.Fprintln(r.out, fmt.Sprintf(rowFormat, "EMAIL", "HOURS", "HOURS", "HOURS", "AMOUNT", "AMOUNT", "AMOUNT", "AMOUNT"))
+	fmt.Fprintln(r.out, fmt.Sprintf(rowFormat, "", "DAYS", "DAYS", "DAYS", "", "", "", ""))
+	fmt.Fprintln(r.out, separator)
 
 	sort.Slice(data.UsersSchedulesSummary, func(i, j int) bool {
 		return strings.Compare(data.UsersSchedulesSummary[i].Name, data.UsersSchedulesSummary[j].Name) < 1
 	})
 
 	for _, userData := range data.UsersSchedulesSummary {
-		fmt.Println(fmt.Sprintf(rowFormat, userData.Name,
+		fmt.Fprintln(r.out, fmt.Sprintf(rowFormat, userData.Name,
 			fmt.Sprintf("%v h", userData.NumWorkHours),
 			fmt.Sprintf("%v h", userData.NumWeekendHours),
 			fmt.Sprintf("%v h", userData.NumBankHolidaysHours),
-			fmt.Sprintf("%s%.2f", r.currency, userData.TotalAmountWorkHours),
-			fmt.Sprintf("%s%.2f", r.currency, userData.TotalAmountWeekendHours),
-			fmt.Sprintf("%s%.2f", r.currency, userData.TotalAmountBankHolidaysHours),
-			fmt.Sprintf("%s%.2f", r.currency, userData.TotalAmount)))
-		fmt.Println(fmt.Sprintf(rowFormat, userData.EmailAddress,
+			FormatAmount(r.currency, userData.TotalAmountWorkHours, r.decimals),
+			FormatAmount(r.currency, userData.TotalAmountWeekendHours, r.decimals),
+			FormatAmount(r.currency, userData.TotalAmountBankHolidaysHours, r.decimals),
+			FormatAmount(r.currency, userData.TotalAmount, r.decimals)))
+		fmt.Fprintln(r.out, fmt.Sprintf(rowFormat, userData.EmailAddress,
 			fmt.Sprintf("%.1f d", userData.NumWorkDays),
 			fmt.Sprintf("%.1f d", userData.NumWeekendDays),
 			fmt.Sprintf("%.1f d", userData.NumBankHolidaysDays),
 			"_____________", "_____________", "__________________", "_________"))
-		fmt.Println(separator)
+		if len(data.ExtraColumns) > 0 {
+			fmt.Fprintln(r.out, fmt.Sprintf("| %s", userData.extraValuesLine(data.ExtraColumns)))
+		}
+		fmt.Fprintln(r.out, separator)
 	}
 
 	return "", nil
@@ -3,7 +3,9 @@ package report
 import (
 	"encoding/csv"
 	"fmt"
+	"io"
 	"log"
+	"math"
 	"os"
 	"sort"
 	"strings"
@@ -12,26 +14,108 @@ import (
 
 type csvReport struct {
 	currency string
+	decimals int
 	outPath  string
+	options  CsvOptions
+	out      io.Writer
+	files    []string
 }
 
-func NewCsvReport(currency string, outPath string) Writer {
+type CsvOptions struct {
+	UseCRLF bool // terminate lines with \r\n, as expected by Windows tools
+	Comma   rune // field separator, ',' if not set
+	PerUser bool // also write a file per user with their rows of every schedule
+
+	MinorUnit         string // when set, e.g. "pence", amounts are written as whole numbers of this unit
+	MinorUnitsPerUnit int    // minor units in a unit of the currency, e.g. 100 pence in a pound
+
+	AmountColumnName string // header of the total amount column instead of the default one, e.g. GROSS_PAY_GBP
+	HoursColumnName  string // name of the hours columns after the day type instead of Hours, e.g. ON_CALL_HOURS
+}
+
+func NewCsvReport(currency string, decimals int, outPath string, out io.Writer, options CsvOptions) Writer {
 	return &csvReport{
 		currency: strings.TrimSpace(currency),
+		decimals: decimals,
 		outPath:  outPath,
+		options:  options,
+		out:      out,
+	}
+}
+
+// amountHeader returns the header of an amount column, with the unit of the amounts when there is one.
+func (r *csvReport) amountHeader(name string) string {
+	unit := r.currency
+	if r.options.MinorUnit != "" {
+		unit = r.options.MinorUnit
+	}
+	if unit == "" {
+		return name
+	}
+	return name + " (" + unit + ")"
+}
+
+// hoursHeader returns the header of the hours column of the day type.
+func (r *csvReport) hoursHeader(dayType string) string {
+	if r.options.HoursColumnName != "" {
+		return dayType + " " + r.options.HoursColumnName
+	}
+	return dayType + " Hours"
+}
+
+// totalAmountHeader returns the header of the total amount column, as given in the options if any.
+func (r *csvReport) totalAmountHeader() string {
+	if r.options.AmountColumnName != "" {
+		return r.options.AmountColumnName
+	}
+	return r.amountHeader("Total  Amount")
+}
+
+func (r *csvReport) newWriter(file *os.File) *csv.Writer {
+	w := csv.NewWriter(file)
+	w.UseCRLF = r.options.UseCRLF
+	if r.options.Comma != 0 {
+		w.Comma = r.options.Comma
 	}
+	return w
+}
+
+// writeRecord writes the record, warning about the fields that contain a custom separator: they're
+// quoted, which tools splitting the lines on the separator don't expect.
+func writeRecord(w *csv.Writer, record []string) error {
+	if w.Comma != ',' {
+		for _, field := range record {
+			if strings.ContainsRune(field, w.Comma) {
+				log.Printf("WARNING: field %q contains the separator %q and is quoted", field, w.Comma)
+			}
+		}
+	}
+	return w.Write(record)
+}
+
+// Files returns the files written by GenerateReport.
+func (r *csvReport) Files() []string {
+	return r.files
 }
 
 func (r *csvReport) GenerateReport(data *PrintableData) (string, error) {
+	r.files = nil
 
-	fmt.Println(separator)
-	fmt.Println(fmt.Sprintf("| Generating report(s) from '%s' to '%s'", data.Start.Format("Mon Jan _2 15:04:05 2006"), data.End.Add(time.Second*-1).Format("Mon Jan _2 15:04:05 2006")))
-	fmt.Println(separator)
+	fmt.Fprintln(r.out, separator)
+	fmt.Fprintln(r.out, fmt.Sprintf("| Generating report(s) from '%s' to '%s'", data.Start.Format("Mon Jan _2 15:04:05 2006"), data.End.Add(time.Second*-1).Format("Mon Jan _2 15:04:05 2006")))
+	if data.AccountName != "" {
+		fmt.Fprintln(r.out, fmt.Sprintf("| Account: %s", data.AccountName))
+	}
+	if data.PeriodLabel != "" {
+		fmt.Fprintln(r.out, fmt.Sprintf("| Period: %s", data.PeriodLabel))
+	}
+	fmt.Fprintln(r.out, separator)
 
 	header := []string{"User", "Email",
-		"Weekday Hours", "Weekday Days", "Weekend Hours", "Weekend Days", "Bank Holiday Hours", "Bank Holiday Days",
-		"Total Weekday Amount (" + r.currency + ")", "Total Weekend Amount (" + r.currency + ")",
-		"Total Bank Holiday Amount (" + r.currency + ")", "Total  Amount (" + r.currency + ")"}
+		r.hoursHeader("Weekday"), "Weekday Days", r.hoursHeader("Weekend"), "Weekend Days", r.hoursHeader("Bank Holiday"), "Bank Holiday Days",
+		r.amountHeader("Total Weekday Amount"), r.amountHeader("Total Weekend Amount"),
+		r.amountHeader("Total Bank Holiday Amount"), r.totalAmountHeader()}
+	header = append(header, data.ExtraColumns...)
 
 	for _, scheduleData := range data.SchedulesData {
 		err := r.writeSingleRotation(scheduleData, data, header)
@@ -49,9 +133,9 @@ func (r *csvReport) GenerateReport(data *PrintableData) (string, error) {
 		return "", err
 	}
 	defer file.Close()
-	w := csv.NewWriter(file)
+	w := r.newWriter(file)
 
-	if err := w.Write(header); err != nil {
+	if err := writeRecord(w, header); err != nil {
 		log.Println("error writing record to csv:", err)
 		return "", err
 
@@ -62,7 +146,7 @@ func (r *csvReport) GenerateReport(data *PrintableData) (string, error) {
 	})
 
 	for _, userData := range data.UsersSchedulesSummary {
-		err := writeUser(userData, w)
+		err := r.writeUser(userData, data.ExtraColumns, w)
 		if err != nil {
 			log.Println("error writing user record to csv: ", filename, " user: ", userData.Name, " err: ", err)
 			return "", err
@@ -71,17 +155,80 @@ func (r *csvReport) GenerateReport(data *PrintableData) (string, error) {
 
 	w.Flush()
 	if err := w.Error(); err != nil {
-		log.Fatal("Error flushing writr", err)
+		log.Println("Error flushing report file: ", filename, err)
 		return "", err
 	}
+	r.files = append(r.files, filename)
+
+	if r.options.PerUser {
+		for _, userData := range data.UsersSchedulesSummary {
+			if err := r.writeUserReport(userData, data, header); err != nil {
+				log.Println("Error creating report for user: ", userData.Name, err)
+				return "", err
+			}
+		}
+	}
 	return fmt.Sprintf("Report successfully generated: file://%s", filename), nil
 }
 
+// userFileName returns the user's email address, or name when unknown, with only the characters safe in file names.
+func userFileName(userData *ScheduleUser) string {
+	if userData.EmailAddress == "" {
+		return SanitizeFileName(userData.Name)
+	}
+	return SanitizeFileName(userData.EmailAddress)
+}
+
+// writeUserReport writes the rows of the user in each schedule, with the schedule in an additional column,
+// followed by the summary of the user as the total.
+func (r *csvReport) writeUserReport(userData *ScheduleUser, data *PrintableData, header []string) error {
+	filename := fmt.Sprintf("%s/pagerduty_oncall_report.%d-%d-user-%s.csv", r.outPath, data.Start.Month(), data.Start.Year(), userFileName(userData))
+	_ = os.Remove(filename)
+	file, err := os.Create(filename)
+	if err != nil {
+		log.Println("Error creating report file: ", filename, err)
+		return err
+	}
+	defer file.Close()
+	w := r.newWriter(file)
+
+	if err := writeRecord(w, append(header[:len(header):len(header)], "Schedule")); err != nil {
+		log.Println("error writing record to csv: ", filename, " err: ", err)
+		return err
+	}
+	for _, scheduleData := range data.SchedulesData {
+		for _, scheduleUser := range scheduleData.RotaUsers {
+			if scheduleUser.Name != userData.Name {
+				continue
+			}
+			if err := r.writeUser(scheduleUser, data.ExtraColumns, w, scheduleData.Name); err != nil {
+				log.Println("error writing user record to csv: ", filename, " schedule: ", scheduleData.ID, " err: ", err)
+				return err
+			}
+		}
+	}
+	if err := r.writeUser(userData, data.ExtraColumns, w, "Total"); err != nil {
+		log.Println("error writing user record to csv: ", filename, " err: ", err)
+		return err
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	r.files = append(r.files, filename)
+	log.Println(fmt.Sprintf("Report successfully generated: file://%s", filename))
+	return nil
+}
+
 func (r *csvReport) writeSingleRotation(scheduleData *ScheduleData, data *PrintableData, header []string) error {
-	fmt.Println(separator)
-	fmt.Println(fmt.Sprintf("| Writing Schedule: '%s' (%s)", scheduleData.Name, scheduleData.ID))
-	fmt.Println(fmt.Sprintf("| Time Range: %s to %s", scheduleData.StartDate.Format(time.RFC822), scheduleData.EndDate.Format(time.RFC822)))
-	fmt.Println(separator)
+	fmt.Fprintln(r.out, separator)
+	fmt.Fprintln(r.out, fmt.Sprintf("| Writing Schedule: '%s' (%s)", scheduleData.Name, scheduleData.ID))
+	fmt.Fprintln(r.out, fmt.Sprintf("| Time Range: %s to %s", scheduleData.StartDate.Format(time.RFC822), scheduleData.EndDate.Format(time.RFC822)))
+	for _, note := range scheduleData.Notes {
+		fmt.Fprintln(r.out, fmt.Sprintf("| %s", note))
+	}
+	fmt.Fprintln(r.out, separator)
 	noSpaceName := strings.Replace(scheduleData.Name, " ", "_", -1)
 
 	filename := fmt.Sprintf("%s/pagerduty_oncall_report.%d-%d-%s-%s.csv", r.outPath, data.Start.Month(), data.Start.Year(), noSpaceName, scheduleData.ID)
@@ -92,34 +239,91 @@ func (r *csvReport) writeSingleRotation(scheduleData *ScheduleData, data *Printa
 		return err
 	}
 	defer file.Close()
-	w := csv.NewWriter(file)
+	w := r.newWriter(file)
+
+	// the users grouped by team get an additional column with the team name
+	if len(scheduleData.TeamGroups) > 0 {
+		header = append(header[:len(header):len(header)], "Team")
+	}
+	if scheduleData.URL != "" {
+		header = append(header[:len(header):len(header)], "schedule_url")
+	}
 
-	if err := w.Write(header); err != nil {
+	if err := writeRecord(w, header); err != nil {
 		log.Println("error writing record to csv: ", filename, " err: ", err)
 		return err
 
 	}
-	sort.Slice(scheduleData.RotaUsers, func(i, j int) bool {
-		return strings.Compare(scheduleData.RotaUsers[i].Name, scheduleData.RotaUsers[j].Name) < 1
-	})
+	for _, group := range scheduleData.userGroups() {
+		var values []string
+		if group.Name != "" {
+			values = append(values, group.Name)
+		}
+		if scheduleData.URL != "" {
+			values = append(values, scheduleData.URL)
+		}
+		for _, userData := range group.Users {
+			err := r.writeUser(userData, data.ExtraColumns, w, values...)
+			if err != nil {
+				log.Println("error writing user record to csv: ", filename, " user: ", userData.Name, " err: ", err)
+				return err
+			}
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		log.Println("Error flushing report file: ", filename, err)
+		return err
+	}
+	r.files = append(r.files, filename)
+	log.Println(fmt.Sprintf("Report successfully generated: file://%s", filename))
 
-	for _, userData := range scheduleData.RotaUsers {
-		err := writeUser(userData, w)
-		if err != nil {
-			log.Println("error writing user record to csv: ", filename, " user: ", userData.Name, " err: ", err)
+	if len(scheduleData.HourlyBreakdown) > 0 {
+		return r.writeHourlyBreakdown(scheduleData, data, noSpaceName)
+	}
+	return nil
+}
+
+func (r *csvReport) writeHourlyBreakdown(scheduleData *ScheduleData, data *PrintableData, noSpaceName string) error {
+	filename := fmt.Sprintf("%s/pagerduty_oncall_report.%d-%d-%s-%s-hourly.csv", r.outPath, data.Start.Month(), data.Start.Year(), noSpaceName, scheduleData.ID)
+	_ = os.Remove(filename)
+	file, err := os.Create(filename)
+	if err != nil {
+		log.Println("Error creating report file: ", filename, err)
+		return err
+	}
+	defer file.Close()
+	w := r.newWriter(file)
+
+	if err := writeRecord(w, []string{"Hour", "Users"}); err != nil {
+		log.Println("error writing record to csv: ", filename, " err: ", err)
+		return err
+	}
+	for _, hour := range scheduleData.HourlyBreakdown {
+		if err := writeRecord(w, []string{hour.Start.Format(hourlyBreakdownFormat), hour.usersLine()}); err != nil {
+			log.Println("error writing record to csv: ", filename, " err: ", err)
 			return err
 		}
 	}
 	w.Flush()
 	if err := w.Error(); err != nil {
-		log.Fatal("Error flushing writr", err)
 		return err
 	}
+	r.files = append(r.files, filename)
 	log.Println(fmt.Sprintf("Report successfully generated: file://%s", filename))
 	return nil
 }
 
-func writeUser(userData *ScheduleUser, w *csv.Writer) error {
+// formatAmount returns the amount with the decimal places of the currency, or as a whole number of minor units
+// when configured.
+func (r *csvReport) formatAmount(amount float32) string {
+	if r.options.MinorUnit != "" {
+		return fmt.Sprintf("%d", int64(math.Round(float64(amount)*float64(r.options.MinorUnitsPerUnit))))
+	}
+	return FormatAmount("", amount, r.decimals)
+}
+
+func (r *csvReport) writeUser(userData *ScheduleUser, extraColumns []string, w *csv.Writer, values ...string) error {
 	dat := []string{userData.Name, userData.EmailAddress,
 		fmt.Sprintf("%v", userData.NumWorkHours),
 		fmt.Sprintf("%.1f", userData.NumWorkDays),
@@ -127,11 +331,13 @@ func writeUser(userData *ScheduleUser, w *csv.Writer) error {
 		fmt.Sprintf("%.1f", userData.NumWeekendDays),
 		fmt.Sprintf("%v", userData.NumBankHolidaysHours),
 		fmt.Sprintf("%.1f", userData.NumBankHolidaysDays),
-		fmt.Sprintf("%.2f", userData.TotalAmountWorkHours),
-		fmt.Sprintf("%.2f", userData.TotalAmountWeekendHours),
-		fmt.Sprintf("%.2f", userData.TotalAmountBankHolidaysHours),
-		fmt.Sprintf("%.2f", userData.TotalAmount)}
-	if err := w.Write(dat); err != nil {
+		r.formatAmount(userData.TotalAmountWorkHours),
+		r.formatAmount(userData.TotalAmountWeekendHours),
+		r.formatAmount(userData.TotalAmountBankHolidaysHours),
+		r.formatAmount(userData.TotalAmount)}
+	dat = append(dat, userData.extraValues(extraColumns)...)
+	dat = append(dat, values...)
+	if err := writeRecord(w, dat); err != nil {
 		log.Println("error writing record to csv:", err)
 		return err
 	}
@@ -0,0 +1,142 @@
+package report
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+const invoiceRowFormat = "%-60s %12s %16s"
+
+// Invoice is the bill of the on-call work of a report, for contractor-style arrangements.
+type Invoice struct {
+	Number   string
+	DueDate  time.Time
+	Client   string
+	TaxRate  float32 // percentage added to the subtotal, no tax line if 0
+	Currency string
+	Decimals int    // decimal places of the amounts
+	Logo     string // path of a PNG or JPEG image shown in the top-left corner, if any
+}
+
+type invoiceLine struct {
+	description string
+	hours       float32
+	amount      float32
+}
+
+// lines returns a line item per schedule with the on-call hours and amount of all its users.
+func (i *Invoice) lines(data *PrintableData) []invoiceLine {
+	lines := make([]invoiceLine, 0, len(data.SchedulesData))
+	for _, scheduleData := range data.SchedulesData {
+		line := invoiceLine{description: scheduleData.Name}
+		for _, user := range scheduleData.RotaUsers {
+			line.hours += user.NumTotalHours()
+			line.amount += user.TotalAmount
+		}
+		line.amount = i.round(line.amount)
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (i *Invoice) round(amount float32) float32 {
+	scale := math.Pow10(i.Decimals)
+	return float32(math.Round(float64(amount)*scale) / scale)
+}
+
+// totals returns the subtotal of the lines, the tax on it and the total to pay.
+func (i *Invoice) totals(lines []invoiceLine) (float32, float32, float32) {
+	var subtotal float32
+	for _, line := range lines {
+		subtotal += line.amount
+	}
+	subtotal = i.round(subtotal)
+	tax := i.round(subtotal * i.TaxRate / 100)
+	return subtotal, tax, subtotal + tax
+}
+
+// FileName returns the name of the invoice file in the output path.
+func (i *Invoice) FileName(outPath string) string {
+	return fmt.Sprintf("%s/invoice.%s.pdf", outPath, SanitizeFileName(i.Number))
+}
+
+// WriteInvoice writes the invoice of the report as a pdf file, separate from the pdf report, in the output path.
+func WriteInvoice(invoice Invoice, data *PrintableData, outPath string) (string, error) {
+	log.Println("Generating invoice...")
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.AddPage()
+
+	if invoice.Logo != "" {
+		logo := pdf.RegisterImageOptions(invoice.Logo, gofpdf.ImageOptions{ReadDpi: true})
+		if err := pdf.Error(); err != nil {
+			return "", fmt.Errorf("failed to load the invoice logo: %w", err)
+		}
+		const logoWidth = 40
+		pdf.ImageOptions(invoice.Logo, 10, 10, logoWidth, 0, false, gofpdf.ImageOptions{ReadDpi: true}, 0, "")
+		pdf.SetY(10 + logoWidth*logo.Height()/logo.Width() + 6)
+	}
+
+	pdf.SetFont("Arial", "B", 20)
+	pdf.CellFormat(0, 10, "INVOICE", "", 0, "L", false, 0, "")
+	pdf.Ln(14)
+
+	pdf.SetFont("Arial", "", 11)
+	details := [][2]string{
+		{"Invoice number", invoice.Number},
+		{"Date", time.Now().Format("02/01/2006")},
+		{"Due date", invoice.DueDate.Format("02/01/2006")},
+		{"Client", invoice.Client},
+		{"Period", fmt.Sprintf("%s to %s", data.Start.Format("02/01/2006"), data.End.Add(time.Second*-1).Format("02/01/2006"))},
+	}
+	if data.PeriodLabel != "" {
+		details = append(details, [2]string{"", data.PeriodLabel})
+	}
+	for _, detail := range details {
+		pdf.CellFormat(40, 6, detail[0], "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 6, tr(detail[1]), "", 0, "L", false, 0, "")
+		pdf.Ln(6)
+	}
+	pdf.Ln(8)
+
+	pdf.SetFont("Courier", "B", 9)
+	pdf.CellFormat(0, 6, fmt.Sprintf(invoiceRowFormat, "DESCRIPTION", "HOURS", "AMOUNT"), "B", 0, "L", false, 0, "")
+	pdf.Ln(7)
+
+	pdf.SetFont("Courier", "", 9)
+	lines := invoice.lines(data)
+	for _, line := range lines {
+		pdf.CellFormat(0, 6, tr(fmt.Sprintf(invoiceRowFormat, fmt.Sprintf("On-call cover: %s", line.description),
+			fmt.Sprintf("%v h", line.hours), FormatAmount(invoice.Currency, line.amount, invoice.Decimals))), "", 0, "L", false, 0, "")
+		pdf.Ln(6)
+	}
+
+	subtotal, tax, total := invoice.totals(lines)
+	pdf.CellFormat(0, 2, "", "B", 0, "L", false, 0, "")
+	pdf.Ln(4)
+	pdf.CellFormat(0, 6, tr(fmt.Sprintf(invoiceRowFormat, "Subtotal", "", FormatAmount(invoice.Currency, subtotal, invoice.Decimals))), "", 0, "L", false, 0, "")
+	pdf.Ln(6)
+	if invoice.TaxRate != 0 {
+		pdf.CellFormat(0, 6, tr(fmt.Sprintf(invoiceRowFormat, fmt.Sprintf("Tax (%v%%)", invoice.TaxRate), "",
+			FormatAmount(invoice.Currency, tax, invoice.Decimals))), "", 0, "L", false, 0, "")
+		pdf.Ln(6)
+	}
+	pdf.SetFont("Courier", "B", 9)
+	pdf.CellFormat(0, 6, tr(fmt.Sprintf(invoiceRowFormat, "Total due", "", FormatAmount(invoice.Currency, total, invoice.Decimals))), "", 0, "L", false, 0, "")
+
+	filename := invoice.FileName(outPath)
+	_ = os.Remove(filename)
+
+	err := pdf.OutputFileAndClose(filename)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Invoice successfully generated: file://%s", filename), nil
+}
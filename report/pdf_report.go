@@ -19,16 +19,24 @@ const (
 
 type pdfReport struct {
 	currency string
+	decimals int
 	outPath  string
+	files    []string
 }
 
-func NewPDFReport(currency string, outPath string) Writer {
+func NewPDFReport(currency string, decimals int, outPath string) Writer {
 	return &pdfReport{
 		currency: currency,
+		decimals: decimals,
 		outPath:  outPath,
 	}
 }
 
+// Files returns the file written by GenerateReport.
+func (r *pdfReport) Files() []string {
+	return r.files
+}
+
 func (r *pdfReport) GenerateReport(data *PrintableData) (string, error) {
 
 	log.Println("Generating pdf report...")
@@ -48,17 +56,43 @@ func (r *pdfReport) GenerateReport(data *PrintableData) (string, error) {
 		pdf.CellFormat(0, 10,
 			fmt.Sprintf("PagerDuty oncall report(s) from %s to %s ", data.Start.Format("02/01/2006"), data.End.Add(time.Second*-1).Format("02/01/2006")),
 			"R", 0, "R", false, 0, "")
+		subtitle := make([]string, 0, 2)
+		if data.AccountName != "" {
+			subtitle = append(subtitle, data.AccountName)
+		}
+		if data.PeriodLabel != "" {
+			subtitle = append(subtitle, data.PeriodLabel)
+		}
+		if len(subtitle) > 0 {
+			pdf.Ln(6)
+			pdf.SetFont("Arial", "", 11)
+			pdf.CellFormat(0, 10, tr(strings.Join(subtitle, " - ")), "R", 0, "R", false, 0, "")
+			pdf.Ln(14)
+			return
+		}
 		pdf.Ln(20)
 	})
 
 	pdf.AddPage()
 
-	for _, scheduleData := range data.SchedulesData {
+	// the bottom border closes the user rows, after the additional columns if any
+	userRowBorder := "B"
+	if len(data.ExtraColumns) > 0 {
+		userRowBorder = ""
+	}
+
+	for i, scheduleData := range data.SchedulesData {
+		if data.startsGroup(i) {
+			pdf.SetFont("Arial", "B", 15)
+			pdf.SetFillColor(200, 200, 200)
+			pdf.CellFormat(0, 8, tr(scheduleData.Group), "", 0, "L", true, 0, "")
+			pdf.Ln(12)
+		}
 
 		pdf.SetFont("Arial", "B", 13)
 		pdf.CellFormat(0, 5,
 			fmt.Sprintf("  Schedule name: '%s'", scheduleData.Name),
-			"L", 0, "L", false, 0, "")
+			"L", 0, "L", false, 0, scheduleData.URL)
 		pdf.Ln(8)
 		pdf.CellFormat(0, 5,
 			fmt.Sprintf("  Schedule ID: %s", scheduleData.ID),
@@ -70,6 +104,11 @@ func (r *pdfReport) GenerateReport(data *PrintableData) (string, error) {
 			"L", 0, "L", false, 0, "")
 		pdf.Ln(8)
 
+		for _, note := range scheduleData.Notes {
+			pdf.CellFormat(0, 5, tr(note), "L", 0, "L", false, 0, "")
+			pdf.Ln(8)
+		}
+
 		pdf.SetFont("Courier", "B", 8)
 		pdf.CellFormat(0, 5,
 			fmt.Sprintf(matrixRowFormat, "USER", "WEEKDAY", "WEEKEND", "B. HOLIDAY", "WEEKDAY", "WEEKEND", "B. HOLIDAY", "TOTAL"),
@@ -86,30 +125,60 @@ func (r *pdfReport) GenerateReport(data *PrintableData) (string, error) {
 
 		pdf.SetFont("Courier", "", 8)
 
-		sort.Slice(scheduleData.RotaUsers, func(i, j int) bool {
-			return strings.Compare(scheduleData.RotaUsers[i].Name, scheduleData.RotaUsers[j].Name) < 1
-		})
-
-		for _, userData := range scheduleData.RotaUsers {
-			pdf.CellFormat(0, 5,
-				fmt.Sprintf(matrixRowFormat, tr(userData.Name),
-					fmt.Sprintf("%v h", userData.NumWorkHours),
-					fmt.Sprintf("%v h", userData.NumWeekendHours),
-					fmt.Sprintf("%v h", userData.NumBankHolidaysHours),
-					tr(fmt.Sprintf("%s%.2f", r.currency, userData.TotalAmountWorkHours)),
-					tr(fmt.Sprintf("%s%.2f", r.currency, userData.TotalAmountWeekendHours)),
-					tr(fmt.Sprintf("%s%.2f", r.currency, userData.TotalAmountBankHolidaysHours)),
-					tr(fmt.Sprintf("%s%.2f", r.currency, userData.TotalAmount))),
-				"", 0, "L", false, 0, "")
-			pdf.Ln(3)
-			pdf.CellFormat(0, 5,
-				fmt.Sprintf(matrixRowFormat, tr(userData.EmailAddress),
-					fmt.Sprintf("%.1f d", userData.NumWorkDays),
-					fmt.Sprintf("%.1f d", userData.NumWeekendDays),
-					fmt.Sprintf("%.1f d", userData.NumBankHolidaysDays),
-					"", "", "", ""),
-				"B", 0, "L", false, 0, "")
+		for _, group := range scheduleData.userGroups() {
+			if group.Name != "" {
+				pdf.SetFont("Arial", "B", 10)
+				pdf.SetFillColor(225, 225, 225)
+				pdf.CellFormat(0, 6, tr(fmt.Sprintf("Team: %s", group.Name)), "B", 0, "L", true, 0, "")
+				pdf.Ln(7)
+				pdf.SetFont("Courier", "", 8)
+			}
+			for _, userData := range group.Users {
+				pdf.CellFormat(0, 5,
+					fmt.Sprintf(matrixRowFormat, tr(userData.Name),
+						fmt.Sprintf("%v h", userData.NumWorkHours),
+						fmt.Sprintf("%v h", userData.NumWeekendHours),
+						fmt.Sprintf("%v h", userData.NumBankHolidaysHours),
+						tr(FormatAmount(r.currency, userData.TotalAmountWorkHours, r.decimals)),
+						tr(FormatAmount(r.currency, userData.TotalAmountWeekendHours, r.decimals)),
+						tr(FormatAmount(r.currency, userData.TotalAmountBankHolidaysHours, r.decimals)),
+						tr(FormatAmount(r.currency, userData.TotalAmount, r.decimals))),
+					"", 0, "L", false, 0, "")
+				pdf.Ln(3)
+				pdf.CellFormat(0, 5,
+					fmt.Sprintf(matrixRowFormat, tr(userData.EmailAddress),
+						fmt.Sprintf("%.1f d", userData.NumWorkDays),
+						fmt.Sprintf("%.1f d", userData.NumWeekendDays),
+						fmt.Sprintf("%.1f d", userData.NumBankHolidaysDays),
+						"", "", "", ""),
+					userRowBorder, 0, "L", false, 0, "")
+				pdf.Ln(5)
+				if len(data.ExtraColumns) > 0 {
+					pdf.CellFormat(0, 5, tr(userData.extraValuesLine(data.ExtraColumns)), "B", 0, "L", false, 0, "")
+					pdf.Ln(5)
+				}
+			}
+			if group.Name != "" {
+				pdf.SetFont("Courier", "B", 8)
+				pdf.CellFormat(0, 5,
+					fmt.Sprintf(matrixRowFormat, tr(fmt.Sprintf("%s subtotal", group.Name)), "", "", "", "", "", "",
+						tr(FormatAmount(r.currency, group.TotalAmount(), r.decimals))),
+					"B", 0, "L", false, 0, "")
+				pdf.Ln(7)
+				pdf.SetFont("Courier", "", 8)
+			}
+		}
+
+		if len(scheduleData.HourlyBreakdown) > 0 {
 			pdf.Ln(5)
+			pdf.SetFont("Arial", "B", 11)
+			pdf.CellFormat(0, 5, "Hourly breakdown", "B", 0, "L", false, 0, "")
+			pdf.Ln(6)
+			pdf.SetFont("Courier", "", 8)
+			for _, hour := range scheduleData.HourlyBreakdown {
+				pdf.CellFormat(0, 4, tr(fmt.Sprintf("%s  %s", hour.Start.Format(hourlyBreakdownFormat), hour.usersLine())), "", 0, "L", false, 0, "")
+				pdf.Ln(4)
+			}
 		}
 
 		pdf.Ln(10)
@@ -147,10 +216,10 @@ func (r *pdfReport) GenerateReport(data *PrintableData) (string, error) {
 				fmt.Sprintf("%v h", userData.NumWorkHours),
 				fmt.Sprintf("%v h", userData.NumWeekendHours),
 				fmt.Sprintf("%v h", userData.NumBankHolidaysHours),
-				tr(fmt.Sprintf("%s%.2f", r.currency, userData.TotalAmountWorkHours)),
-				tr(fmt.Sprintf("%s%.2f", r.currency, userData.TotalAmountWeekendHours)),
-				tr(fmt.Sprintf("%s%.2f", r.currency, userData.TotalAmountBankHolidaysHours)),
-				tr(fmt.Sprintf("%s%.2f", r.currency, userData.TotalAmount))),
+				tr(FormatAmount(r.currency, userData.TotalAmountWorkHours, r.decimals)),
+				tr(FormatAmount(r.currency, userData.TotalAmountWeekendHours, r.decimals)),
+				tr(FormatAmount(r.currency, userData.TotalAmountBankHolidaysHours, r.decimals)),
+				tr(FormatAmount(r.currency, userData.TotalAmount, r.decimals))),
 			"", 0, "L", false, 0, "")
 		pdf.Ln(3)
 		pdf.CellFormat(0, 5,
@@ -159,8 +228,12 @@ func (r *pdfReport) GenerateReport(data *PrintableData) (string, error) {
 				fmt.Sprintf("%.1f d", userData.NumWeekendDays),
 				fmt.Sprintf("%.1f d", userData.NumBankHolidaysDays),
 				"", "", "", ""),
-			"B", 0, "L", false, 0, "")
+			userRowBorder, 0, "L", false, 0, "")
 		pdf.Ln(5)
+		if len(data.ExtraColumns) > 0 {
+			pdf.CellFormat(0, 5, tr(userData.extraValuesLine(data.ExtraColumns)), "B", 0, "L", false, 0, "")
+			pdf.Ln(5)
+		}
 	}
 
 	filename := fmt.Sprintf("%s/pagerduty_oncall_report.%d-%d.pdf", r.outPath, data.Start.Month(), data.Start.Year())
@@ -170,6 +243,7 @@ func (r *pdfReport) GenerateReport(data *PrintableData) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	r.files = []string{filename}
 
 	return fmt.Sprintf("Report successfully generated: file://%s", filename), nil
 }
\ No newline at end of file
@@ -1,14 +1,19 @@
 package report
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
 type PrintableData struct {
+	AccountName           string
+	PeriodLabel           string // human-readable name of the report period, e.g. "Q1 2024"
 	Start                 time.Time
 	End                   time.Time
 	SchedulesData         []*ScheduleData
 	UsersSchedulesSummary []*ScheduleUser
+	ExtraColumns          []string // additional per-user columns, read from ScheduleUser.ExtraValues
 }
 
 type ScheduleData struct {
@@ -17,6 +22,81 @@ type ScheduleData struct {
 	StartDate time.Time
 	EndDate   time.Time
 	RotaUsers []*ScheduleUser
+	Notes     []string // additional information about the schedule, printed below its header
+	URL       string   // link to the schedule in PagerDuty, if requested
+	TimeZone  string   // time zone the schedule hours are calculated in
+	Group     string   // heading of the group of schedules it is listed in, if grouped
+
+	HourlyBreakdown []HourOnCall // who was on call at each calendar hour of the period, if requested
+	TeamGroups      []TeamGroup  // RotaUsers grouped by team, if requested
+}
+
+// TeamGroup holds the users of a schedule that belong to a team.
+type TeamGroup struct {
+	Name  string
+	Users []*ScheduleUser
+}
+
+// TotalAmount returns the amount to pay to the users of the group.
+func (g TeamGroup) TotalAmount() float32 {
+	var total float32
+	for _, user := range g.Users {
+		total += user.TotalAmount
+	}
+	return total
+}
+
+// userGroups returns the team groups of the schedule, or a single unnamed group with all
+// the users when they are not grouped.
+func (s *ScheduleData) userGroups() []TeamGroup {
+	if len(s.TeamGroups) > 0 {
+		return s.TeamGroups
+	}
+	return []TeamGroup{{Users: s.RotaUsers}}
+}
+
+// HourOnCall lists the users on call during the hour starting at Start.
+type HourOnCall struct {
+	Start time.Time
+	Users []string
+}
+
+func (h HourOnCall) usersLine() string {
+	if len(h.Users) == 0 {
+		return "nobody"
+	}
+	return strings.Join(h.Users, ", ")
+}
+
+// startsGroup reports whether the schedule at the index is the first one of its group.
+func (d *PrintableData) startsGroup(i int) bool {
+	group := d.SchedulesData[i].Group
+	return group != "" && (i == 0 || d.SchedulesData[i-1].Group != group)
+}
+
+// AmountPlaceholder is replaced by the amount in a currency given as a format, e.g. "£{amount} GBP".
+const AmountPlaceholder = "{amount}"
+
+// FormatAmount returns the amount preceded by the currency symbol, or in place of the AmountPlaceholder of the
+// currency format, with the given decimal places.
+func FormatAmount(currency string, amount float32, decimals int) string {
+	number := fmt.Sprintf("%.*f", decimals, amount)
+	if strings.Contains(currency, AmountPlaceholder) {
+		return strings.Replace(currency, AmountPlaceholder, number, 1)
+	}
+	return currency + number
+}
+
+const hourlyBreakdownFormat = "Mon 02 Jan 2006 15:04 MST"
+
+// SanitizeFileName replaces the characters that aren't safe in file names with underscores.
+func SanitizeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || strings.ContainsRune("@.-_+", r) {
+			return r
+		}
+		return '_'
+	}, name)
 }
 
 type ScheduleUser struct {
@@ -32,8 +112,39 @@ type ScheduleUser struct {
 	NumBankHolidaysDays          float32
 	TotalAmountBankHolidaysHours float32
 	TotalAmount                  float32
+	ExtraValues                  map[string]string
+}
+
+// NumTotalHours returns the on-call hours of the user across all the day types.
+func (u *ScheduleUser) NumTotalHours() float32 {
+	return u.NumWorkHours + u.NumWeekendHours + u.NumBankHolidaysHours
+}
+
+// extraValues returns the values of the user for the given additional columns.
+func (u *ScheduleUser) extraValues(columns []string) []string {
+	values := make([]string, 0, len(columns))
+	for _, column := range columns {
+		values = append(values, u.ExtraValues[column])
+	}
+	return values
+}
+
+// extraValuesLine formats the additional columns of the user as a single line, for the
+// fixed-width layouts.
+func (u *ScheduleUser) extraValuesLine(columns []string) string {
+	fields := make([]string, 0, len(columns))
+	for i, value := range u.extraValues(columns) {
+		fields = append(fields, fmt.Sprintf("%s: %s", columns[i], value))
+	}
+	return strings.Join(fields, ", ")
 }
 
 type Writer interface {
 	GenerateReport(data *PrintableData) (string, error)
 }
+
+// FileWriter is a Writer writing the report to files, which it lists once the report is generated.
+type FileWriter interface {
+	Writer
+	Files() []string
+}
@@ -3,6 +3,7 @@ package test
 import (
 	"testing"
 
+	"github.com/form3tech-oss/go-pagerduty-oncall-report/configuration"
 	"github.com/form3tech-oss/go-pagerduty-oncall-report/test/stages"
 )
 
@@ -58,6 +59,72 @@ func TestFindNonExistingRotationUserInfoById(t *testing.T) {
 		ValueIsNotFound()
 }
 
+func TestFindExistingScheduleSettingsById(t *testing.T) {
+	given, when, then := stages.ConfigTest(t)
+
+	given.
+		AValidConfigurationCorrectlyLoaded()
+
+	when.
+		AnExistingScheduleSettingsIsRequested()
+
+	then.
+		ValueIsFound().And().
+		ScheduleSettingsHaveUserSortKey("hours_desc")
+}
+
+func TestFindNonExistingScheduleSettingsById(t *testing.T) {
+	given, when, then := stages.ConfigTest(t)
+
+	given.
+		AValidConfigurationCorrectlyLoaded()
+
+	when.
+		ANonExistingScheduleSettingsIsRequested()
+
+	then.
+		NoValueIsReturned()
+}
+
+func TestFindEffectivePriceByDayPrefersUserPrices(t *testing.T) {
+	given, when, then := stages.ConfigTest(t)
+
+	given.
+		AValidConfigurationCorrectlyLoaded()
+
+	when.
+		TheWeekendPriceIsRequested("SCHED_4", "ABCDEF1")
+
+	then.
+		ThePriceIs(3, configuration.PriceSourceUser)
+}
+
+func TestFindEffectivePriceByDayPrefersSchedulePrices(t *testing.T) {
+	given, when, then := stages.ConfigTest(t)
+
+	given.
+		AValidConfigurationCorrectlyLoaded()
+
+	when.
+		TheWeekendPriceIsRequested("SCHED_4", "ABCDEF2")
+
+	then.
+		ThePriceIs(2, configuration.PriceSourceSchedule)
+}
+
+func TestFindEffectivePriceByDayDefaultsToGlobalPrices(t *testing.T) {
+	given, when, then := stages.ConfigTest(t)
+
+	given.
+		AValidConfigurationCorrectlyLoaded()
+
+	when.
+		TheWeekendPriceIsRequested("SCHED_5", "ABCDEF2")
+
+	then.
+		ThePriceIs(1, configuration.PriceSourceGlobal)
+}
+
 func TestConfigurationMalformed(t *testing.T) {
 	given, when, then := stages.ConfigTest(t)
 
@@ -20,6 +20,8 @@ type ConfigStage struct {
 
 	mapValue interface{}
 	mapError error
+
+	priceSource string
 }
 
 func ConfigTest(t *testing.T) (*ConfigStage, *ConfigStage, *ConfigStage) {
@@ -53,9 +55,18 @@ rotationUsers:
   - name: "User 1"
     holidaysCalendar: uk
     userId: ABCDEF1
+    prices:
+    - day: weekend
+      price: 3
   - name: "User 2"
     holidaysCalendar: uk
     userId: ABCDEF2
+scheduleSettings:
+  - id: SCHED_4
+    userSortKey: hours_desc
+    prices:
+    - day: weekend
+      price: 2
 schedulesToIgnore:
   - SCHED_1
   - SCHED_2
@@ -130,6 +141,25 @@ func (s *ConfigStage) ANonExistingRotationInfoIsRequested() *ConfigStage {
 	return s
 }
 
+func (s *ConfigStage) AnExistingScheduleSettingsIsRequested() *ConfigStage {
+	if settings := s.config.FindScheduleSettingsByID("SCHED_4"); settings != nil {
+		s.mapValue = settings
+	}
+	return s
+}
+
+func (s *ConfigStage) ANonExistingScheduleSettingsIsRequested() *ConfigStage {
+	if settings := s.config.FindScheduleSettingsByID("NONE"); settings != nil {
+		s.mapValue = settings
+	}
+	return s
+}
+
+func (s *ConfigStage) TheWeekendPriceIsRequested(scheduleID, userID string) *ConfigStage {
+	s.mapValue, s.priceSource, s.mapError = s.config.FindEffectivePriceByDay("weekend", scheduleID, userID)
+	return s
+}
+
 func (s *ConfigStage) ValueIsFound() *ConfigStage {
 	assert.Nil(s.t, s.mapError)
 	assert.NotNil(s.t, s.mapValue)
@@ -141,6 +171,26 @@ func (s *ConfigStage) ValueIsNotFound() *ConfigStage {
 	assert.Nil(s.t, s.mapValue)
 	return s
 }
+
+func (s *ConfigStage) NoValueIsReturned() *ConfigStage {
+	assert.Nil(s.t, s.mapValue)
+	return s
+}
+
+func (s *ConfigStage) ScheduleSettingsHaveUserSortKey(userSortKey string) *ConfigStage {
+	settings, ok := s.mapValue.(*configuration.ScheduleSettings)
+	assert.True(s.t, ok)
+	assert.Equal(s.t, userSortKey, settings.UserSortKey)
+	return s
+}
+
+func (s *ConfigStage) ThePriceIs(price int, source string) *ConfigStage {
+	assert.Nil(s.t, s.mapError)
+	assert.Equal(s.t, price, s.mapValue)
+	assert.Equal(s.t, source, s.priceSource)
+	return s
+}
+
 func (s *ConfigStage) ConfigErrorIsCreated() *ConfigStage {
 	assert.NotNil(s.t, s.configError)
 	return s